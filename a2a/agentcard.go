@@ -0,0 +1,198 @@
+// Copyright 2025 The A2A Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package a2a
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"google.golang.org/protobuf/encoding/protojson"
+
+	a2apb "github.com/a2aproject/a2a-go/grpc"
+)
+
+// WellKnownAgentCardPath is the path an agent publishes its public AgentCard at,
+// relative to the base URL of the agent server.
+const WellKnownAgentCardPath = "/.well-known/agent-card.json"
+
+// ExtendedAgentCardPath is the path an agent which sets SupportsAuthenticatedExtendedCard
+// serves its extended AgentCard at to authenticated clients, relative to the base URL
+// of the agent server.
+const ExtendedAgentCardPath = "/agent/authenticatedExtendedCard"
+
+// The JSON representation of an AgentCard defined by the specification mostly matches
+// the protojson encoding of the proto message. The exceptions are security schemes, which
+// use a "type" discriminator instead of a oneof field, and security requirements, which
+// are maps of scheme names to lists of scopes instead of StringList messages.
+
+// MarshalAgentCard encodes an AgentCard using the JSON format defined by the specification.
+func MarshalAgentCard(card *a2apb.AgentCard) ([]byte, error) {
+	data, err := protojson.Marshal(card)
+	if err != nil {
+		return nil, err
+	}
+	var obj map[string]any
+	if err := json.Unmarshal(data, &obj); err != nil {
+		return nil, err
+	}
+	if schemes, ok := obj["securitySchemes"].(map[string]any); ok {
+		for name, scheme := range schemes {
+			converted, err := securitySchemeToSpec(scheme)
+			if err != nil {
+				return nil, fmt.Errorf("security scheme %q: %w", name, err)
+			}
+			schemes[name] = converted
+		}
+	}
+	if security, ok := obj["security"].([]any); ok {
+		for i, requirement := range security {
+			security[i] = securityRequirementToSpec(requirement)
+		}
+	}
+	// Fields required by the specification are emitted even if they are empty.
+	defaults := map[string]any{
+		"capabilities":       map[string]any{},
+		"defaultInputModes":  []any{},
+		"defaultOutputModes": []any{},
+		"skills":             []any{},
+	}
+	for k, v := range defaults {
+		if _, ok := obj[k]; !ok {
+			obj[k] = v
+		}
+	}
+	return json.Marshal(obj)
+}
+
+// UnmarshalAgentCard decodes an AgentCard from the JSON format defined by the specification.
+// Fields which have no representation in the proto message are ignored.
+func UnmarshalAgentCard(data []byte) (*a2apb.AgentCard, error) {
+	var obj map[string]any
+	if err := json.Unmarshal(data, &obj); err != nil {
+		return nil, err
+	}
+	if schemes, ok := obj["securitySchemes"].(map[string]any); ok {
+		for name, scheme := range schemes {
+			converted, err := securitySchemeFromSpec(scheme)
+			if err != nil {
+				return nil, fmt.Errorf("security scheme %q: %w", name, err)
+			}
+			schemes[name] = converted
+		}
+	}
+	if security, ok := obj["security"].([]any); ok {
+		for i, requirement := range security {
+			security[i] = securityRequirementFromSpec(requirement)
+		}
+	}
+	normalized, err := json.Marshal(obj)
+	if err != nil {
+		return nil, err
+	}
+	var card a2apb.AgentCard
+	if err := (protojson.UnmarshalOptions{DiscardUnknown: true}).Unmarshal(normalized, &card); err != nil {
+		return nil, err
+	}
+	return &card, nil
+}
+
+// securitySchemeTypes maps the "type" discriminator values to the proto oneof field names.
+var securitySchemeTypes = map[string]string{
+	"apiKey":        "apiKeySecurityScheme",
+	"http":          "httpAuthSecurityScheme",
+	"oauth2":        "oauth2SecurityScheme",
+	"openIdConnect": "openIdConnectSecurityScheme",
+}
+
+// oauthFlowPreference defines which flow is kept when a scheme declares more than one,
+// since the proto message can only hold a single flow.
+var oauthFlowPreference = []string{"clientCredentials", "authorizationCode", "password", "implicit"}
+
+func securitySchemeFromSpec(v any) (any, error) {
+	scheme, ok := v.(map[string]any)
+	if !ok {
+		return nil, errors.New("security scheme must be an object")
+	}
+	typ, _ := scheme["type"].(string)
+	field, ok := securitySchemeTypes[typ]
+	if !ok {
+		return nil, fmt.Errorf("unsupported security scheme type %q", typ)
+	}
+	delete(scheme, "type")
+	if typ == "apiKey" {
+		scheme["location"] = scheme["in"]
+		delete(scheme, "in")
+	}
+	if flows, ok := scheme["flows"].(map[string]any); ok {
+		for _, name := range oauthFlowPreference {
+			if flow, ok := flows[name]; ok {
+				scheme["flows"] = map[string]any{name: flow}
+				break
+			}
+		}
+	}
+	return map[string]any{field: scheme}, nil
+}
+
+func securitySchemeToSpec(v any) (any, error) {
+	wrapper, ok := v.(map[string]any)
+	if !ok {
+		return nil, errors.New("security scheme must be an object")
+	}
+	for typ, field := range securitySchemeTypes {
+		scheme, ok := wrapper[field].(map[string]any)
+		if !ok {
+			continue
+		}
+		scheme["type"] = typ
+		if typ == "apiKey" {
+			scheme["in"] = scheme["location"]
+			delete(scheme, "location")
+		}
+		return scheme, nil
+	}
+	return nil, errors.New("security scheme is not set")
+}
+
+func securityRequirementFromSpec(v any) any {
+	requirement, ok := v.(map[string]any)
+	if !ok {
+		return v
+	}
+	schemes := make(map[string]any, len(requirement))
+	for name, scopes := range requirement {
+		schemes[name] = map[string]any{"list": scopes}
+	}
+	return map[string]any{"schemes": schemes}
+}
+
+func securityRequirementToSpec(v any) any {
+	wrapper, ok := v.(map[string]any)
+	if !ok {
+		return v
+	}
+	schemes, _ := wrapper["schemes"].(map[string]any)
+	requirement := make(map[string]any, len(schemes))
+	for name, scopes := range schemes {
+		stringList, _ := scopes.(map[string]any)
+		list := stringList["list"]
+		if list == nil {
+			list = []any{}
+		}
+		requirement[name] = list
+	}
+	return requirement
+}
@@ -0,0 +1,119 @@
+// Copyright 2025 The A2A Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package a2a
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"google.golang.org/protobuf/proto"
+
+	a2apb "github.com/a2aproject/a2a-go/grpc"
+)
+
+func TestAgentCardJSON(t *testing.T) {
+	card := &a2apb.AgentCard{
+		Name:    "agent",
+		Url:     "https://agent.example.com",
+		Version: "1.0.0",
+		SecuritySchemes: map[string]*a2apb.SecurityScheme{
+			"key":    {Scheme: &a2apb.SecurityScheme_ApiKeySecurityScheme{ApiKeySecurityScheme: &a2apb.APIKeySecurityScheme{Location: "header", Name: "X-API-Key"}}},
+			"bearer": {Scheme: &a2apb.SecurityScheme_HttpAuthSecurityScheme{HttpAuthSecurityScheme: &a2apb.HTTPAuthSecurityScheme{Scheme: "Bearer"}}},
+			"oauth": {Scheme: &a2apb.SecurityScheme_Oauth2SecurityScheme{Oauth2SecurityScheme: &a2apb.OAuth2SecurityScheme{
+				Flows: &a2apb.OAuthFlows{Flow: &a2apb.OAuthFlows_ClientCredentials{ClientCredentials: &a2apb.ClientCredentialsOAuthFlow{
+					TokenUrl: "https://auth.example.com/token",
+					Scopes:   map[string]string{"read": "Read access"},
+				}}},
+			}}},
+			"oidc": {Scheme: &a2apb.SecurityScheme_OpenIdConnectSecurityScheme{OpenIdConnectSecurityScheme: &a2apb.OpenIdConnectSecurityScheme{OpenIdConnectUrl: "https://auth.example.com/.well-known/openid-configuration"}}},
+		},
+		Security: []*a2apb.Security{
+			{Schemes: map[string]*a2apb.StringList{"oauth": {List: []string{"read"}}}},
+			{Schemes: map[string]*a2apb.StringList{"key": {}, "bearer": {}}},
+		},
+	}
+
+	data, err := MarshalAgentCard(card)
+	if err != nil {
+		t.Fatalf("MarshalAgentCard() error = %v", err)
+	}
+	var obj struct {
+		Capabilities       map[string]any            `json:"capabilities"`
+		DefaultInputModes  []string                  `json:"defaultInputModes"`
+		Skills             []any                     `json:"skills"`
+		SecuritySchemes    map[string]map[string]any `json:"securitySchemes"`
+		Security           []map[string][]string     `json:"security"`
+		DefaultOutputModes []string                  `json:"defaultOutputModes"`
+	}
+	if err := json.Unmarshal(data, &obj); err != nil {
+		t.Fatalf("json.Unmarshal() error = %v", err)
+	}
+	if obj.Capabilities == nil || obj.DefaultInputModes == nil || obj.DefaultOutputModes == nil || obj.Skills == nil {
+		t.Errorf("MarshalAgentCard() = %s, want required fields to be present", data)
+	}
+	wantTypes := map[string]string{"key": "apiKey", "bearer": "http", "oauth": "oauth2", "oidc": "openIdConnect"}
+	for name, typ := range wantTypes {
+		if got := obj.SecuritySchemes[name]["type"]; got != typ {
+			t.Errorf("security scheme %q type = %v, want %q", name, got, typ)
+		}
+	}
+	if got := obj.SecuritySchemes["key"]["in"]; got != "header" {
+		t.Errorf("apiKey scheme in = %v, want header", got)
+	}
+	wantSecurity := []map[string][]string{{"oauth": {"read"}}, {"key": {}, "bearer": {}}}
+	if !reflect.DeepEqual(obj.Security, wantSecurity) {
+		t.Errorf("security = %v, want %v", obj.Security, wantSecurity)
+	}
+
+	got, err := UnmarshalAgentCard(data)
+	if err != nil {
+		t.Fatalf("UnmarshalAgentCard() error = %v", err)
+	}
+	// Required fields emitted as empty values are decoded as an empty capabilities message.
+	want := proto.Clone(card).(*a2apb.AgentCard)
+	want.Capabilities = &a2apb.AgentCapabilities{}
+	if !proto.Equal(got, want) {
+		t.Errorf("UnmarshalAgentCard(MarshalAgentCard()) = %v, want %v", got, want)
+	}
+}
+
+func TestUnmarshalAgentCardOAuthFlows(t *testing.T) {
+	data := []byte(`{
+		"name": "agent",
+		"securitySchemes": {
+			"oauth": {
+				"type": "oauth2",
+				"flows": {
+					"authorizationCode": {"authorizationUrl": "https://auth.example.com/authorize", "tokenUrl": "https://auth.example.com/token", "scopes": {}},
+					"clientCredentials": {"tokenUrl": "https://auth.example.com/token", "scopes": {"read": ""}}
+				}
+			}
+		},
+		"unknownField": true
+	}`)
+	card, err := UnmarshalAgentCard(data)
+	if err != nil {
+		t.Fatalf("UnmarshalAgentCard() error = %v", err)
+	}
+	flows := card.GetSecuritySchemes()["oauth"].GetOauth2SecurityScheme().GetFlows()
+	if flows.GetClientCredentials().GetTokenUrl() != "https://auth.example.com/token" {
+		t.Errorf("UnmarshalAgentCard() flows = %v, want the client credentials flow", flows)
+	}
+
+	if _, err := UnmarshalAgentCard([]byte(`{"securitySchemes": {"x": {"type": "mutualTLS"}}}`)); err == nil {
+		t.Error("UnmarshalAgentCard() with an unsupported scheme error = nil, want error")
+	}
+}
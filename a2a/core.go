@@ -0,0 +1,266 @@
+// Copyright 2025 The A2A Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package a2a
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// Event is an object which can be produced by an agent while it handles a message.
+// It is implemented by *Task, *Message, *TaskStatusUpdateEvent and *TaskArtifactUpdateEvent.
+type Event interface {
+	isEvent()
+}
+
+// SendMessageResult is the result of a non-streaming message send.
+// It is implemented by *Task and *Message.
+type SendMessageResult interface {
+	Event
+	isSendMessageResult()
+}
+
+func (*Task) isEvent()                    {}
+func (*Message) isEvent()                 {}
+func (*TaskStatusUpdateEvent) isEvent()   {}
+func (*TaskArtifactUpdateEvent) isEvent() {}
+
+func (*Task) isSendMessageResult()    {}
+func (*Message) isSendMessageResult() {}
+
+// UnmarshalEvent decodes a JSON object into an Event using its "kind" discriminator.
+func UnmarshalEvent(data []byte) (Event, error) {
+	var typed struct {
+		Kind string `json:"kind"`
+	}
+	if err := json.Unmarshal(data, &typed); err != nil {
+		return nil, err
+	}
+	var event Event
+	switch typed.Kind {
+	case kindTask:
+		event = &Task{}
+	case kindMessage:
+		event = &Message{}
+	case kindStatusUpdate:
+		event = &TaskStatusUpdateEvent{}
+	case kindArtifactUpdate:
+		event = &TaskArtifactUpdateEvent{}
+	default:
+		return nil, fmt.Errorf("unknown event kind %q", typed.Kind)
+	}
+	if err := json.Unmarshal(data, event); err != nil {
+		return nil, err
+	}
+	return event, nil
+}
+
+const (
+	kindTask           = "task"
+	kindMessage        = "message"
+	kindStatusUpdate   = "status-update"
+	kindArtifactUpdate = "artifact-update"
+)
+
+// TaskState defines the set of states a Task can be in.
+type TaskState string
+
+const (
+	// TaskStateUnknown is used when the state of a task can not be determined.
+	TaskStateUnknown TaskState = "unknown"
+	// TaskStateSubmitted means the task was received and acknowledged by the agent.
+	TaskStateSubmitted TaskState = "submitted"
+	// TaskStateWorking means the task is actively being processed.
+	TaskStateWorking TaskState = "working"
+	// TaskStateInputRequired means the agent is waiting for additional input from the client.
+	TaskStateInputRequired TaskState = "input-required"
+	// TaskStateAuthRequired means the agent is waiting for the client to authenticate.
+	TaskStateAuthRequired TaskState = "auth-required"
+	// TaskStateCompleted is a terminal state of a successfully finished task.
+	TaskStateCompleted TaskState = "completed"
+	// TaskStateCanceled is a terminal state of a task canceled by the client.
+	TaskStateCanceled TaskState = "canceled"
+	// TaskStateFailed is a terminal state of a task which finished with an error.
+	TaskStateFailed TaskState = "failed"
+	// TaskStateRejected is a terminal state of a task the agent decided not to perform.
+	TaskStateRejected TaskState = "rejected"
+)
+
+// MessageRole identifies the sender of a Message.
+type MessageRole string
+
+const (
+	// MessageRoleUser is the role of messages sent by a client.
+	MessageRoleUser MessageRole = "user"
+	// MessageRoleAgent is the role of messages sent by an agent.
+	MessageRoleAgent MessageRole = "agent"
+)
+
+// Task is a stateful unit of work an agent performs for a client.
+type Task struct {
+	// ID is a unique identifier of the task generated by the server.
+	ID string `json:"id"`
+	// ContextID is a server-generated identifier for grouping related tasks and messages.
+	ContextID string `json:"contextId"`
+	// Status is the current status of the task.
+	Status TaskStatus `json:"status"`
+	// Artifacts is the collection of outputs produced by the agent for the task.
+	Artifacts []*Artifact `json:"artifacts,omitempty"`
+	// History is the list of messages exchanged during the task.
+	History []*Message `json:"history,omitempty"`
+	// Metadata is an optional set of extension-specific key-value pairs.
+	Metadata map[string]any `json:"metadata,omitempty"`
+}
+
+// MarshalJSON implements json.Marshaler adding the "kind" discriminator.
+func (t *Task) MarshalJSON() ([]byte, error) {
+	type wrapped Task
+	return json.Marshal(struct {
+		Kind string `json:"kind"`
+		*wrapped
+	}{Kind: kindTask, wrapped: (*wrapped)(t)})
+}
+
+// TaskStatus represents the status of a Task at a point in time.
+type TaskStatus struct {
+	// State is the current state of the task.
+	State TaskState `json:"state"`
+	// Message is an optional message providing details about the status.
+	Message *Message `json:"message,omitempty"`
+	// Timestamp is the time when the status was recorded.
+	Timestamp *time.Time `json:"timestamp,omitempty"`
+}
+
+// Message is a single turn of a conversation between a client and an agent.
+type Message struct {
+	// ID is a unique identifier of the message created by the sender.
+	ID string `json:"messageId"`
+	// ContextID is the identifier of the context the message belongs to.
+	ContextID string `json:"contextId,omitempty"`
+	// TaskID is the identifier of the task the message belongs to.
+	TaskID string `json:"taskId,omitempty"`
+	// ReferenceTaskIDs is a list of tasks the message refers to for additional context.
+	ReferenceTaskIDs []string `json:"referenceTaskIds,omitempty"`
+	// Role identifies the sender of the message.
+	Role MessageRole `json:"role"`
+	// Parts is the content of the message.
+	Parts ContentParts `json:"parts"`
+	// Metadata is an optional set of extension-specific key-value pairs.
+	Metadata map[string]any `json:"metadata,omitempty"`
+	// Extensions is a list of URIs of the extensions relevant to the message.
+	Extensions []string `json:"extensions,omitempty"`
+}
+
+// MarshalJSON implements json.Marshaler adding the "kind" discriminator.
+func (m *Message) MarshalJSON() ([]byte, error) {
+	type wrapped Message
+	return json.Marshal(struct {
+		Kind string `json:"kind"`
+		*wrapped
+	}{Kind: kindMessage, wrapped: (*wrapped)(m)})
+}
+
+// Artifact is an output produced by an agent as a result of a Task.
+type Artifact struct {
+	// ID is a unique identifier of the artifact within the task.
+	ID string `json:"artifactId"`
+	// Name is an optional human-readable name of the artifact.
+	Name string `json:"name,omitempty"`
+	// Description is an optional human-readable description of the artifact.
+	Description string `json:"description,omitempty"`
+	// Parts is the content of the artifact.
+	Parts ContentParts `json:"parts"`
+	// Metadata is an optional set of extension-specific key-value pairs.
+	Metadata map[string]any `json:"metadata,omitempty"`
+	// Extensions is a list of URIs of the extensions relevant to the artifact.
+	Extensions []string `json:"extensions,omitempty"`
+}
+
+// TaskStatusUpdateEvent notifies a client about a change of a Task status.
+type TaskStatusUpdateEvent struct {
+	// TaskID is the identifier of the updated task.
+	TaskID string `json:"taskId"`
+	// ContextID is the identifier of the context the task belongs to.
+	ContextID string `json:"contextId"`
+	// Status is the new status of the task.
+	Status TaskStatus `json:"status"`
+	// Final is true if this is the last event of the stream.
+	Final bool `json:"final"`
+	// Metadata is an optional set of extension-specific key-value pairs.
+	Metadata map[string]any `json:"metadata,omitempty"`
+}
+
+// MarshalJSON implements json.Marshaler adding the "kind" discriminator.
+func (e *TaskStatusUpdateEvent) MarshalJSON() ([]byte, error) {
+	type wrapped TaskStatusUpdateEvent
+	return json.Marshal(struct {
+		Kind string `json:"kind"`
+		*wrapped
+	}{Kind: kindStatusUpdate, wrapped: (*wrapped)(e)})
+}
+
+// TaskArtifactUpdateEvent notifies a client about a new or updated Artifact.
+type TaskArtifactUpdateEvent struct {
+	// TaskID is the identifier of the task the artifact belongs to.
+	TaskID string `json:"taskId"`
+	// ContextID is the identifier of the context the task belongs to.
+	ContextID string `json:"contextId"`
+	// Artifact is the new artifact or a chunk of an existing one.
+	Artifact *Artifact `json:"artifact"`
+	// Append is true if the parts must be appended to a previously sent artifact with the same ID.
+	Append bool `json:"append,omitempty"`
+	// LastChunk is true if this is the final chunk of the artifact.
+	LastChunk bool `json:"lastChunk,omitempty"`
+	// Metadata is an optional set of extension-specific key-value pairs.
+	Metadata map[string]any `json:"metadata,omitempty"`
+}
+
+// MarshalJSON implements json.Marshaler adding the "kind" discriminator.
+func (e *TaskArtifactUpdateEvent) MarshalJSON() ([]byte, error) {
+	type wrapped TaskArtifactUpdateEvent
+	return json.Marshal(struct {
+		Kind string `json:"kind"`
+		*wrapped
+	}{Kind: kindArtifactUpdate, wrapped: (*wrapped)(e)})
+}
+
+// PushNotificationConfig defines where and how an agent delivers asynchronous updates of a Task.
+type PushNotificationConfig struct {
+	// ID is an optional identifier of the configuration, unique within a task.
+	ID string `json:"id,omitempty"`
+	// URL is the webhook the agent sends notifications to.
+	URL string `json:"url"`
+	// Token is an optional client-provided value the agent includes in every notification.
+	Token string `json:"token,omitempty"`
+	// Auth describes how the agent must authenticate with the webhook.
+	Auth *PushAuthInfo `json:"authentication,omitempty"`
+}
+
+// PushAuthInfo defines authentication details of a push notification webhook.
+type PushAuthInfo struct {
+	// Schemes is a list of supported authentication schemes, e.g. "Bearer".
+	Schemes []string `json:"schemes"`
+	// Credentials are optional credentials for the webhook.
+	Credentials string `json:"credentials,omitempty"`
+}
+
+// TaskPushConfig associates a PushNotificationConfig with a Task.
+type TaskPushConfig struct {
+	// TaskID is the identifier of the task.
+	TaskID string `json:"taskId"`
+	// Config is the push notification configuration.
+	Config PushNotificationConfig `json:"pushNotificationConfig"`
+}
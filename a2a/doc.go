@@ -0,0 +1,27 @@
+// Copyright 2025 The A2A Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package a2a defines the A2A protocol domain model as plain Go types.
+//
+// The types mirror the objects of the A2A specification (Task, Message, Part,
+// Artifact, update events and push notification configurations) without any
+// dependency on protobuf runtime types: metadata is a map[string]any, timestamps
+// are time.Time and parts are a sealed interface implemented by TextPart, FilePart
+// and DataPart. JSON encoding of the types follows the JSON-RPC binding of the protocol.
+//
+// Converters to and from the generated types of package
+// github.com/a2aproject/a2a-go/grpc are provided by the XxxFromProto and XxxToProto
+// functions. Converting a proto message to the domain model and back yields an
+// equal proto message.
+package a2a
@@ -0,0 +1,64 @@
+// Copyright 2025 The A2A Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package a2a
+
+import (
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Errors defined by the A2A protocol. They can be wrapped with additional context
+// and matched using errors.Is. Every error also carries a gRPC status code,
+// so it is reported correctly when returned from an A2AServiceServer method.
+var (
+	// ErrTaskNotFound means the requested task ID does not exist or has expired.
+	ErrTaskNotFound = newProtocolError(codes.NotFound, "task not found")
+	// ErrTaskNotCancelable means the task is in a state where it can not be canceled.
+	ErrTaskNotCancelable = newProtocolError(codes.FailedPrecondition, "task cannot be canceled")
+	// ErrPushNotificationNotSupported means the agent does not support push notifications.
+	ErrPushNotificationNotSupported = newProtocolError(codes.Unimplemented, "push notification is not supported")
+	// ErrUnsupportedOperation means the requested operation is not supported by the agent.
+	ErrUnsupportedOperation = newProtocolError(codes.Unimplemented, "this operation is not supported")
+	// ErrUnsupportedContentType means there is a mismatch between the content types
+	// requested by the client and the ones supported by the agent.
+	ErrUnsupportedContentType = newProtocolError(codes.InvalidArgument, "incompatible content types")
+	// ErrInvalidAgentResponse means the agent produced a response which does not conform to the protocol.
+	ErrInvalidAgentResponse = newProtocolError(codes.Internal, "invalid agent response")
+	// ErrInvalidParams means the request parameters are invalid.
+	ErrInvalidParams = newProtocolError(codes.InvalidArgument, "invalid params")
+)
+
+// ErrPushConfigNotFound means the requested push notification config does not exist.
+// The protocol defines no error for it, so the JSON-RPC binding reports it as invalid
+// params, while gRPC reports it with the NotFound code.
+var ErrPushConfigNotFound = newProtocolError(codes.NotFound, "push notification config not found")
+
+type protocolError struct {
+	code    codes.Code
+	message string
+}
+
+func newProtocolError(code codes.Code, message string) error {
+	return &protocolError{code: code, message: message}
+}
+
+func (e *protocolError) Error() string {
+	return e.message
+}
+
+// GRPCStatus allows status.FromError to extract the gRPC code of the error.
+func (e *protocolError) GRPCStatus() *status.Status {
+	return status.New(e.code, e.message)
+}
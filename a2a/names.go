@@ -0,0 +1,56 @@
+// Copyright 2025 The A2A Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package a2a
+
+import (
+	"fmt"
+	"strings"
+)
+
+const (
+	tasksCollection       = "tasks"
+	pushConfigsCollection = "pushNotificationConfigs"
+)
+
+// TaskName returns the resource name of a task used by the gRPC binding: tasks/{id}.
+func TaskName(taskID string) string {
+	return tasksCollection + "/" + taskID
+}
+
+// ParseTaskName extracts a task ID from a resource name of the tasks/{id} format.
+func ParseTaskName(name string) (string, error) {
+	segments := strings.Split(name, "/")
+	if len(segments) != 2 || segments[0] != tasksCollection || segments[1] == "" {
+		return "", fmt.Errorf("invalid task name %q, expected tasks/{id}", name)
+	}
+	return segments[1], nil
+}
+
+// PushConfigName returns the resource name of a task push notification config used by
+// the gRPC binding: tasks/{id}/pushNotificationConfigs/{config_id}.
+func PushConfigName(taskID, configID string) string {
+	return TaskName(taskID) + "/" + pushConfigsCollection + "/" + configID
+}
+
+// ParsePushConfigName extracts task and config IDs from a resource name
+// of the tasks/{id}/pushNotificationConfigs/{config_id} format.
+// The config ID is allowed to be empty.
+func ParsePushConfigName(name string) (taskID, configID string, err error) {
+	segments := strings.Split(name, "/")
+	if len(segments) != 4 || segments[0] != tasksCollection || segments[1] == "" || segments[2] != pushConfigsCollection {
+		return "", "", fmt.Errorf("invalid push config name %q, expected tasks/{id}/pushNotificationConfigs/{config_id}", name)
+	}
+	return segments[1], segments[3], nil
+}
@@ -0,0 +1,85 @@
+// Copyright 2025 The A2A Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package a2a
+
+// MessageSendParams are the parameters of message/send and message/stream requests.
+type MessageSendParams struct {
+	// Message is the message being sent to the agent.
+	Message *Message `json:"message"`
+	// Config is an optional configuration of the request.
+	Config *MessageSendConfig `json:"configuration,omitempty"`
+	// Metadata is an optional set of extension-specific key-value pairs.
+	Metadata map[string]any `json:"metadata,omitempty"`
+}
+
+// MessageSendConfig defines how an agent handles a message.
+type MessageSendConfig struct {
+	// AcceptedOutputModes is a list of output MIME types the client is prepared to accept.
+	AcceptedOutputModes []string `json:"acceptedOutputModes,omitempty"`
+	// PushConfig is an optional configuration for the agent to send push notifications
+	// about the task.
+	PushConfig *PushNotificationConfig `json:"pushNotificationConfig,omitempty"`
+	// HistoryLength is the number of most recent messages to include in the returned task.
+	HistoryLength *int `json:"historyLength,omitempty"`
+	// Blocking makes the agent wait for the task to reach a terminal or interrupted state
+	// before responding.
+	Blocking bool `json:"blocking,omitempty"`
+}
+
+// TaskQueryParams are the parameters of a tasks/get request.
+type TaskQueryParams struct {
+	// ID is the identifier of the task.
+	ID string `json:"id"`
+	// HistoryLength is the number of most recent messages to include in the returned task.
+	HistoryLength *int `json:"historyLength,omitempty"`
+	// Metadata is an optional set of extension-specific key-value pairs.
+	Metadata map[string]any `json:"metadata,omitempty"`
+}
+
+// TaskIDParams are the parameters of requests which identify a task, like tasks/cancel.
+type TaskIDParams struct {
+	// ID is the identifier of the task.
+	ID string `json:"id"`
+	// Metadata is an optional set of extension-specific key-value pairs.
+	Metadata map[string]any `json:"metadata,omitempty"`
+}
+
+// GetTaskPushConfigParams are the parameters of a tasks/pushNotificationConfig/get request.
+type GetTaskPushConfigParams struct {
+	// TaskID is the identifier of the task.
+	TaskID string `json:"id"`
+	// ConfigID is an optional identifier of the config to retrieve.
+	ConfigID string `json:"pushNotificationConfigId,omitempty"`
+	// Metadata is an optional set of extension-specific key-value pairs.
+	Metadata map[string]any `json:"metadata,omitempty"`
+}
+
+// ListTaskPushConfigParams are the parameters of a tasks/pushNotificationConfig/list request.
+type ListTaskPushConfigParams struct {
+	// TaskID is the identifier of the task.
+	TaskID string `json:"id"`
+	// Metadata is an optional set of extension-specific key-value pairs.
+	Metadata map[string]any `json:"metadata,omitempty"`
+}
+
+// DeleteTaskPushConfigParams are the parameters of a tasks/pushNotificationConfig/delete request.
+type DeleteTaskPushConfigParams struct {
+	// TaskID is the identifier of the task.
+	TaskID string `json:"id"`
+	// ConfigID is the identifier of the config to delete.
+	ConfigID string `json:"pushNotificationConfigId"`
+	// Metadata is an optional set of extension-specific key-value pairs.
+	Metadata map[string]any `json:"metadata,omitempty"`
+}
@@ -0,0 +1,150 @@
+// Copyright 2025 The A2A Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package a2a
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// Part is a piece of content of a Message or an Artifact.
+// It is implemented by TextPart, FilePart and DataPart.
+type Part interface {
+	isPart()
+}
+
+func (TextPart) isPart() {}
+func (FilePart) isPart() {}
+func (DataPart) isPart() {}
+
+const (
+	kindText = "text"
+	kindFile = "file"
+	kindData = "data"
+)
+
+// TextPart is a Part containing plain text.
+type TextPart struct {
+	// Text is the content of the part.
+	Text string `json:"text"`
+	// Metadata is an optional set of extension-specific key-value pairs.
+	Metadata map[string]any `json:"metadata,omitempty"`
+}
+
+// MarshalJSON implements json.Marshaler adding the "kind" discriminator.
+func (p TextPart) MarshalJSON() ([]byte, error) {
+	type wrapped TextPart
+	return json.Marshal(struct {
+		Kind string `json:"kind"`
+		wrapped
+	}{Kind: kindText, wrapped: wrapped(p)})
+}
+
+// FilePart is a Part containing a file, either inline or referenced by URI.
+type FilePart struct {
+	// File is the content of the part.
+	File File `json:"file"`
+	// Metadata is an optional set of extension-specific key-value pairs.
+	Metadata map[string]any `json:"metadata,omitempty"`
+}
+
+// MarshalJSON implements json.Marshaler adding the "kind" discriminator.
+func (p FilePart) MarshalJSON() ([]byte, error) {
+	type wrapped FilePart
+	return json.Marshal(struct {
+		Kind string `json:"kind"`
+		wrapped
+	}{Kind: kindFile, wrapped: wrapped(p)})
+}
+
+// File is the content of a FilePart. Exactly one of Bytes and URI is expected to be set.
+type File struct {
+	// Name is an optional name of the file.
+	Name string `json:"name,omitempty"`
+	// MimeType is an optional MIME type of the file.
+	MimeType string `json:"mimeType,omitempty"`
+	// Bytes is the inline content of the file. It is base64-encoded in JSON.
+	Bytes []byte `json:"bytes,omitempty"`
+	// URI is the location of the file content.
+	URI string `json:"uri,omitempty"`
+}
+
+// DataPart is a Part containing structured JSON data.
+type DataPart struct {
+	// Data is the content of the part.
+	Data map[string]any `json:"data"`
+	// Metadata is an optional set of extension-specific key-value pairs.
+	Metadata map[string]any `json:"metadata,omitempty"`
+}
+
+// MarshalJSON implements json.Marshaler adding the "kind" discriminator.
+func (p DataPart) MarshalJSON() ([]byte, error) {
+	type wrapped DataPart
+	return json.Marshal(struct {
+		Kind string `json:"kind"`
+		wrapped
+	}{Kind: kindData, wrapped: wrapped(p)})
+}
+
+// ContentParts is a list of parts which can be decoded from JSON using
+// the "kind" discriminator of each element.
+type ContentParts []Part
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (cp *ContentParts) UnmarshalJSON(data []byte) error {
+	var raw []json.RawMessage
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	if raw == nil {
+		*cp = nil
+		return nil
+	}
+	result := make(ContentParts, len(raw))
+	for i, r := range raw {
+		part, err := unmarshalPart(r)
+		if err != nil {
+			return fmt.Errorf("part %d: %w", i, err)
+		}
+		result[i] = part
+	}
+	*cp = result
+	return nil
+}
+
+func unmarshalPart(data []byte) (Part, error) {
+	var typed struct {
+		Kind string `json:"kind"`
+	}
+	if err := json.Unmarshal(data, &typed); err != nil {
+		return nil, err
+	}
+	switch typed.Kind {
+	case kindText:
+		var p TextPart
+		err := json.Unmarshal(data, &p)
+		return p, err
+	case kindFile:
+		var p FilePart
+		err := json.Unmarshal(data, &p)
+		return p, err
+	case kindData:
+		var p DataPart
+		err := json.Unmarshal(data, &p)
+		return p, err
+	default:
+		return nil, fmt.Errorf("unknown part kind %q", typed.Kind)
+	}
+}
@@ -0,0 +1,630 @@
+// Copyright 2025 The A2A Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package a2a
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"google.golang.org/protobuf/types/known/structpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	a2apb "github.com/a2aproject/a2a-go/grpc"
+)
+
+// The proto representation has no fields for Message.ReferenceTaskIDs, Part metadata
+// and File.Name, so they are dropped by the XxxToProto converters. All the other fields
+// are converted both ways without loss.
+
+var (
+	taskStateToProto = map[TaskState]a2apb.TaskState{
+		TaskStateUnknown:       a2apb.TaskState_TASK_STATE_UNSPECIFIED,
+		TaskStateSubmitted:     a2apb.TaskState_TASK_STATE_SUBMITTED,
+		TaskStateWorking:       a2apb.TaskState_TASK_STATE_WORKING,
+		TaskStateInputRequired: a2apb.TaskState_TASK_STATE_INPUT_REQUIRED,
+		TaskStateAuthRequired:  a2apb.TaskState_TASK_STATE_AUTH_REQUIRED,
+		TaskStateCompleted:     a2apb.TaskState_TASK_STATE_COMPLETED,
+		TaskStateCanceled:      a2apb.TaskState_TASK_STATE_CANCELLED,
+		TaskStateFailed:        a2apb.TaskState_TASK_STATE_FAILED,
+		TaskStateRejected:      a2apb.TaskState_TASK_STATE_REJECTED,
+	}
+	taskStateFromProto = invert(taskStateToProto)
+
+	roleToProto = map[MessageRole]a2apb.Role{
+		"":               a2apb.Role_ROLE_UNSPECIFIED,
+		MessageRoleUser:  a2apb.Role_ROLE_USER,
+		MessageRoleAgent: a2apb.Role_ROLE_AGENT,
+	}
+	roleFromProto = invert(roleToProto)
+)
+
+func invert[K, V comparable](m map[K]V) map[V]K {
+	result := make(map[V]K, len(m))
+	for k, v := range m {
+		result[v] = k
+	}
+	return result
+}
+
+// TaskStateFromProto converts a proto task state. Values not known to the domain model
+// are mapped to TaskStateUnknown.
+func TaskStateFromProto(s a2apb.TaskState) TaskState {
+	if state, ok := taskStateFromProto[s]; ok {
+		return state
+	}
+	return TaskStateUnknown
+}
+
+// TaskStateToProto converts a task state to its proto representation. Values not known
+// to the proto schema are mapped to TASK_STATE_UNSPECIFIED.
+func TaskStateToProto(s TaskState) a2apb.TaskState {
+	return taskStateToProto[s]
+}
+
+// TaskFromProto converts a proto Task to the domain model.
+func TaskFromProto(t *a2apb.Task) (*Task, error) {
+	if t == nil {
+		return nil, nil
+	}
+	status, err := taskStatusFromProto(t.GetStatus())
+	if err != nil {
+		return nil, fmt.Errorf("task status: %w", err)
+	}
+	artifacts, err := convertSlice(t.GetArtifacts(), ArtifactFromProto)
+	if err != nil {
+		return nil, fmt.Errorf("task artifacts: %w", err)
+	}
+	history, err := convertSlice(t.GetHistory(), MessageFromProto)
+	if err != nil {
+		return nil, fmt.Errorf("task history: %w", err)
+	}
+	return &Task{
+		ID:        t.GetId(),
+		ContextID: t.GetContextId(),
+		Status:    status,
+		Artifacts: artifacts,
+		History:   history,
+		Metadata:  metadataFromProto(t.GetMetadata()),
+	}, nil
+}
+
+// TaskToProto converts a Task to its proto representation.
+func TaskToProto(t *Task) (*a2apb.Task, error) {
+	if t == nil {
+		return nil, nil
+	}
+	status, err := taskStatusToProto(t.Status)
+	if err != nil {
+		return nil, fmt.Errorf("task status: %w", err)
+	}
+	artifacts, err := convertSlice(t.Artifacts, ArtifactToProto)
+	if err != nil {
+		return nil, fmt.Errorf("task artifacts: %w", err)
+	}
+	history, err := convertSlice(t.History, MessageToProto)
+	if err != nil {
+		return nil, fmt.Errorf("task history: %w", err)
+	}
+	metadata, err := metadataToProto(t.Metadata)
+	if err != nil {
+		return nil, fmt.Errorf("task metadata: %w", err)
+	}
+	return &a2apb.Task{
+		Id:        t.ID,
+		ContextId: t.ContextID,
+		Status:    status,
+		Artifacts: artifacts,
+		History:   history,
+		Metadata:  metadata,
+	}, nil
+}
+
+// A nil proto status is represented by a zero TaskStatus.
+func taskStatusFromProto(s *a2apb.TaskStatus) (TaskStatus, error) {
+	if s == nil {
+		return TaskStatus{}, nil
+	}
+	msg, err := MessageFromProto(s.GetUpdate())
+	if err != nil {
+		return TaskStatus{}, err
+	}
+	var timestamp *time.Time
+	if s.GetTimestamp() != nil {
+		t := s.GetTimestamp().AsTime()
+		timestamp = &t
+	}
+	return TaskStatus{State: TaskStateFromProto(s.GetState()), Message: msg, Timestamp: timestamp}, nil
+}
+
+func taskStatusToProto(s TaskStatus) (*a2apb.TaskStatus, error) {
+	if s.State == "" && s.Message == nil && s.Timestamp == nil {
+		return nil, nil
+	}
+	msg, err := MessageToProto(s.Message)
+	if err != nil {
+		return nil, err
+	}
+	var timestamp *timestamppb.Timestamp
+	if s.Timestamp != nil {
+		timestamp = timestamppb.New(*s.Timestamp)
+	}
+	return &a2apb.TaskStatus{State: TaskStateToProto(s.State), Update: msg, Timestamp: timestamp}, nil
+}
+
+// MessageFromProto converts a proto Message to the domain model.
+func MessageFromProto(m *a2apb.Message) (*Message, error) {
+	if m == nil {
+		return nil, nil
+	}
+	parts, err := partsFromProto(m.GetContent())
+	if err != nil {
+		return nil, fmt.Errorf("message content: %w", err)
+	}
+	role, ok := roleFromProto[m.GetRole()]
+	if !ok {
+		return nil, fmt.Errorf("unknown message role %v", m.GetRole())
+	}
+	return &Message{
+		ID:         m.GetMessageId(),
+		ContextID:  m.GetContextId(),
+		TaskID:     m.GetTaskId(),
+		Role:       role,
+		Parts:      parts,
+		Metadata:   metadataFromProto(m.GetMetadata()),
+		Extensions: m.GetExtensions(),
+	}, nil
+}
+
+// MessageToProto converts a Message to its proto representation.
+func MessageToProto(m *Message) (*a2apb.Message, error) {
+	if m == nil {
+		return nil, nil
+	}
+	role, ok := roleToProto[m.Role]
+	if !ok {
+		return nil, fmt.Errorf("unknown message role %q", m.Role)
+	}
+	parts, err := partsToProto(m.Parts)
+	if err != nil {
+		return nil, fmt.Errorf("message parts: %w", err)
+	}
+	metadata, err := metadataToProto(m.Metadata)
+	if err != nil {
+		return nil, fmt.Errorf("message metadata: %w", err)
+	}
+	return &a2apb.Message{
+		MessageId:  m.ID,
+		ContextId:  m.ContextID,
+		TaskId:     m.TaskID,
+		Role:       role,
+		Content:    parts,
+		Metadata:   metadata,
+		Extensions: m.Extensions,
+	}, nil
+}
+
+// PartFromProto converts a proto Part to the domain model.
+func PartFromProto(p *a2apb.Part) (Part, error) {
+	switch v := p.GetPart().(type) {
+	case *a2apb.Part_Text:
+		return TextPart{Text: v.Text}, nil
+	case *a2apb.Part_File:
+		file := File{MimeType: v.File.GetMimeType()}
+		switch f := v.File.GetFile().(type) {
+		case *a2apb.FilePart_FileWithUri:
+			file.URI = f.FileWithUri
+		case *a2apb.FilePart_FileWithBytes:
+			file.Bytes = f.FileWithBytes
+			if file.Bytes == nil {
+				file.Bytes = []byte{}
+			}
+		case nil:
+		default:
+			return nil, fmt.Errorf("unknown file content type %T", f)
+		}
+		return FilePart{File: file}, nil
+	case *a2apb.Part_Data:
+		return DataPart{Data: metadataFromProto(v.Data.GetData())}, nil
+	case nil:
+		return nil, errors.New("part has no content")
+	default:
+		return nil, fmt.Errorf("unknown part type %T", v)
+	}
+}
+
+// PartToProto converts a Part to its proto representation.
+func PartToProto(p Part) (*a2apb.Part, error) {
+	switch v := p.(type) {
+	case TextPart:
+		return &a2apb.Part{Part: &a2apb.Part_Text{Text: v.Text}}, nil
+	case FilePart:
+		file := &a2apb.FilePart{MimeType: v.File.MimeType}
+		switch {
+		case v.File.Bytes != nil && v.File.URI != "":
+			return nil, errors.New("file part must have either bytes or uri set, not both")
+		case v.File.Bytes != nil:
+			file.File = &a2apb.FilePart_FileWithBytes{FileWithBytes: v.File.Bytes}
+		case v.File.URI != "":
+			file.File = &a2apb.FilePart_FileWithUri{FileWithUri: v.File.URI}
+		}
+		return &a2apb.Part{Part: &a2apb.Part_File{File: file}}, nil
+	case DataPart:
+		data, err := metadataToProto(v.Data)
+		if err != nil {
+			return nil, fmt.Errorf("data part: %w", err)
+		}
+		return &a2apb.Part{Part: &a2apb.Part_Data{Data: &a2apb.DataPart{Data: data}}}, nil
+	case nil:
+		return nil, errors.New("part is nil")
+	default:
+		return nil, fmt.Errorf("unknown part type %T", v)
+	}
+}
+
+func partsFromProto(parts []*a2apb.Part) (ContentParts, error) {
+	if parts == nil {
+		return nil, nil
+	}
+	result := make(ContentParts, len(parts))
+	for i, p := range parts {
+		part, err := PartFromProto(p)
+		if err != nil {
+			return nil, fmt.Errorf("part %d: %w", i, err)
+		}
+		result[i] = part
+	}
+	return result, nil
+}
+
+func partsToProto(parts ContentParts) ([]*a2apb.Part, error) {
+	if parts == nil {
+		return nil, nil
+	}
+	result := make([]*a2apb.Part, len(parts))
+	for i, p := range parts {
+		part, err := PartToProto(p)
+		if err != nil {
+			return nil, fmt.Errorf("part %d: %w", i, err)
+		}
+		result[i] = part
+	}
+	return result, nil
+}
+
+// ArtifactFromProto converts a proto Artifact to the domain model.
+func ArtifactFromProto(a *a2apb.Artifact) (*Artifact, error) {
+	if a == nil {
+		return nil, nil
+	}
+	parts, err := partsFromProto(a.GetParts())
+	if err != nil {
+		return nil, fmt.Errorf("artifact parts: %w", err)
+	}
+	return &Artifact{
+		ID:          a.GetArtifactId(),
+		Name:        a.GetName(),
+		Description: a.GetDescription(),
+		Parts:       parts,
+		Metadata:    metadataFromProto(a.GetMetadata()),
+		Extensions:  a.GetExtensions(),
+	}, nil
+}
+
+// ArtifactToProto converts an Artifact to its proto representation.
+func ArtifactToProto(a *Artifact) (*a2apb.Artifact, error) {
+	if a == nil {
+		return nil, nil
+	}
+	parts, err := partsToProto(a.Parts)
+	if err != nil {
+		return nil, fmt.Errorf("artifact parts: %w", err)
+	}
+	metadata, err := metadataToProto(a.Metadata)
+	if err != nil {
+		return nil, fmt.Errorf("artifact metadata: %w", err)
+	}
+	return &a2apb.Artifact{
+		ArtifactId:  a.ID,
+		Name:        a.Name,
+		Description: a.Description,
+		Parts:       parts,
+		Metadata:    metadata,
+		Extensions:  a.Extensions,
+	}, nil
+}
+
+// StatusUpdateFromProto converts a proto TaskStatusUpdateEvent to the domain model.
+func StatusUpdateFromProto(e *a2apb.TaskStatusUpdateEvent) (*TaskStatusUpdateEvent, error) {
+	if e == nil {
+		return nil, nil
+	}
+	status, err := taskStatusFromProto(e.GetStatus())
+	if err != nil {
+		return nil, fmt.Errorf("event status: %w", err)
+	}
+	return &TaskStatusUpdateEvent{
+		TaskID:    e.GetTaskId(),
+		ContextID: e.GetContextId(),
+		Status:    status,
+		Final:     e.GetFinal(),
+		Metadata:  metadataFromProto(e.GetMetadata()),
+	}, nil
+}
+
+// StatusUpdateToProto converts a TaskStatusUpdateEvent to its proto representation.
+func StatusUpdateToProto(e *TaskStatusUpdateEvent) (*a2apb.TaskStatusUpdateEvent, error) {
+	if e == nil {
+		return nil, nil
+	}
+	status, err := taskStatusToProto(e.Status)
+	if err != nil {
+		return nil, fmt.Errorf("event status: %w", err)
+	}
+	metadata, err := metadataToProto(e.Metadata)
+	if err != nil {
+		return nil, fmt.Errorf("event metadata: %w", err)
+	}
+	return &a2apb.TaskStatusUpdateEvent{
+		TaskId:    e.TaskID,
+		ContextId: e.ContextID,
+		Status:    status,
+		Final:     e.Final,
+		Metadata:  metadata,
+	}, nil
+}
+
+// ArtifactUpdateFromProto converts a proto TaskArtifactUpdateEvent to the domain model.
+func ArtifactUpdateFromProto(e *a2apb.TaskArtifactUpdateEvent) (*TaskArtifactUpdateEvent, error) {
+	if e == nil {
+		return nil, nil
+	}
+	artifact, err := ArtifactFromProto(e.GetArtifact())
+	if err != nil {
+		return nil, fmt.Errorf("event artifact: %w", err)
+	}
+	return &TaskArtifactUpdateEvent{
+		TaskID:    e.GetTaskId(),
+		ContextID: e.GetContextId(),
+		Artifact:  artifact,
+		Append:    e.GetAppend(),
+		LastChunk: e.GetLastChunk(),
+		Metadata:  metadataFromProto(e.GetMetadata()),
+	}, nil
+}
+
+// ArtifactUpdateToProto converts a TaskArtifactUpdateEvent to its proto representation.
+func ArtifactUpdateToProto(e *TaskArtifactUpdateEvent) (*a2apb.TaskArtifactUpdateEvent, error) {
+	if e == nil {
+		return nil, nil
+	}
+	artifact, err := ArtifactToProto(e.Artifact)
+	if err != nil {
+		return nil, fmt.Errorf("event artifact: %w", err)
+	}
+	metadata, err := metadataToProto(e.Metadata)
+	if err != nil {
+		return nil, fmt.Errorf("event metadata: %w", err)
+	}
+	return &a2apb.TaskArtifactUpdateEvent{
+		TaskId:    e.TaskID,
+		ContextId: e.ContextID,
+		Artifact:  artifact,
+		Append:    e.Append,
+		LastChunk: e.LastChunk,
+		Metadata:  metadata,
+	}, nil
+}
+
+// EventFromProto converts a proto StreamResponse to the domain model.
+func EventFromProto(r *a2apb.StreamResponse) (Event, error) {
+	switch v := r.GetPayload().(type) {
+	case *a2apb.StreamResponse_Task:
+		if v.Task != nil {
+			return asEvent(TaskFromProto(v.Task))
+		}
+	case *a2apb.StreamResponse_Msg:
+		if v.Msg != nil {
+			return asEvent(MessageFromProto(v.Msg))
+		}
+	case *a2apb.StreamResponse_StatusUpdate:
+		if v.StatusUpdate != nil {
+			return asEvent(StatusUpdateFromProto(v.StatusUpdate))
+		}
+	case *a2apb.StreamResponse_ArtifactUpdate:
+		if v.ArtifactUpdate != nil {
+			return asEvent(ArtifactUpdateFromProto(v.ArtifactUpdate))
+		}
+	case nil:
+	default:
+		return nil, fmt.Errorf("unknown stream response payload %T", v)
+	}
+	return nil, errors.New("stream response has no payload")
+}
+
+// EventToProto converts an Event to a proto StreamResponse.
+func EventToProto(e Event) (*a2apb.StreamResponse, error) {
+	switch v := e.(type) {
+	case *Task:
+		task, err := TaskToProto(v)
+		if err != nil {
+			return nil, err
+		}
+		return &a2apb.StreamResponse{Payload: &a2apb.StreamResponse_Task{Task: task}}, nil
+	case *Message:
+		msg, err := MessageToProto(v)
+		if err != nil {
+			return nil, err
+		}
+		return &a2apb.StreamResponse{Payload: &a2apb.StreamResponse_Msg{Msg: msg}}, nil
+	case *TaskStatusUpdateEvent:
+		event, err := StatusUpdateToProto(v)
+		if err != nil {
+			return nil, err
+		}
+		return &a2apb.StreamResponse{Payload: &a2apb.StreamResponse_StatusUpdate{StatusUpdate: event}}, nil
+	case *TaskArtifactUpdateEvent:
+		event, err := ArtifactUpdateToProto(v)
+		if err != nil {
+			return nil, err
+		}
+		return &a2apb.StreamResponse{Payload: &a2apb.StreamResponse_ArtifactUpdate{ArtifactUpdate: event}}, nil
+	case nil:
+		return nil, errors.New("event is nil")
+	default:
+		return nil, fmt.Errorf("unknown event type %T", v)
+	}
+}
+
+// SendMessageResultFromProto converts a proto SendMessageResponse to the domain model.
+func SendMessageResultFromProto(r *a2apb.SendMessageResponse) (SendMessageResult, error) {
+	switch v := r.GetPayload().(type) {
+	case *a2apb.SendMessageResponse_Task:
+		if v.Task != nil {
+			return asResult(TaskFromProto(v.Task))
+		}
+	case *a2apb.SendMessageResponse_Msg:
+		if v.Msg != nil {
+			return asResult(MessageFromProto(v.Msg))
+		}
+	case nil:
+	default:
+		return nil, fmt.Errorf("unknown send message response payload %T", v)
+	}
+	return nil, errors.New("send message response has no payload")
+}
+
+// SendMessageResultToProto converts a SendMessageResult to a proto SendMessageResponse.
+func SendMessageResultToProto(r SendMessageResult) (*a2apb.SendMessageResponse, error) {
+	switch v := r.(type) {
+	case *Task:
+		task, err := TaskToProto(v)
+		if err != nil {
+			return nil, err
+		}
+		return &a2apb.SendMessageResponse{Payload: &a2apb.SendMessageResponse_Task{Task: task}}, nil
+	case *Message:
+		msg, err := MessageToProto(v)
+		if err != nil {
+			return nil, err
+		}
+		return &a2apb.SendMessageResponse{Payload: &a2apb.SendMessageResponse_Msg{Msg: msg}}, nil
+	case nil:
+		return nil, errors.New("result is nil")
+	default:
+		return nil, fmt.Errorf("unknown result type %T", v)
+	}
+}
+
+// PushConfigFromProto converts a proto PushNotificationConfig to the domain model.
+func PushConfigFromProto(c *a2apb.PushNotificationConfig) *PushNotificationConfig {
+	if c == nil {
+		return nil
+	}
+	config := &PushNotificationConfig{ID: c.GetId(), URL: c.GetUrl(), Token: c.GetToken()}
+	if auth := c.GetAuthentication(); auth != nil {
+		config.Auth = &PushAuthInfo{Schemes: auth.GetSchemes(), Credentials: auth.GetCredentials()}
+	}
+	return config
+}
+
+// PushConfigToProto converts a PushNotificationConfig to its proto representation.
+func PushConfigToProto(c *PushNotificationConfig) *a2apb.PushNotificationConfig {
+	if c == nil {
+		return nil
+	}
+	config := &a2apb.PushNotificationConfig{Id: c.ID, Url: c.URL, Token: c.Token}
+	if c.Auth != nil {
+		config.Authentication = &a2apb.AuthenticationInfo{Schemes: c.Auth.Schemes, Credentials: c.Auth.Credentials}
+	}
+	return config
+}
+
+// TaskPushConfigFromProto converts a proto TaskPushNotificationConfig to the domain model.
+// The task ID is parsed from the resource name.
+func TaskPushConfigFromProto(c *a2apb.TaskPushNotificationConfig) (*TaskPushConfig, error) {
+	if c == nil {
+		return nil, nil
+	}
+	taskID, configID, err := ParsePushConfigName(c.GetName())
+	if err != nil {
+		return nil, err
+	}
+	config := PushConfigFromProto(c.GetPushNotificationConfig())
+	if config == nil {
+		config = &PushNotificationConfig{}
+	}
+	if config.ID == "" {
+		config.ID = configID
+	} else if configID != "" && configID != config.ID {
+		return nil, fmt.Errorf("config id %q does not match resource name %q", config.ID, c.GetName())
+	}
+	return &TaskPushConfig{TaskID: taskID, Config: *config}, nil
+}
+
+// TaskPushConfigToProto converts a TaskPushConfig to its proto representation.
+func TaskPushConfigToProto(c *TaskPushConfig) *a2apb.TaskPushNotificationConfig {
+	if c == nil {
+		return nil
+	}
+	return &a2apb.TaskPushNotificationConfig{
+		Name:                   PushConfigName(c.TaskID, c.Config.ID),
+		PushNotificationConfig: PushConfigToProto(&c.Config),
+	}
+}
+
+func metadataFromProto(s *structpb.Struct) map[string]any {
+	if s == nil {
+		return nil
+	}
+	return s.AsMap()
+}
+
+func metadataToProto(m map[string]any) (*structpb.Struct, error) {
+	if m == nil {
+		return nil, nil
+	}
+	return structpb.NewStruct(m)
+}
+
+func convertSlice[T, R any](items []T, convert func(T) (R, error)) ([]R, error) {
+	if items == nil {
+		return nil, nil
+	}
+	result := make([]R, len(items))
+	for i, item := range items {
+		converted, err := convert(item)
+		if err != nil {
+			return nil, fmt.Errorf("item %d: %w", i, err)
+		}
+		result[i] = converted
+	}
+	return result, nil
+}
+
+// asEvent prevents a nil pointer from being returned as a non-nil interface along with an error.
+func asEvent[T Event](v T, err error) (Event, error) {
+	if err != nil {
+		return nil, err
+	}
+	return v, nil
+}
+
+func asResult[T SendMessageResult](v T, err error) (SendMessageResult, error) {
+	if err != nil {
+		return nil, err
+	}
+	return v, nil
+}
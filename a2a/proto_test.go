@@ -0,0 +1,322 @@
+// Copyright 2025 The A2A Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package a2a
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"google.golang.org/protobuf/proto"
+
+	a2apb "github.com/a2aproject/a2a-go/grpc"
+)
+
+func newTestTask() *Task {
+	timestamp := time.Date(2025, 7, 1, 12, 30, 0, 0, time.UTC)
+	return &Task{
+		ID:        "task-1",
+		ContextID: "ctx-1",
+		Status: TaskStatus{
+			State: TaskStateInputRequired,
+			Message: &Message{
+				ID:    "status-msg",
+				Role:  MessageRoleAgent,
+				Parts: ContentParts{TextPart{Text: "need more input"}},
+			},
+			Timestamp: &timestamp,
+		},
+		Artifacts: []*Artifact{{
+			ID:          "artifact-1",
+			Name:        "result",
+			Description: "the result",
+			Parts: ContentParts{
+				FilePart{File: File{MimeType: "image/png", Bytes: []byte{1, 2, 3}}},
+				FilePart{File: File{URI: "https://example.com/file.txt"}},
+				DataPart{Data: map[string]any{"answer": float64(42), "nested": map[string]any{"ok": true}}},
+			},
+			Metadata:   map[string]any{"source": "test"},
+			Extensions: []string{"https://example.com/ext"},
+		}},
+		History: []*Message{{
+			ID:         "msg-1",
+			ContextID:  "ctx-1",
+			TaskID:     "task-1",
+			Role:       MessageRoleUser,
+			Parts:      ContentParts{TextPart{Text: "hello"}},
+			Metadata:   map[string]any{"lang": "en"},
+			Extensions: []string{"https://example.com/ext"},
+		}},
+		Metadata: map[string]any{"priority": float64(1)},
+	}
+}
+
+func TestTaskProtoRoundTrip(t *testing.T) {
+	task := newTestTask()
+	pb, err := TaskToProto(task)
+	if err != nil {
+		t.Fatalf("TaskToProto() error = %v", err)
+	}
+	if pb.GetStatus().GetState() != a2apb.TaskState_TASK_STATE_INPUT_REQUIRED {
+		t.Errorf("TaskToProto() state = %v, want %v", pb.GetStatus().GetState(), a2apb.TaskState_TASK_STATE_INPUT_REQUIRED)
+	}
+	got, err := TaskFromProto(pb)
+	if err != nil {
+		t.Fatalf("TaskFromProto() error = %v", err)
+	}
+	if !reflect.DeepEqual(got, task) {
+		t.Errorf("TaskFromProto(TaskToProto()) = %+v, want %+v", got, task)
+	}
+}
+
+func TestTaskStateProto(t *testing.T) {
+	for state := range taskStateToProto {
+		if got := TaskStateFromProto(TaskStateToProto(state)); got != state {
+			t.Errorf("TaskStateFromProto(TaskStateToProto(%q)) = %q", state, got)
+		}
+	}
+	if got := TaskStateFromProto(a2apb.TaskState(1000)); got != TaskStateUnknown {
+		t.Errorf("TaskStateFromProto(1000) = %q, want %q", got, TaskStateUnknown)
+	}
+	if got := TaskStateToProto("paused"); got != a2apb.TaskState_TASK_STATE_UNSPECIFIED {
+		t.Errorf("TaskStateToProto(paused) = %v, want %v", got, a2apb.TaskState_TASK_STATE_UNSPECIFIED)
+	}
+}
+
+func TestEventProtoRoundTrip(t *testing.T) {
+	task := newTestTask()
+	events := []Event{
+		task,
+		task.History[0],
+		&TaskStatusUpdateEvent{TaskID: "task-1", ContextID: "ctx-1", Status: TaskStatus{State: TaskStateCompleted}, Final: true},
+		&TaskArtifactUpdateEvent{TaskID: "task-1", ContextID: "ctx-1", Artifact: task.Artifacts[0], Append: true, LastChunk: true},
+	}
+	for _, event := range events {
+		t.Run(reflect.TypeOf(event).String(), func(t *testing.T) {
+			pb, err := EventToProto(event)
+			if err != nil {
+				t.Fatalf("EventToProto() error = %v", err)
+			}
+			got, err := EventFromProto(pb)
+			if err != nil {
+				t.Fatalf("EventFromProto() error = %v", err)
+			}
+			if !reflect.DeepEqual(got, event) {
+				t.Errorf("EventFromProto(EventToProto()) = %+v, want %+v", got, event)
+			}
+
+			data, err := json.Marshal(event)
+			if err != nil {
+				t.Fatalf("json.Marshal() error = %v", err)
+			}
+			decoded, err := UnmarshalEvent(data)
+			if err != nil {
+				t.Fatalf("UnmarshalEvent() error = %v", err)
+			}
+			if !reflect.DeepEqual(decoded, event) {
+				t.Errorf("UnmarshalEvent(%s) = %+v, want %+v", data, decoded, event)
+			}
+		})
+	}
+}
+
+func TestSendMessageResultProtoRoundTrip(t *testing.T) {
+	task := newTestTask()
+	for _, result := range []SendMessageResult{task, task.History[0]} {
+		pb, err := SendMessageResultToProto(result)
+		if err != nil {
+			t.Fatalf("SendMessageResultToProto(%T) error = %v", result, err)
+		}
+		got, err := SendMessageResultFromProto(pb)
+		if err != nil {
+			t.Fatalf("SendMessageResultFromProto(%T) error = %v", result, err)
+		}
+		if !reflect.DeepEqual(got, result) {
+			t.Errorf("SendMessageResultFromProto(SendMessageResultToProto()) = %+v, want %+v", got, result)
+		}
+	}
+}
+
+func TestConvertErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		convert func() error
+		wantErr string
+	}{
+		{
+			name: "file with bytes and uri",
+			convert: func() error {
+				_, err := PartToProto(FilePart{File: File{Bytes: []byte{1}, URI: "https://example.com"}})
+				return err
+			},
+			wantErr: "either bytes or uri",
+		},
+		{
+			name: "nil part",
+			convert: func() error {
+				_, err := MessageToProto(&Message{Role: MessageRoleUser, Parts: ContentParts{nil}})
+				return err
+			},
+			wantErr: "part 0: part is nil",
+		},
+		{
+			name: "unknown role",
+			convert: func() error {
+				_, err := MessageToProto(&Message{Role: "system"})
+				return err
+			},
+			wantErr: "unknown message role",
+		},
+		{
+			name: "part without content",
+			convert: func() error {
+				_, err := MessageFromProto(&a2apb.Message{Content: []*a2apb.Part{{}}})
+				return err
+			},
+			wantErr: "part has no content",
+		},
+		{
+			name: "metadata not convertible",
+			convert: func() error {
+				_, err := TaskToProto(&Task{Metadata: map[string]any{"ch": make(chan int)}})
+				return err
+			},
+			wantErr: "task metadata",
+		},
+		{
+			name: "empty stream response",
+			convert: func() error {
+				_, err := EventFromProto(&a2apb.StreamResponse{})
+				return err
+			},
+			wantErr: "no payload",
+		},
+		{
+			name: "empty send message response",
+			convert: func() error {
+				_, err := SendMessageResultFromProto(&a2apb.SendMessageResponse{})
+				return err
+			},
+			wantErr: "no payload",
+		},
+		{
+			name: "nil event",
+			convert: func() error {
+				_, err := EventToProto(nil)
+				return err
+			},
+			wantErr: "event is nil",
+		},
+		{
+			name: "push config id mismatch",
+			convert: func() error {
+				_, err := TaskPushConfigFromProto(&a2apb.TaskPushNotificationConfig{
+					Name:                   "tasks/t1/pushNotificationConfigs/c1",
+					PushNotificationConfig: &a2apb.PushNotificationConfig{Id: "c2"},
+				})
+				return err
+			},
+			wantErr: "does not match",
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.convert()
+			if err == nil || !strings.Contains(err.Error(), tc.wantErr) {
+				t.Errorf("error = %v, want containing %q", err, tc.wantErr)
+			}
+		})
+	}
+}
+
+func TestTaskPushConfigProto(t *testing.T) {
+	config := &TaskPushConfig{
+		TaskID: "t1",
+		Config: PushNotificationConfig{
+			ID:    "c1",
+			URL:   "https://example.com/webhook",
+			Token: "secret",
+			Auth:  &PushAuthInfo{Schemes: []string{"Bearer"}, Credentials: "creds"},
+		},
+	}
+	pb := TaskPushConfigToProto(config)
+	want := &a2apb.TaskPushNotificationConfig{
+		Name: "tasks/t1/pushNotificationConfigs/c1",
+		PushNotificationConfig: &a2apb.PushNotificationConfig{
+			Id:             "c1",
+			Url:            "https://example.com/webhook",
+			Token:          "secret",
+			Authentication: &a2apb.AuthenticationInfo{Schemes: []string{"Bearer"}, Credentials: "creds"},
+		},
+	}
+	if !proto.Equal(pb, want) {
+		t.Errorf("TaskPushConfigToProto() = %v, want %v", pb, want)
+	}
+	got, err := TaskPushConfigFromProto(pb)
+	if err != nil {
+		t.Fatalf("TaskPushConfigFromProto() error = %v", err)
+	}
+	if !reflect.DeepEqual(got, config) {
+		t.Errorf("TaskPushConfigFromProto() = %+v, want %+v", got, config)
+	}
+
+	// The config ID is taken from the resource name when the config does not have one.
+	got, err = TaskPushConfigFromProto(&a2apb.TaskPushNotificationConfig{Name: "tasks/t1/pushNotificationConfigs/c2"})
+	if err != nil {
+		t.Fatalf("TaskPushConfigFromProto() error = %v", err)
+	}
+	if got.TaskID != "t1" || got.Config.ID != "c2" {
+		t.Errorf("TaskPushConfigFromProto() = %+v, want task t1 and config c2", got)
+	}
+}
+
+func TestParseNames(t *testing.T) {
+	tests := []struct {
+		name       string
+		input      string
+		wantTask   string
+		wantConfig string
+		wantErr    bool
+	}{
+		{name: "config", input: "tasks/t1/pushNotificationConfigs/c1", wantTask: "t1", wantConfig: "c1"},
+		{name: "empty config id", input: "tasks/t1/pushNotificationConfigs/", wantTask: "t1"},
+		{name: "task name", input: "tasks/t1", wantErr: true},
+		{name: "empty task id", input: "tasks//pushNotificationConfigs/c1", wantErr: true},
+		{name: "wrong collection", input: "tasks/t1/configs/c1", wantErr: true},
+		{name: "too long", input: "tasks/t1/pushNotificationConfigs/c1/x", wantErr: true},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			task, config, err := ParsePushConfigName(tc.input)
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("ParsePushConfigName(%q) error = %v, wantErr %v", tc.input, err, tc.wantErr)
+			}
+			if task != tc.wantTask || config != tc.wantConfig {
+				t.Errorf("ParsePushConfigName(%q) = (%q, %q), want (%q, %q)", tc.input, task, config, tc.wantTask, tc.wantConfig)
+			}
+		})
+	}
+
+	for _, name := range []string{"tasks", "tasks/", "task/t1", "tasks/t1/x"} {
+		if _, err := ParseTaskName(name); err == nil {
+			t.Errorf("ParseTaskName(%q) error = nil, want error", name)
+		}
+	}
+	if id, err := ParseTaskName(TaskName("t1")); err != nil || id != "t1" {
+		t.Errorf("ParseTaskName(TaskName(t1)) = (%q, %v), want t1", id, err)
+	}
+}
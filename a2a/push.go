@@ -0,0 +1,34 @@
+// Copyright 2025 The A2A Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package a2a
+
+// NotificationTokenHeader is the header carrying the token of the push notification
+// config with every notification, so that webhooks can validate the notification
+// was sent for one of their configs.
+const NotificationTokenHeader = "X-A2A-Notification-Token"
+
+// NotificationSignatureHeader is the header carrying the compact JWS signing a push
+// notification. The JWS header identifies the signing key with "kid" and its claims
+// contain the issue time in "iat" and the hex SHA-256 digest of the request body in
+// "request_body_sha256".
+const NotificationSignatureHeader = "X-A2A-Notification-Signature"
+
+// NotificationBodyHashClaim is the claim of a push notification signature containing
+// the hex SHA-256 digest of the request body.
+const NotificationBodyHashClaim = "request_body_sha256"
+
+// WellKnownJWKSPath is the path an agent publishes the public keys verifying its push
+// notifications at, relative to the base URL of the agent server.
+const WellKnownJWKSPath = "/.well-known/jwks.json"
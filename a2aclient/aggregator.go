@@ -0,0 +1,217 @@
+// Copyright 2025 The A2A Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package a2aclient
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+
+	"google.golang.org/protobuf/proto"
+
+	"github.com/a2aproject/a2a-go/a2a"
+	a2apb "github.com/a2aproject/a2a-go/grpc"
+	"github.com/a2aproject/a2a-go/internal/taskupdate"
+)
+
+// EventStream is a stream of events returned by SendStreamingMessage or TaskSubscription.
+// It is implemented by grpc.ServerStreamingClient[a2apb.StreamResponse], so the streams
+// of every transport can be used.
+type EventStream interface {
+	// Recv returns the next event, or io.EOF at the end of the stream.
+	Recv() (*a2apb.StreamResponse, error)
+}
+
+// AggregatorOption configures a TaskAggregator.
+type AggregatorOption func(*TaskAggregator)
+
+// WithArtifactComplete registers a function called with the assembled artifact once
+// its last chunk is received, or when the task reaches a terminal state while the
+// artifact is incomplete. The function is called by the goroutine applying the events
+// and must not call the methods of the aggregator.
+func WithArtifactComplete(fn func(artifact *a2apb.Artifact)) AggregatorOption {
+	return func(a *TaskAggregator) {
+		a.onComplete = fn
+	}
+}
+
+// TaskAggregator maintains a live view of a task built from the events of a stream.
+// Status updates replace the status of the task, moving the previous status message
+// to the history, and artifact updates are merged by artifact ID, appending the parts
+// of chunks. The methods of TaskAggregator are safe for concurrent use.
+type TaskAggregator struct {
+	onComplete func(artifact *a2apb.Artifact)
+
+	mu   sync.Mutex
+	task *a2apb.Task
+	// complete holds the IDs of the artifacts which received their last chunk.
+	complete map[string]struct{}
+}
+
+// NewTaskAggregator returns an aggregator starting from the task. The task may be nil,
+// in which case the task is created from the first event.
+func NewTaskAggregator(task *a2apb.Task, opts ...AggregatorOption) *TaskAggregator {
+	a := &TaskAggregator{complete: make(map[string]struct{})}
+	if task != nil {
+		a.task = proto.Clone(task).(*a2apb.Task)
+	}
+	for _, opt := range opts {
+		opt(a)
+	}
+	return a
+}
+
+// Task returns a snapshot of the task, or nil if no task event was applied yet.
+func (a *TaskAggregator) Task() *a2apb.Task {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if a.task == nil {
+		return nil
+	}
+	return proto.Clone(a.task).(*a2apb.Task)
+}
+
+// state returns the current state of the task.
+func (a *TaskAggregator) state() a2apb.TaskState {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return a.task.GetStatus().GetState()
+}
+
+// ArtifactComplete reports whether the artifact with the ID is fully received.
+func (a *TaskAggregator) ArtifactComplete(artifactID string) bool {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	_, ok := a.complete[artifactID]
+	return ok
+}
+
+// Apply updates the task with the event. Message events do not affect the task
+// and are ignored.
+func (a *TaskAggregator) Apply(event *a2apb.StreamResponse) error {
+	var completed []*a2apb.Artifact
+	err := func() error {
+		a.mu.Lock()
+		defer a.mu.Unlock()
+		var err error
+		completed, err = a.apply(event)
+		return err
+	}()
+	if err != nil {
+		return err
+	}
+	if a.onComplete != nil {
+		for _, artifact := range completed {
+			a.onComplete(artifact)
+		}
+	}
+	return nil
+}
+
+// Consume applies the events of the stream until it ends. It returns nil when the
+// stream ends with io.EOF.
+func (a *TaskAggregator) Consume(stream EventStream) error {
+	for {
+		event, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if err := a.Apply(event); err != nil {
+			return err
+		}
+	}
+}
+
+// apply updates the task with the event and returns the artifacts it completed.
+func (a *TaskAggregator) apply(event *a2apb.StreamResponse) ([]*a2apb.Artifact, error) {
+	switch p := event.GetPayload().(type) {
+	case *a2apb.StreamResponse_Msg:
+		return nil, nil
+
+	case *a2apb.StreamResponse_Task:
+		if err := a.checkTask(p.Task.GetId()); err != nil {
+			return nil, err
+		}
+		a.task = proto.Clone(p.Task).(*a2apb.Task)
+		return a.completeOnTerminal(), nil
+
+	case *a2apb.StreamResponse_StatusUpdate:
+		update := p.StatusUpdate
+		if err := a.checkTask(update.GetTaskId()); err != nil {
+			return nil, err
+		}
+		a.ensureTask(update.GetTaskId(), update.GetContextId())
+		taskupdate.ApplyStatusUpdate(a.task, update)
+		return a.completeOnTerminal(), nil
+
+	case *a2apb.StreamResponse_ArtifactUpdate:
+		update := p.ArtifactUpdate
+		if err := a.checkTask(update.GetTaskId()); err != nil {
+			return nil, err
+		}
+		if update.GetArtifact() == nil {
+			return nil, fmt.Errorf("%w: artifact update without artifact", a2a.ErrInvalidAgentResponse)
+		}
+		a.ensureTask(update.GetTaskId(), update.GetContextId())
+		id := update.GetArtifact().GetArtifactId()
+		if !update.GetAppend() {
+			// A new artifact or a replacement starts over.
+			delete(a.complete, id)
+		}
+		artifact := taskupdate.ApplyArtifactUpdate(a.task, update)
+		if !update.GetLastChunk() {
+			return nil, nil
+		}
+		a.complete[id] = struct{}{}
+		return []*a2apb.Artifact{proto.Clone(artifact).(*a2apb.Artifact)}, nil
+
+	default:
+		return nil, fmt.Errorf("%w: unsupported event %T", a2a.ErrInvalidAgentResponse, p)
+	}
+}
+
+func (a *TaskAggregator) checkTask(taskID string) error {
+	if a.task != nil && a.task.GetId() != taskID {
+		return fmt.Errorf("%w: event for task %s, expected %s", a2a.ErrInvalidAgentResponse, taskID, a.task.GetId())
+	}
+	return nil
+}
+
+func (a *TaskAggregator) ensureTask(taskID, contextID string) {
+	if a.task == nil {
+		a.task = &a2apb.Task{Id: taskID, ContextId: contextID}
+	}
+}
+
+// completeOnTerminal marks all the artifacts of a terminal task as complete and
+// returns the ones which were not complete yet.
+func (a *TaskAggregator) completeOnTerminal() []*a2apb.Artifact {
+	if !taskupdate.IsTerminal(a.task.GetStatus().GetState()) {
+		return nil
+	}
+	var completed []*a2apb.Artifact
+	for _, artifact := range a.task.GetArtifacts() {
+		if _, ok := a.complete[artifact.GetArtifactId()]; ok {
+			continue
+		}
+		a.complete[artifact.GetArtifactId()] = struct{}{}
+		completed = append(completed, proto.Clone(artifact).(*a2apb.Artifact))
+	}
+	return completed
+}
@@ -0,0 +1,219 @@
+// Copyright 2025 The A2A Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package a2aclient
+
+import (
+	"errors"
+	"io"
+	"sync"
+	"testing"
+
+	"google.golang.org/protobuf/proto"
+
+	"github.com/a2aproject/a2a-go/a2a"
+	a2apb "github.com/a2aproject/a2a-go/grpc"
+)
+
+// sliceStream is an EventStream returning the events, then err or io.EOF.
+type sliceStream struct {
+	events []*a2apb.StreamResponse
+	err    error
+	read   int
+}
+
+func (s *sliceStream) Recv() (*a2apb.StreamResponse, error) {
+	if s.read < len(s.events) {
+		s.read++
+		return s.events[s.read-1], nil
+	}
+	if s.err != nil {
+		return nil, s.err
+	}
+	return nil, io.EOF
+}
+
+func statusUpdateEvent(state a2apb.TaskState, msg string, final bool) *a2apb.StreamResponse {
+	status := &a2apb.TaskStatus{State: state}
+	if msg != "" {
+		status.Update = &a2apb.Message{MessageId: msg, Role: a2apb.Role_ROLE_AGENT}
+	}
+	return &a2apb.StreamResponse{Payload: &a2apb.StreamResponse_StatusUpdate{StatusUpdate: &a2apb.TaskStatusUpdateEvent{
+		TaskId:    "t1",
+		ContextId: "c1",
+		Status:    status,
+		Final:     final,
+	}}}
+}
+
+func artifactChunkEvent(id, text string, appending, last bool) *a2apb.StreamResponse {
+	return &a2apb.StreamResponse{Payload: &a2apb.StreamResponse_ArtifactUpdate{ArtifactUpdate: &a2apb.TaskArtifactUpdateEvent{
+		TaskId:    "t1",
+		ContextId: "c1",
+		Artifact:  &a2apb.Artifact{ArtifactId: id, Parts: []*a2apb.Part{{Part: &a2apb.Part_Text{Text: text}}}},
+		Append:    appending,
+		LastChunk: last,
+	}}}
+}
+
+func taskSnapshotEvent(state a2apb.TaskState) *a2apb.StreamResponse {
+	return &a2apb.StreamResponse{Payload: &a2apb.StreamResponse_Task{Task: &a2apb.Task{
+		Id:        "t1",
+		ContextId: "c1",
+		Status:    &a2apb.TaskStatus{State: state},
+	}}}
+}
+
+func artifactTexts(artifact *a2apb.Artifact) []string {
+	var texts []string
+	for _, part := range artifact.GetParts() {
+		texts = append(texts, part.GetText())
+	}
+	return texts
+}
+
+func TestTaskAggregator(t *testing.T) {
+	var mu sync.Mutex
+	var completed []string
+	a := NewTaskAggregator(nil, WithArtifactComplete(func(artifact *a2apb.Artifact) {
+		mu.Lock()
+		defer mu.Unlock()
+		completed = append(completed, artifact.GetArtifactId())
+	}))
+	if a.Task() != nil {
+		t.Fatalf("Task() = %v before any event, want nil", a.Task())
+	}
+	err := a.Consume(&sliceStream{events: []*a2apb.StreamResponse{
+		statusUpdateEvent(a2apb.TaskState_TASK_STATE_WORKING, "m1", false),
+		artifactChunkEvent("a1", "hello ", false, false),
+		artifactChunkEvent("a2", "partial", false, false),
+		artifactChunkEvent("a1", "world", true, true),
+		{Payload: &a2apb.StreamResponse_Msg{Msg: &a2apb.Message{MessageId: "ignored"}}},
+		statusUpdateEvent(a2apb.TaskState_TASK_STATE_COMPLETED, "m2", true),
+	}})
+	if err != nil {
+		t.Fatalf("Consume() error = %v", err)
+	}
+
+	task := a.Task()
+	if task.GetId() != "t1" || task.GetContextId() != "c1" || task.GetStatus().GetState() != a2apb.TaskState_TASK_STATE_COMPLETED {
+		t.Errorf("Task() = %v, want completed task t1 in c1", task)
+	}
+	if len(task.GetHistory()) != 1 || task.GetHistory()[0].GetMessageId() != "m1" {
+		t.Errorf("Task() history = %v, want the previous status message", task.GetHistory())
+	}
+	if len(task.GetArtifacts()) != 2 {
+		t.Fatalf("Task() has %d artifacts, want 2", len(task.GetArtifacts()))
+	}
+	if got := artifactTexts(task.GetArtifacts()[0]); len(got) != 2 || got[0] != "hello " || got[1] != "world" {
+		t.Errorf("assembled artifact parts = %q, want [hello  world]", got)
+	}
+	for _, id := range []string{"a1", "a2"} {
+		if !a.ArtifactComplete(id) {
+			t.Errorf("ArtifactComplete(%s) = false, want true", id)
+		}
+	}
+	// a1 completes with its last chunk, a2 when the task reaches a terminal state.
+	if len(completed) != 2 || completed[0] != "a1" || completed[1] != "a2" {
+		t.Errorf("completed artifacts = %v, want [a1 a2]", completed)
+	}
+}
+
+func TestTaskAggregatorArtifactReplacement(t *testing.T) {
+	a := NewTaskAggregator(nil)
+	events := []*a2apb.StreamResponse{
+		artifactChunkEvent("a1", "v1", false, true),
+		artifactChunkEvent("a1", "v2", false, false),
+	}
+	for _, event := range events {
+		if err := a.Apply(event); err != nil {
+			t.Fatalf("Apply() error = %v", err)
+		}
+	}
+	if a.ArtifactComplete("a1") {
+		t.Error("ArtifactComplete() = true for a replaced artifact waiting for chunks")
+	}
+	if got := artifactTexts(a.Task().GetArtifacts()[0]); len(got) != 1 || got[0] != "v2" {
+		t.Errorf("replaced artifact parts = %q, want [v2]", got)
+	}
+}
+
+func TestTaskAggregatorInitialTask(t *testing.T) {
+	initial := &a2apb.Task{
+		Id:        "t1",
+		ContextId: "c1",
+		Status:    &a2apb.TaskStatus{State: a2apb.TaskState_TASK_STATE_SUBMITTED},
+		Artifacts: []*a2apb.Artifact{{ArtifactId: "a1", Parts: []*a2apb.Part{{Part: &a2apb.Part_Text{Text: "hello "}}}}},
+	}
+	a := NewTaskAggregator(initial)
+	if err := a.Apply(artifactChunkEvent("a1", "world", true, true)); err != nil {
+		t.Fatalf("Apply() error = %v", err)
+	}
+	if got := artifactTexts(a.Task().GetArtifacts()[0]); len(got) != 2 {
+		t.Errorf("artifact parts = %q, want the chunk appended to the initial artifact", got)
+	}
+	if len(initial.GetArtifacts()[0].GetParts()) != 1 {
+		t.Error("Apply() modified the initial task")
+	}
+
+	// The snapshots are not affected by later events.
+	snapshot := a.Task()
+	if err := a.Apply(taskSnapshotEvent(a2apb.TaskState_TASK_STATE_WORKING)); err != nil {
+		t.Fatalf("Apply() error = %v", err)
+	}
+	if snapshot.GetStatus().GetState() != a2apb.TaskState_TASK_STATE_SUBMITTED || len(snapshot.GetArtifacts()) != 1 {
+		t.Errorf("snapshot changed to %v", snapshot)
+	}
+	if got := a.Task(); !proto.Equal(got, taskSnapshotEvent(a2apb.TaskState_TASK_STATE_WORKING).GetTask()) {
+		t.Errorf("Task() = %v, want the task of the last task event", got)
+	}
+}
+
+func TestTaskAggregatorInvalidEvents(t *testing.T) {
+	otherTask := statusUpdateEvent(a2apb.TaskState_TASK_STATE_WORKING, "", false)
+	otherTask.GetStatusUpdate().TaskId = "t2"
+	tests := []struct {
+		name  string
+		event *a2apb.StreamResponse
+	}{
+		{name: "event for another task", event: otherTask},
+		{name: "artifact update without artifact", event: &a2apb.StreamResponse{Payload: &a2apb.StreamResponse_ArtifactUpdate{
+			ArtifactUpdate: &a2apb.TaskArtifactUpdateEvent{TaskId: "t1"},
+		}}},
+		{name: "empty event", event: &a2apb.StreamResponse{}},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			a := NewTaskAggregator(&a2apb.Task{Id: "t1"})
+			if err := a.Apply(tc.event); !errors.Is(err, a2a.ErrInvalidAgentResponse) {
+				t.Errorf("Apply() error = %v, want %v", err, a2a.ErrInvalidAgentResponse)
+			}
+		})
+	}
+}
+
+func TestTaskAggregatorConsumeError(t *testing.T) {
+	streamErr := errors.New("connection reset")
+	a := NewTaskAggregator(nil)
+	err := a.Consume(&sliceStream{
+		events: []*a2apb.StreamResponse{statusUpdateEvent(a2apb.TaskState_TASK_STATE_WORKING, "", false)},
+		err:    streamErr,
+	})
+	if !errors.Is(err, streamErr) {
+		t.Errorf("Consume() error = %v, want %v", err, streamErr)
+	}
+	if a.Task().GetStatus().GetState() != a2apb.TaskState_TASK_STATE_WORKING {
+		t.Errorf("Task() = %v, want the events before the error applied", a.Task())
+	}
+}
@@ -0,0 +1,43 @@
+// Copyright 2025 The A2A Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package auth attaches credentials to the calls made to A2A agents, following the
+// security schemes and requirements declared by their AgentCard.
+//
+// A CredentialProvider supplies the credentials of the caller for the schemes of an
+// agent. For every call, an Interceptor selects the first security requirement of the
+// card for which the provider has credentials for all the schemes, and attaches them
+// as the schemes prescribe: API keys in a header, query parameter or cookie, and the
+// credentials of HTTP, OAuth2 and OpenID Connect schemes in the Authorization header.
+// Over HTTP, credentials are only sent to the origins of the URLs of the card.
+//
+//	card, err := resolver.Resolve(ctx, agentURL)
+//	interceptor := auth.NewInterceptor(card, auth.StaticCredentials{
+//		"apiKey": os.Getenv("AGENT_API_KEY"),
+//	})
+//	factory := a2aclient.NewFactory(
+//		a2aclient.WithJSONRPCTransport(jsonrpc.WithHTTPClient(interceptor.HTTPClient(nil))),
+//		a2aclient.WithGRPCTransport(append(interceptor.DialOptions(), creds)...))
+//	client, err := factory.CreateFromCard(ctx, card)
+//
+// ClientCredentials provides access tokens for OAuth2 schemes declaring a client
+// credentials flow. It requests tokens from the token URL of the flow and renews them
+// before they expire. The client secret is only sent to https token URLs on the host
+// of the agent unless other hosts are allowed, or the token URL is configured explicitly:
+//
+//	provider := auth.Chain(
+//		auth.NewClientCredentials(clientID, clientSecret,
+//			auth.WithAllowedTokenHosts("auth.example.com")),
+//		auth.StaticCredentials{"apiKey": apiKey})
+package auth
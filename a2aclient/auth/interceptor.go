@@ -0,0 +1,289 @@
+// Copyright 2025 The A2A Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package auth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+
+	a2apb "github.com/a2aproject/a2a-go/grpc"
+)
+
+// ErrNoSatisfiableRequirement is returned for calls to an agent when the provider has
+// no credentials for any of the security requirements of its card.
+var ErrNoSatisfiableRequirement = errors.New("no satisfiable security requirement")
+
+// Interceptor attaches credentials to the calls made to the agent described by a card.
+type Interceptor struct {
+	card     *a2apb.AgentCard
+	provider CredentialProvider
+}
+
+// NewInterceptor returns an Interceptor for the agent described by the card, obtaining
+// credentials from the provider.
+func NewInterceptor(card *a2apb.AgentCard, provider CredentialProvider) *Interceptor {
+	return &Interceptor{card: card, provider: provider}
+}
+
+// credentials are the values to attach to a call.
+type credentials struct {
+	headers map[string]string
+	query   map[string]string
+	cookies map[string]string
+}
+
+// credentials obtains the credentials of the first satisfiable security requirement.
+// Requirements using query parameters are skipped unless the transport supports them.
+// Agents without requirements are called without credentials.
+func (i *Interceptor) credentials(ctx context.Context, supportsQuery bool) (*credentials, error) {
+	requirements := i.card.GetSecurity()
+	if len(requirements) == 0 {
+		return &credentials{}, nil
+	}
+	var errs []error
+	for _, security := range requirements {
+		creds, err := i.satisfy(ctx, security, supportsQuery)
+		if err == nil {
+			return creds, nil
+		}
+		if !errors.Is(err, ErrNoCredential) {
+			return nil, err
+		}
+		errs = append(errs, err)
+	}
+	return nil, fmt.Errorf("%w: %w", ErrNoSatisfiableRequirement, errors.Join(errs...))
+}
+
+func (i *Interceptor) satisfy(ctx context.Context, security *a2apb.Security, supportsQuery bool) (*credentials, error) {
+	creds := &credentials{headers: make(map[string]string), query: make(map[string]string), cookies: make(map[string]string)}
+	names := make([]string, 0, len(security.GetSchemes()))
+	for name := range security.GetSchemes() {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	for _, name := range names {
+		scheme, ok := i.card.GetSecuritySchemes()[name]
+		if !ok {
+			return nil, fmt.Errorf("%w: %s is not declared", ErrNoCredential, name)
+		}
+		apiKey := scheme.GetApiKeySecurityScheme()
+		if apiKey != nil && strings.EqualFold(apiKey.GetLocation(), "query") && !supportsQuery {
+			return nil, fmt.Errorf("%w: %s is passed in a query parameter", ErrNoCredential, name)
+		}
+		req := SchemeRequest{Card: i.card, Name: name, Scheme: scheme, Scopes: security.GetSchemes()[name].GetList()}
+		credential, err := i.provider.Credential(ctx, req)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", name, err)
+		}
+		if err := creds.add(scheme, credential); err != nil {
+			return nil, fmt.Errorf("%s: %w", name, err)
+		}
+	}
+	return creds, nil
+}
+
+// add stores the credential where the scheme expects it.
+func (c *credentials) add(scheme *a2apb.SecurityScheme, credential string) error {
+	switch s := scheme.GetScheme().(type) {
+	case *a2apb.SecurityScheme_ApiKeySecurityScheme:
+		name := s.ApiKeySecurityScheme.GetName()
+		switch strings.ToLower(s.ApiKeySecurityScheme.GetLocation()) {
+		case "header":
+			c.headers[name] = credential
+		case "query":
+			c.query[name] = credential
+		case "cookie":
+			c.cookies[name] = credential
+		default:
+			return fmt.Errorf("unsupported API key location %q", s.ApiKeySecurityScheme.GetLocation())
+		}
+	case *a2apb.SecurityScheme_HttpAuthSecurityScheme:
+		c.setAuthorization(s.HttpAuthSecurityScheme.GetScheme(), credential)
+	case *a2apb.SecurityScheme_Oauth2SecurityScheme, *a2apb.SecurityScheme_OpenIdConnectSecurityScheme:
+		c.setAuthorization("Bearer", credential)
+	default:
+		return fmt.Errorf("unsupported security scheme %T", s)
+	}
+	return nil
+}
+
+func (c *credentials) setAuthorization(scheme, credential string) {
+	if strings.EqualFold(scheme, "bearer") {
+		scheme = "Bearer"
+	} else if strings.EqualFold(scheme, "basic") {
+		scheme = "Basic"
+	}
+	c.headers["Authorization"] = scheme + " " + credential
+}
+
+// cookieHeader returns the value of the Cookie header carrying the cookies.
+func (c *credentials) cookieHeader() string {
+	parts := make([]string, 0, len(c.cookies))
+	for name, value := range c.cookies {
+		parts = append(parts, (&http.Cookie{Name: name, Value: value}).String())
+	}
+	slices.Sort(parts)
+	return strings.Join(parts, "; ")
+}
+
+// UnaryInterceptor returns a gRPC interceptor attaching credentials to unary calls
+// as metadata. Cookies are sent in the cookie metadata.
+func (i *Interceptor) UnaryInterceptor() grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		ctx, err := i.outgoingContext(ctx)
+		if err != nil {
+			return err
+		}
+		return invoker(ctx, method, req, reply, cc, opts...)
+	}
+}
+
+// StreamInterceptor returns a gRPC interceptor attaching credentials to streaming calls.
+func (i *Interceptor) StreamInterceptor() grpc.StreamClientInterceptor {
+	return func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+		ctx, err := i.outgoingContext(ctx)
+		if err != nil {
+			return nil, err
+		}
+		return streamer(ctx, desc, cc, method, opts...)
+	}
+}
+
+// DialOptions returns the options installing the interceptors of the Interceptor on
+// a gRPC connection.
+func (i *Interceptor) DialOptions() []grpc.DialOption {
+	return []grpc.DialOption{
+		grpc.WithChainUnaryInterceptor(i.UnaryInterceptor()),
+		grpc.WithChainStreamInterceptor(i.StreamInterceptor()),
+	}
+}
+
+func (i *Interceptor) outgoingContext(ctx context.Context) (context.Context, error) {
+	creds, err := i.credentials(ctx, false)
+	if err != nil {
+		return nil, err
+	}
+	var kv []string
+	for name, value := range creds.headers {
+		kv = append(kv, strings.ToLower(name), value)
+	}
+	if len(creds.cookies) > 0 {
+		kv = append(kv, "cookie", creds.cookieHeader())
+	}
+	if len(kv) == 0 {
+		return ctx, nil
+	}
+	return metadata.AppendToOutgoingContext(ctx, kv...), nil
+}
+
+// RoundTripper returns an http.RoundTripper attaching credentials to the requests it
+// sends through base, or http.DefaultTransport if base is nil. Credentials are only
+// attached to requests for the origin of the card URL or of one of its additional
+// interfaces, so that they are not sent to other hosts when a request is redirected.
+func (i *Interceptor) RoundTripper(base http.RoundTripper) http.RoundTripper {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	return &roundTripper{interceptor: i, base: base}
+}
+
+// HTTPClient returns an HTTP client attaching credentials to its requests, using
+// base as the underlying transport. It is meant to be passed to jsonrpc.WithHTTPClient.
+func (i *Interceptor) HTTPClient(base http.RoundTripper) *http.Client {
+	return &http.Client{Transport: i.RoundTripper(base)}
+}
+
+type roundTripper struct {
+	interceptor *Interceptor
+	base        http.RoundTripper
+}
+
+// RoundTrip implements http.RoundTripper. The request is cloned before the credentials
+// are added, as round trippers must not modify their input.
+func (t *roundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	if !t.interceptor.trusts(req.URL) {
+		return t.base.RoundTrip(req)
+	}
+	creds, err := t.interceptor.credentials(req.Context(), true)
+	if err != nil {
+		if req.Body != nil {
+			_ = req.Body.Close()
+		}
+		return nil, err
+	}
+	req = req.Clone(req.Context())
+	for name, value := range creds.headers {
+		req.Header.Set(name, value)
+	}
+	if len(creds.query) > 0 {
+		query := req.URL.Query()
+		for name, value := range creds.query {
+			query.Set(name, value)
+		}
+		req.URL.RawQuery = query.Encode()
+	}
+	for name, value := range creds.cookies {
+		req.AddCookie(&http.Cookie{Name: name, Value: value})
+	}
+	return t.base.RoundTrip(req)
+}
+
+// trusts reports whether u has the origin of the card URL or of one of the additional
+// interfaces of the card.
+func (i *Interceptor) trusts(u *url.URL) bool {
+	want := origin(u)
+	if want == "" {
+		return false
+	}
+	urls := []string{i.card.GetUrl()}
+	for _, iface := range i.card.GetAdditionalInterfaces() {
+		urls = append(urls, iface.GetUrl())
+	}
+	for _, rawURL := range urls {
+		if agentURL, err := url.Parse(rawURL); err == nil && origin(agentURL) == want {
+			return true
+		}
+	}
+	return false
+}
+
+// origin returns the scheme, host and port of u, with the default port of the scheme
+// if u has none. It returns an empty string for URLs without a host.
+func origin(u *url.URL) string {
+	host := strings.ToLower(u.Hostname())
+	if host == "" {
+		return ""
+	}
+	scheme := strings.ToLower(u.Scheme)
+	port := u.Port()
+	if port == "" {
+		switch scheme {
+		case "http":
+			port = "80"
+		case "https":
+			port = "443"
+		}
+	}
+	return scheme + "://" + net.JoinHostPort(host, port)
+}
@@ -0,0 +1,479 @@
+// Copyright 2025 The A2A Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package auth
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+
+	"github.com/a2aproject/a2a-go/a2asrv"
+	srvauth "github.com/a2aproject/a2a-go/a2asrv/auth"
+	a2apb "github.com/a2aproject/a2a-go/grpc"
+	"github.com/a2aproject/a2a-go/jsonrpc"
+)
+
+func apiKeyScheme(location, name string) *a2apb.SecurityScheme {
+	return &a2apb.SecurityScheme{Scheme: &a2apb.SecurityScheme_ApiKeySecurityScheme{
+		ApiKeySecurityScheme: &a2apb.APIKeySecurityScheme{Location: location, Name: name},
+	}}
+}
+
+func httpScheme(scheme string) *a2apb.SecurityScheme {
+	return &a2apb.SecurityScheme{Scheme: &a2apb.SecurityScheme_HttpAuthSecurityScheme{
+		HttpAuthSecurityScheme: &a2apb.HTTPAuthSecurityScheme{Scheme: scheme},
+	}}
+}
+
+func oauth2Scheme(flows *a2apb.OAuthFlows) *a2apb.SecurityScheme {
+	return &a2apb.SecurityScheme{Scheme: &a2apb.SecurityScheme_Oauth2SecurityScheme{
+		Oauth2SecurityScheme: &a2apb.OAuth2SecurityScheme{Flows: flows},
+	}}
+}
+
+// requires returns a security requirement of the schemes with their scopes.
+func requires(schemes map[string][]string) *a2apb.Security {
+	security := &a2apb.Security{Schemes: make(map[string]*a2apb.StringList)}
+	for name, scopes := range schemes {
+		security.Schemes[name] = &a2apb.StringList{List: scopes}
+	}
+	return security
+}
+
+// newTestCard returns a card accepting an OAuth2 token with the write scope, HTTP
+// basic credentials together with a tenant query parameter, or an API key and a
+// session cookie.
+func newTestCard() *a2apb.AgentCard {
+	return &a2apb.AgentCard{
+		Url: "https://agent.example.com/rpc",
+		SecuritySchemes: map[string]*a2apb.SecurityScheme{
+			"oauth":   oauth2Scheme(nil),
+			"basic":   httpScheme("basic"),
+			"tenant":  apiKeyScheme("query", "tenant"),
+			"key":     apiKeyScheme("header", "X-API-Key"),
+			"session": apiKeyScheme("cookie", "session"),
+		},
+		Security: []*a2apb.Security{
+			requires(map[string][]string{"oauth": {"write"}}),
+			requires(map[string][]string{"basic": nil, "tenant": nil}),
+			requires(map[string][]string{"key": nil, "session": nil}),
+		},
+	}
+}
+
+// roundTripperFunc is a function implementing http.RoundTripper.
+type roundTripperFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+// closeRecorder is a request body recording whether it was closed.
+type closeRecorder struct {
+	io.Reader
+	closed bool
+}
+
+func (r *closeRecorder) Close() error {
+	r.closed = true
+	return nil
+}
+
+// sentRequest sends a request through the round tripper of the interceptor and
+// returns the request received by the base transport.
+func sentRequest(t *testing.T, i *Interceptor) (*http.Request, error) {
+	t.Helper()
+	var sent *http.Request
+	base := roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		sent = req
+		return &http.Response{StatusCode: http.StatusOK, Body: http.NoBody, Request: req}, nil
+	})
+	req := httptest.NewRequest(http.MethodPost, "https://agent.example.com/rpc?v=1", nil)
+	req.RequestURI = ""
+	resp, err := i.RoundTripper(base).RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+	_ = resp.Body.Close()
+	if len(req.Header) != 0 || req.URL.RawQuery != "v=1" {
+		t.Errorf("RoundTrip() modified the request: header = %v, query = %q", req.Header, req.URL.RawQuery)
+	}
+	return sent, nil
+}
+
+func TestInterceptorRoundTripper(t *testing.T) {
+	tests := []struct {
+		name        string
+		card        *a2apb.AgentCard
+		provider    CredentialProvider
+		wantHeaders map[string]string
+		wantQuery   string
+		wantCookies map[string]string
+		wantErr     error
+	}{
+		{
+			name:        "bearer token",
+			card:        newTestCard(),
+			provider:    StaticCredentials{"oauth": "token", "key": "k1", "session": "s1"},
+			wantHeaders: map[string]string{"Authorization": "Bearer token"},
+			wantQuery:   "v=1",
+		},
+		{
+			name:        "basic credentials and query parameter",
+			card:        newTestCard(),
+			provider:    StaticCredentials{"basic": BasicCredential("alice", "secret"), "tenant": "acme"},
+			wantHeaders: map[string]string{"Authorization": "Basic YWxpY2U6c2VjcmV0"},
+			wantQuery:   "tenant=acme&v=1",
+		},
+		{
+			name:        "header and cookie",
+			card:        newTestCard(),
+			provider:    StaticCredentials{"basic": BasicCredential("alice", "secret"), "key": "k1", "session": "s1"},
+			wantHeaders: map[string]string{"X-API-Key": "k1"},
+			wantQuery:   "v=1",
+			wantCookies: map[string]string{"session": "s1"},
+		},
+		{
+			name:      "no requirements",
+			card:      &a2apb.AgentCard{},
+			provider:  StaticCredentials{"oauth": "token"},
+			wantQuery: "v=1",
+		},
+		{
+			name:     "no satisfiable requirement",
+			card:     newTestCard(),
+			provider: StaticCredentials{"key": "k1"},
+			wantErr:  ErrNoSatisfiableRequirement,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			sent, err := sentRequest(t, NewInterceptor(tc.card, tc.provider))
+			if !errors.Is(err, tc.wantErr) {
+				t.Fatalf("RoundTrip() error = %v, want %v", err, tc.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			for name, want := range tc.wantHeaders {
+				if got := sent.Header.Get(name); got != want {
+					t.Errorf("header %s = %q, want %q", name, got, want)
+				}
+			}
+			if tc.wantHeaders["Authorization"] == "" && sent.Header.Get("Authorization") != "" {
+				t.Errorf("header Authorization = %q, want none", sent.Header.Get("Authorization"))
+			}
+			if sent.URL.RawQuery != tc.wantQuery {
+				t.Errorf("query = %q, want %q", sent.URL.RawQuery, tc.wantQuery)
+			}
+			cookies := make(map[string]string)
+			for _, c := range sent.Cookies() {
+				cookies[c.Name] = c.Value
+			}
+			if len(cookies) != len(tc.wantCookies) || (len(cookies) > 0 && !reflect.DeepEqual(cookies, tc.wantCookies)) {
+				t.Errorf("cookies = %v, want %v", cookies, tc.wantCookies)
+			}
+		})
+	}
+}
+
+func TestInterceptorTrustedOrigins(t *testing.T) {
+	card := newTestCard()
+	card.AdditionalInterfaces = []*a2apb.AgentInterface{{Url: "https://grpc.example.com:8443", Transport: "GRPC"}}
+	tests := []struct {
+		name      string
+		url       string
+		wantCreds bool
+	}{
+		{name: "card URL", url: "https://agent.example.com/rpc", wantCreds: true},
+		{name: "other path", url: "https://agent.example.com/other", wantCreds: true},
+		{name: "default port", url: "https://AGENT.example.com:443/rpc", wantCreds: true},
+		{name: "additional interface", url: "https://grpc.example.com:8443/", wantCreds: true},
+		{name: "other host", url: "https://attacker.example.com/rpc"},
+		{name: "other scheme", url: "http://agent.example.com/rpc"},
+		{name: "other port", url: "https://agent.example.com:8443/rpc"},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			var sent *http.Request
+			base := roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+				sent = req
+				return &http.Response{StatusCode: http.StatusOK, Body: http.NoBody, Request: req}, nil
+			})
+			provider := StaticCredentials{"basic": BasicCredential("alice", "secret"), "tenant": "acme"}
+			req, err := http.NewRequest(http.MethodPost, tc.url, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if _, err := NewInterceptor(card, provider).RoundTripper(base).RoundTrip(req); err != nil {
+				t.Fatalf("RoundTrip() error = %v", err)
+			}
+			gotCreds := sent.Header.Get("Authorization") != "" || sent.URL.Query().Has("tenant")
+			if gotCreds != tc.wantCreds {
+				t.Errorf("RoundTrip() attached credentials = %v, want %v", gotCreds, tc.wantCreds)
+			}
+		})
+	}
+}
+
+func TestInterceptorRedirect(t *testing.T) {
+	received := make(chan *http.Request, 1)
+	other := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received <- r
+	}))
+	defer other.Close()
+	agent := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, other.URL+"/rpc", http.StatusTemporaryRedirect)
+	}))
+	defer agent.Close()
+
+	card := newTestCard()
+	card.Url = agent.URL
+	provider := StaticCredentials{"key": "k1", "session": "s1", "basic": BasicCredential("alice", "secret"), "tenant": "acme"}
+	client := NewInterceptor(card, provider).HTTPClient(nil)
+	resp, err := client.Post(agent.URL+"/rpc", "application/json", strings.NewReader("{}"))
+	if err != nil {
+		t.Fatalf("Post() error = %v", err)
+	}
+	_ = resp.Body.Close()
+
+	r := <-received
+	if r.Header.Get("Authorization") != "" || r.Header.Get("X-API-Key") != "" || r.Header.Get("Cookie") != "" || r.URL.Query().Has("tenant") {
+		t.Errorf("redirected request carries credentials: header = %v, query = %q", r.Header, r.URL.RawQuery)
+	}
+}
+
+func TestInterceptorPassesScopes(t *testing.T) {
+	var requests []SchemeRequest
+	provider := CredentialProviderFunc(func(_ context.Context, req SchemeRequest) (string, error) {
+		requests = append(requests, req)
+		return "", ErrNoCredential
+	})
+	card := newTestCard()
+	_, err := sentRequest(t, NewInterceptor(card, provider))
+	if !errors.Is(err, ErrNoSatisfiableRequirement) {
+		t.Fatalf("RoundTrip() error = %v, want %v", err, ErrNoSatisfiableRequirement)
+	}
+	var names []string
+	for _, req := range requests {
+		names = append(names, req.Name)
+		if req.Card != card || req.Scheme != card.GetSecuritySchemes()[req.Name] {
+			t.Errorf("SchemeRequest for %s has the wrong card or scheme", req.Name)
+		}
+	}
+	// The first scheme of every requirement is requested, in the order of the card.
+	if want := []string{"oauth", "basic", "key"}; !reflect.DeepEqual(names, want) {
+		t.Errorf("requested schemes = %v, want %v", names, want)
+	}
+	if want := []string{"write"}; !reflect.DeepEqual(requests[0].Scopes, want) {
+		t.Errorf("scopes = %v, want %v", requests[0].Scopes, want)
+	}
+}
+
+func TestInterceptorProviderError(t *testing.T) {
+	errProvider := errors.New("token endpoint unavailable")
+	calls := 0
+	provider := CredentialProviderFunc(func(context.Context, SchemeRequest) (string, error) {
+		calls++
+		return "", errProvider
+	})
+	body := &closeRecorder{Reader: strings.NewReader("{}")}
+	req, err := http.NewRequest(http.MethodPost, "https://agent.example.com/", body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	base := roundTripperFunc(func(*http.Request) (*http.Response, error) {
+		t.Error("the request was sent")
+		return nil, errors.New("unexpected request")
+	})
+	_, err = NewInterceptor(newTestCard(), provider).RoundTripper(base).RoundTrip(req)
+	if !errors.Is(err, errProvider) || errors.Is(err, ErrNoSatisfiableRequirement) {
+		t.Errorf("RoundTrip() error = %v, want %v", err, errProvider)
+	}
+	if calls != 1 {
+		t.Errorf("the provider was called %d times, want 1", calls)
+	}
+	if !body.closed {
+		t.Error("the request body was not closed")
+	}
+}
+
+func TestInterceptorUnsupportedLocation(t *testing.T) {
+	card := &a2apb.AgentCard{
+		Url:             "https://agent.example.com/rpc",
+		SecuritySchemes: map[string]*a2apb.SecurityScheme{"key": apiKeyScheme("body", "key")},
+		Security:        []*a2apb.Security{requires(map[string][]string{"key": nil})},
+	}
+	_, err := sentRequest(t, NewInterceptor(card, StaticCredentials{"key": "k1"}))
+	if err == nil || !strings.Contains(err.Error(), `unsupported API key location "body"`) {
+		t.Errorf("RoundTrip() error = %v, want an unsupported location", err)
+	}
+}
+
+func TestInterceptorUnaryInterceptor(t *testing.T) {
+	tests := []struct {
+		name     string
+		provider CredentialProvider
+		want     metadata.MD
+		wantErr  error
+	}{
+		{
+			name:     "bearer token",
+			provider: StaticCredentials{"oauth": "token"},
+			want:     metadata.Pairs("authorization", "Bearer token"),
+		},
+		{
+			name:     "header and cookie",
+			provider: StaticCredentials{"key": "k1", "session": "s1"},
+			want:     metadata.Pairs("x-api-key", "k1", "cookie", "session=s1"),
+		},
+		{
+			// Query parameters cannot be sent over gRPC.
+			name:     "query parameter",
+			provider: StaticCredentials{"basic": BasicCredential("alice", "secret"), "tenant": "acme"},
+			wantErr:  ErrNoSatisfiableRequirement,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			var got metadata.MD
+			invoker := func(ctx context.Context, _ string, _, _ any, _ *grpc.ClientConn, _ ...grpc.CallOption) error {
+				got, _ = metadata.FromOutgoingContext(ctx)
+				return nil
+			}
+			interceptor := NewInterceptor(newTestCard(), tc.provider).UnaryInterceptor()
+			err := interceptor(context.Background(), a2apb.A2AService_SendMessage_FullMethodName, nil, nil, nil, invoker)
+			if !errors.Is(err, tc.wantErr) {
+				t.Fatalf("interceptor() error = %v, want %v", err, tc.wantErr)
+			}
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("metadata = %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestInterceptorStreamInterceptor(t *testing.T) {
+	var got metadata.MD
+	streamer := func(ctx context.Context, _ *grpc.StreamDesc, _ *grpc.ClientConn, _ string, _ ...grpc.CallOption) (grpc.ClientStream, error) {
+		got, _ = metadata.FromOutgoingContext(ctx)
+		return nil, nil
+	}
+	interceptor := NewInterceptor(newTestCard(), StaticCredentials{"oauth": "token"}).StreamInterceptor()
+	if _, err := interceptor(context.Background(), &grpc.StreamDesc{}, nil, a2apb.A2AService_SendStreamingMessage_FullMethodName, streamer); err != nil {
+		t.Fatalf("interceptor() error = %v", err)
+	}
+	if want := metadata.Pairs("authorization", "Bearer token"); !reflect.DeepEqual(got, want) {
+		t.Errorf("metadata = %v, want %v", got, want)
+	}
+
+	interceptor = NewInterceptor(newTestCard(), StaticCredentials{}).StreamInterceptor()
+	_, err := interceptor(context.Background(), &grpc.StreamDesc{}, nil, a2apb.A2AService_SendStreamingMessage_FullMethodName, streamer)
+	if !errors.Is(err, ErrNoSatisfiableRequirement) {
+		t.Errorf("interceptor() error = %v, want %v", err, ErrNoSatisfiableRequirement)
+	}
+}
+
+// whoamiExecutor completes tasks with the subject of the authenticated principal.
+type whoamiExecutor struct{}
+
+func (whoamiExecutor) Execute(ctx context.Context, reqCtx *a2asrv.RequestContext, queue a2asrv.EventQueue) error {
+	subject := "anonymous"
+	if p, ok := srvauth.FromContext(ctx); ok {
+		subject = p.Scheme + ":" + p.Subject
+	}
+	updater := a2asrv.NewTaskUpdater(reqCtx, queue)
+	return updater.Complete(ctx, updater.NewAgentMessage(&a2apb.Part{Part: &a2apb.Part_Text{Text: subject}}))
+}
+
+func (whoamiExecutor) Cancel(context.Context, *a2asrv.RequestContext, a2asrv.EventQueue) error {
+	return nil
+}
+
+func TestInterceptorWithAuthenticator(t *testing.T) {
+	card := newTestCard()
+	authn, err := srvauth.NewAuthenticator(card,
+		srvauth.WithVerifier("oauth", srvauth.VerifierFunc(func(_ context.Context, token string) (*srvauth.Principal, error) {
+			if token != "token" {
+				return nil, errors.New("invalid token")
+			}
+			return &srvauth.Principal{Subject: "alice", Scopes: []string{"write"}}, nil
+		})),
+		srvauth.WithVerifier("basic", srvauth.BasicVerifier(func(_ context.Context, username, password string) (*srvauth.Principal, error) {
+			if password != "secret" {
+				return nil, errors.New("wrong password")
+			}
+			return &srvauth.Principal{Subject: username}, nil
+		})),
+		srvauth.WithVerifier("tenant", srvauth.VerifierFunc(func(_ context.Context, tenant string) (*srvauth.Principal, error) {
+			return &srvauth.Principal{Subject: tenant}, nil
+		})),
+		srvauth.WithVerifier("key", srvauth.VerifierFunc(func(_ context.Context, key string) (*srvauth.Principal, error) {
+			return &srvauth.Principal{Subject: key}, nil
+		})),
+		srvauth.WithVerifier("session", srvauth.VerifierFunc(func(_ context.Context, session string) (*srvauth.Principal, error) {
+			return &srvauth.Principal{Subject: session}, nil
+		})),
+	)
+	if err != nil {
+		t.Fatalf("NewAuthenticator() error = %v", err)
+	}
+	server := httptest.NewServer(authn.Middleware(jsonrpc.NewHandler(a2asrv.NewHandler(whoamiExecutor{}))))
+	defer server.Close()
+	card.Url = server.URL
+
+	tests := []struct {
+		name     string
+		provider CredentialProvider
+		want     string
+		wantErr  bool
+	}{
+		{name: "bearer token", provider: StaticCredentials{"oauth": "token"}, want: "oauth:alice"},
+		{
+			name:     "basic credentials and query parameter",
+			provider: StaticCredentials{"basic": BasicCredential("bob", "secret"), "tenant": "acme"},
+			want:     "basic:bob",
+		},
+		{name: "header and cookie", provider: StaticCredentials{"key": "k1", "session": "s1"}, want: "key:k1"},
+		{name: "rejected credentials", provider: StaticCredentials{"oauth": "expired"}, wantErr: true},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := jsonrpc.NewClient(server.URL, jsonrpc.WithHTTPClient(NewInterceptor(card, tc.provider).HTTPClient(nil)))
+			resp, err := client.SendMessage(context.Background(), &a2apb.SendMessageRequest{Request: &a2apb.Message{
+				MessageId: "m1",
+				Role:      a2apb.Role_ROLE_USER,
+				Content:   []*a2apb.Part{{Part: &a2apb.Part_Text{Text: "who am I?"}}},
+			}})
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("SendMessage() error = %v, want error: %v", err, tc.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if got := resp.GetTask().GetStatus().GetUpdate().GetContent()[0].GetText(); got != tc.want {
+				t.Errorf("SendMessage() principal = %q, want %q", got, tc.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,303 @@
+// Copyright 2025 The A2A Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package auth
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	a2apb "github.com/a2aproject/a2a-go/grpc"
+)
+
+// DefaultExpiryDelta is how long before their expiry tokens are renewed by default.
+const DefaultExpiryDelta = 30 * time.Second
+
+// ErrUntrustedTokenURL is returned by ClientCredentials for schemes declaring a token
+// or refresh URL on a host which is not trusted with the client credentials.
+var ErrUntrustedTokenURL = errors.New("untrusted token URL")
+
+// TokenError is the error response of an OAuth2 token endpoint.
+type TokenError struct {
+	// StatusCode is the HTTP status of the response.
+	StatusCode int
+	// Code is the OAuth2 error code, such as "invalid_client".
+	Code string
+	// Description is the optional human-readable description of the error.
+	Description string
+}
+
+func (e *TokenError) Error() string {
+	msg := fmt.Sprintf("token request failed with HTTP status %d", e.StatusCode)
+	if e.Code != "" {
+		msg += ": " + e.Code
+	}
+	if e.Description != "" {
+		msg += ": " + e.Description
+	}
+	return msg
+}
+
+// ClientCredentialsOption configures ClientCredentials.
+type ClientCredentialsOption func(*ClientCredentials)
+
+// WithTokenHTTPClient sets the client used for token requests. http.DefaultClient is
+// used by default.
+func WithTokenHTTPClient(client *http.Client) ClientCredentialsOption {
+	return func(c *ClientCredentials) {
+		c.client = client
+	}
+}
+
+// WithExpiryDelta sets how long before their expiry tokens are renewed.
+// DefaultExpiryDelta is used by default.
+func WithExpiryDelta(delta time.Duration) ClientCredentialsOption {
+	return func(c *ClientCredentials) {
+		c.expiryDelta = delta
+	}
+}
+
+// WithTokenParams adds parameters to the token requests, for example the audience
+// required by some authorization servers.
+func WithTokenParams(params url.Values) ClientCredentialsOption {
+	return func(c *ClientCredentials) {
+		for k, v := range params {
+			c.params[k] = append(c.params[k], v...)
+		}
+	}
+}
+
+// WithTokenURL sets the token URL of the authorization server. Tokens are requested
+// from it instead of the token and refresh URLs declared by the schemes, which are
+// then ignored.
+func WithTokenURL(tokenURL string) ClientCredentialsOption {
+	return func(c *ClientCredentials) {
+		c.tokenURL = tokenURL
+	}
+}
+
+// WithAllowedTokenHosts sets the hosts the token and refresh URLs declared by the
+// schemes may point to, replacing the host of the AgentCard URL allowed by default.
+// Hosts are compared without their port.
+func WithAllowedTokenHosts(hosts ...string) ClientCredentialsOption {
+	return func(c *ClientCredentials) {
+		c.allowedHosts = append(c.allowedHosts, hosts...)
+	}
+}
+
+// WithInsecureTokenURLs allows the token and refresh URLs declared by the schemes to
+// use http instead of https. It is meant for tests and local development.
+func WithInsecureTokenURLs() ClientCredentialsOption {
+	return func(c *ClientCredentials) {
+		c.insecure = true
+	}
+}
+
+// ClientCredentials is a CredentialProvider obtaining access tokens for OAuth2 schemes
+// with the client credentials grant, using the token URL of the ClientCredentials flow
+// declared by the scheme. Only the scopes listed by the security requirement are
+// requested. Tokens are cached by token URL and scopes, and renewed shortly before they
+// expire, using the refresh token if the server issued one. It returns ErrNoCredential
+// for other schemes, so it is usually chained with providers for them.
+//
+// As the client secret is sent to the token URL, the URLs declared by the card are
+// only used if they use https and their host is the host of the card URL, or one of
+// the hosts set with WithAllowedTokenHosts. Other URLs fail with ErrUntrustedTokenURL.
+// WithTokenURL configures the authorization server explicitly instead.
+type ClientCredentials struct {
+	clientID     string
+	clientSecret string
+	client       *http.Client
+	expiryDelta  time.Duration
+	params       url.Values
+	tokenURL     string
+	allowedHosts []string
+	insecure     bool
+
+	mu     sync.Mutex
+	tokens map[string]*cachedToken
+}
+
+// cachedToken is the token of a token URL and set of scopes. Its mutex is held while
+// the token is renewed, so that concurrent calls wait for a single token request.
+type cachedToken struct {
+	mu           sync.Mutex
+	accessToken  string
+	refreshToken string
+	expiry       time.Time
+}
+
+// NewClientCredentials returns ClientCredentials authenticating to the authorization
+// server with the client ID and secret.
+func NewClientCredentials(clientID, clientSecret string, opts ...ClientCredentialsOption) *ClientCredentials {
+	c := &ClientCredentials{
+		clientID:     clientID,
+		clientSecret: clientSecret,
+		client:       http.DefaultClient,
+		expiryDelta:  DefaultExpiryDelta,
+		params:       make(url.Values),
+		tokens:       make(map[string]*cachedToken),
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
+// Credential implements CredentialProvider.
+func (c *ClientCredentials) Credential(ctx context.Context, req SchemeRequest) (string, error) {
+	flow := req.Scheme.GetOauth2SecurityScheme().GetFlows().GetClientCredentials()
+	if flow == nil || (c.tokenURL == "" && flow.GetTokenUrl() == "") {
+		return "", ErrNoCredential
+	}
+	tokenURL, refreshURL := c.tokenURL, c.tokenURL
+	if tokenURL == "" {
+		tokenURL, refreshURL = flow.GetTokenUrl(), flow.GetRefreshUrl()
+		if refreshURL == "" {
+			refreshURL = tokenURL
+		}
+		for _, u := range []string{tokenURL, refreshURL} {
+			if err := c.checkTokenURL(req.Card, u); err != nil {
+				return "", err
+			}
+		}
+	}
+	scopes := slices.Clone(req.Scopes)
+	slices.Sort(scopes)
+	scopes = slices.Compact(scopes)
+
+	c.mu.Lock()
+	key := tokenURL + " " + strings.Join(scopes, " ")
+	token, ok := c.tokens[key]
+	if !ok {
+		token = &cachedToken{}
+		c.tokens[key] = token
+	}
+	c.mu.Unlock()
+
+	token.mu.Lock()
+	defer token.mu.Unlock()
+	if token.accessToken != "" && (token.expiry.IsZero() || time.Until(token.expiry) > c.expiryDelta) {
+		return token.accessToken, nil
+	}
+	if token.refreshToken != "" {
+		form := url.Values{"grant_type": {"refresh_token"}, "refresh_token": {token.refreshToken}}
+		// A failed refresh falls back to a new grant, which only needs the client credentials.
+		if err := c.request(ctx, refreshURL, form, scopes, token); err == nil {
+			return token.accessToken, nil
+		}
+		token.refreshToken = ""
+	}
+	if err := c.request(ctx, tokenURL, url.Values{"grant_type": {"client_credentials"}}, scopes, token); err != nil {
+		return "", err
+	}
+	return token.accessToken, nil
+}
+
+// checkTokenURL checks that a URL declared by the card uses https and is on a trusted host.
+func (c *ClientCredentials) checkTokenURL(card *a2apb.AgentCard, rawURL string) error {
+	u, err := url.Parse(rawURL)
+	if err != nil || (u.Scheme != "https" && u.Scheme != "http") || u.Hostname() == "" {
+		return fmt.Errorf("%w: invalid URL %q", ErrUntrustedTokenURL, rawURL)
+	}
+	if u.Scheme == "http" && !c.insecure {
+		return fmt.Errorf("%w: %s does not use https", ErrUntrustedTokenURL, rawURL)
+	}
+	allowed := c.allowedHosts
+	if len(allowed) == 0 {
+		if cardURL, err := url.Parse(card.GetUrl()); err == nil && cardURL.Hostname() != "" {
+			allowed = []string{cardURL.Hostname()}
+		}
+	}
+	host := strings.TrimSuffix(u.Hostname(), ".")
+	for _, h := range allowed {
+		if strings.EqualFold(strings.TrimSuffix(h, "."), host) {
+			return nil
+		}
+	}
+	return fmt.Errorf("%w: %s is not an allowed host", ErrUntrustedTokenURL, u.Hostname())
+}
+
+// request obtains a token from the endpoint and stores it in token.
+func (c *ClientCredentials) request(ctx context.Context, endpoint string, form url.Values, scopes []string, token *cachedToken) error {
+	for k, v := range c.params {
+		form[k] = append(form[k], v...)
+	}
+	if len(scopes) > 0 {
+		form.Set("scope", strings.Join(scopes, " "))
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return fmt.Errorf("failed to create token request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+	// RFC 6749 requires the client credentials to be form-encoded before Basic encoding.
+	req.SetBasicAuth(url.QueryEscape(c.clientID), url.QueryEscape(c.clientSecret))
+	issued := time.Now()
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to request token: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+	body, err := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if err != nil {
+		return fmt.Errorf("failed to read token response: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		tokenErr := &TokenError{StatusCode: resp.StatusCode}
+		var errResp struct {
+			Error            string `json:"error"`
+			ErrorDescription string `json:"error_description"`
+		}
+		if json.Unmarshal(body, &errResp) == nil {
+			tokenErr.Code, tokenErr.Description = errResp.Error, errResp.ErrorDescription
+		}
+		return tokenErr
+	}
+	var tokenResp struct {
+		AccessToken  string      `json:"access_token"`
+		TokenType    string      `json:"token_type"`
+		ExpiresIn    json.Number `json:"expires_in"`
+		RefreshToken string      `json:"refresh_token"`
+	}
+	if err := json.Unmarshal(body, &tokenResp); err != nil {
+		return fmt.Errorf("failed to decode token response: %w", err)
+	}
+	if tokenResp.AccessToken == "" {
+		return errors.New("token response has no access token")
+	}
+	if tokenResp.TokenType != "" && !strings.EqualFold(tokenResp.TokenType, "bearer") {
+		return fmt.Errorf("unsupported token type %q", tokenResp.TokenType)
+	}
+	token.accessToken = tokenResp.AccessToken
+	token.expiry = time.Time{}
+	if seconds, err := tokenResp.ExpiresIn.Int64(); err == nil && seconds > 0 {
+		token.expiry = issued.Add(time.Duration(seconds) * time.Second)
+	}
+	// Servers may omit the refresh token from refresh responses to keep the previous one.
+	if tokenResp.RefreshToken != "" {
+		token.refreshToken = tokenResp.RefreshToken
+	}
+	return nil
+}
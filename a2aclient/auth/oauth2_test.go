@@ -0,0 +1,553 @@
+// Copyright 2025 The A2A Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package auth
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	a2apb "github.com/a2aproject/a2a-go/grpc"
+)
+
+const (
+	testClientID     = "client id"
+	testClientSecret = "s&cret"
+)
+
+// tokenServer is an OAuth2 token endpoint issuing numbered tokens over https.
+type tokenServer struct {
+	*httptest.Server
+
+	mu sync.Mutex
+	// expiresIn is the lifetime of the issued tokens in seconds, if positive.
+	expiresIn int
+	// refreshToken is issued with the tokens if not empty.
+	refreshToken string
+	// refreshFails makes refresh requests fail.
+	refreshFails bool
+	// tokenType is the type of the issued tokens, Bearer by default.
+	tokenType string
+	// requests are the paths and forms of the token requests.
+	requests []tokenRequest
+}
+
+type tokenRequest struct {
+	path string
+	form url.Values
+}
+
+func newTokenServer(t *testing.T) *tokenServer {
+	t.Helper()
+	s := &tokenServer{}
+	s.Server = httptest.NewTLSServer(http.HandlerFunc(s.handle))
+	t.Cleanup(s.Close)
+	return s
+}
+
+func (s *tokenServer) handle(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if err := r.ParseForm(); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	s.requests = append(s.requests, tokenRequest{path: r.URL.Path, form: r.PostForm})
+	w.Header().Set("Content-Type", "application/json")
+	id, secret, _ := r.BasicAuth()
+	id, _ = url.QueryUnescape(id)
+	secret, _ = url.QueryUnescape(secret)
+	if id != testClientID || secret != testClientSecret {
+		w.WriteHeader(http.StatusUnauthorized)
+		_ = json.NewEncoder(w).Encode(map[string]string{"error": "invalid_client", "error_description": "unknown client"})
+		return
+	}
+	if r.PostForm.Get("grant_type") == "refresh_token" && (s.refreshFails || r.PostForm.Get("refresh_token") != s.refreshToken) {
+		w.WriteHeader(http.StatusBadRequest)
+		_ = json.NewEncoder(w).Encode(map[string]string{"error": "invalid_grant"})
+		return
+	}
+	resp := map[string]any{"access_token": fmt.Sprintf("token%d", len(s.requests)), "token_type": "Bearer"}
+	if s.tokenType != "" {
+		resp["token_type"] = s.tokenType
+	}
+	if s.expiresIn > 0 {
+		resp["expires_in"] = s.expiresIn
+	}
+	if s.refreshToken != "" {
+		resp["refresh_token"] = s.refreshToken
+	}
+	_ = json.NewEncoder(w).Encode(resp)
+}
+
+// grants returns the grant types of the token requests.
+func (s *tokenServer) grants() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var grants []string
+	for _, r := range s.requests {
+		grants = append(grants, r.form.Get("grant_type"))
+	}
+	return grants
+}
+
+// paths returns the paths of the token requests.
+func (s *tokenServer) paths() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var paths []string
+	for _, r := range s.requests {
+		paths = append(paths, r.path)
+	}
+	return paths
+}
+
+func (s *tokenServer) lastRequest() tokenRequest {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if len(s.requests) == 0 {
+		return tokenRequest{}
+	}
+	return s.requests[len(s.requests)-1]
+}
+
+func clientCredentialsScheme(tokenURL, refreshURL string) *a2apb.SecurityScheme {
+	return oauth2Scheme(&a2apb.OAuthFlows{Flow: &a2apb.OAuthFlows_ClientCredentials{
+		ClientCredentials: &a2apb.ClientCredentialsOAuthFlow{TokenUrl: tokenURL, RefreshUrl: refreshURL},
+	}})
+}
+
+// schemeRequest returns a request for the client credentials scheme of an agent
+// served from the same host as the token server.
+func (s *tokenServer) schemeRequest(scopes ...string) SchemeRequest {
+	return SchemeRequest{
+		Card:   &a2apb.AgentCard{Url: "https://127.0.0.1:9999/"},
+		Name:   "oauth",
+		Scheme: clientCredentialsScheme(s.URL+"/token", s.URL+"/refresh"),
+		Scopes: scopes,
+	}
+}
+
+func TestClientCredentialsCachesTokens(t *testing.T) {
+	server := newTokenServer(t)
+	server.expiresIn = 3600
+	c := NewClientCredentials(testClientID, testClientSecret, WithTokenHTTPClient(server.Client()))
+	ctx := context.Background()
+	tests := []struct {
+		name   string
+		scopes []string
+		want   string
+	}{
+		{name: "first token", scopes: []string{"write", "read"}, want: "token1"},
+		{name: "cached token", scopes: []string{"read", "write", "read"}, want: "token1"},
+		{name: "other scopes", scopes: []string{"read"}, want: "token2"},
+		{name: "cached token of other scopes", scopes: []string{"read"}, want: "token2"},
+		{name: "no scopes", want: "token3"},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := c.Credential(ctx, server.schemeRequest(tc.scopes...))
+			if err != nil {
+				t.Fatalf("Credential() error = %v", err)
+			}
+			if got != tc.want {
+				t.Errorf("Credential() = %q, want %q", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestClientCredentialsRequestsScopes(t *testing.T) {
+	server := newTokenServer(t)
+	c := NewClientCredentials(testClientID, testClientSecret,
+		WithTokenHTTPClient(server.Client()), WithTokenParams(url.Values{"audience": {"agent"}}))
+	tests := []struct {
+		name      string
+		scopes    []string
+		wantScope []string
+	}{
+		{name: "sorted scopes", scopes: []string{"write", "read", "write"}, wantScope: []string{"read write"}},
+		{name: "no scopes"},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if _, err := c.Credential(context.Background(), server.schemeRequest(tc.scopes...)); err != nil {
+				t.Fatalf("Credential() error = %v", err)
+			}
+			form := server.lastRequest().form
+			if got := form["scope"]; strings.Join(got, ",") != strings.Join(tc.wantScope, ",") {
+				t.Errorf("scope = %q, want %q", got, tc.wantScope)
+			}
+			if got := form.Get("audience"); got != "agent" {
+				t.Errorf("audience = %q, want %q", got, "agent")
+			}
+			if got := form.Get("grant_type"); got != "client_credentials" {
+				t.Errorf("grant_type = %q, want client_credentials", got)
+			}
+		})
+	}
+}
+
+func TestClientCredentialsRenewsTokens(t *testing.T) {
+	tests := []struct {
+		name         string
+		expiresIn    int
+		expiryDelta  time.Duration
+		refreshToken string
+		refreshFails bool
+		wantTokens   []string
+		wantGrants   []string
+		wantPaths    []string
+	}{
+		{
+			name:        "token valid beyond the expiry delta",
+			expiresIn:   3600,
+			expiryDelta: time.Minute,
+			wantTokens:  []string{"token1", "token1"},
+			wantGrants:  []string{"client_credentials"},
+			wantPaths:   []string{"/token"},
+		},
+		{
+			name:       "token without expiry",
+			wantTokens: []string{"token1", "token1"},
+			wantGrants: []string{"client_credentials"},
+			wantPaths:  []string{"/token"},
+		},
+		{
+			name:        "token expiring within the expiry delta",
+			expiresIn:   60,
+			expiryDelta: 2 * time.Minute,
+			wantTokens:  []string{"token1", "token2"},
+			wantGrants:  []string{"client_credentials", "client_credentials"},
+			wantPaths:   []string{"/token", "/token"},
+		},
+		{
+			name:         "refresh token",
+			expiresIn:    60,
+			expiryDelta:  2 * time.Minute,
+			refreshToken: "refresh",
+			wantTokens:   []string{"token1", "token2"},
+			wantGrants:   []string{"client_credentials", "refresh_token"},
+			wantPaths:    []string{"/token", "/refresh"},
+		},
+		{
+			name:         "failed refresh",
+			expiresIn:    60,
+			expiryDelta:  2 * time.Minute,
+			refreshToken: "refresh",
+			refreshFails: true,
+			wantTokens:   []string{"token1", "token3"},
+			wantGrants:   []string{"client_credentials", "refresh_token", "client_credentials"},
+			wantPaths:    []string{"/token", "/refresh", "/token"},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			server := newTokenServer(t)
+			server.expiresIn, server.refreshToken = tc.expiresIn, tc.refreshToken
+			opts := []ClientCredentialsOption{WithTokenHTTPClient(server.Client())}
+			if tc.expiryDelta > 0 {
+				opts = append(opts, WithExpiryDelta(tc.expiryDelta))
+			}
+			c := NewClientCredentials(testClientID, testClientSecret, opts...)
+			var tokens []string
+			for i := range tc.wantTokens {
+				if i > 0 {
+					server.mu.Lock()
+					server.refreshFails = tc.refreshFails
+					server.mu.Unlock()
+				}
+				token, err := c.Credential(context.Background(), server.schemeRequest("read"))
+				if err != nil {
+					t.Fatalf("Credential() error = %v", err)
+				}
+				tokens = append(tokens, token)
+			}
+			if strings.Join(tokens, ",") != strings.Join(tc.wantTokens, ",") {
+				t.Errorf("Credential() = %v, want %v", tokens, tc.wantTokens)
+			}
+			if got := server.grants(); strings.Join(got, ",") != strings.Join(tc.wantGrants, ",") {
+				t.Errorf("grant types = %v, want %v", got, tc.wantGrants)
+			}
+			if paths := server.paths(); strings.Join(paths, ",") != strings.Join(tc.wantPaths, ",") {
+				t.Errorf("token request paths = %v, want %v", paths, tc.wantPaths)
+			}
+		})
+	}
+}
+
+func TestClientCredentialsConcurrentCalls(t *testing.T) {
+	server := newTokenServer(t)
+	c := NewClientCredentials(testClientID, testClientSecret, WithTokenHTTPClient(server.Client()))
+	var wg sync.WaitGroup
+	for range 10 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := c.Credential(context.Background(), server.schemeRequest("read")); err != nil {
+				t.Errorf("Credential() error = %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+	if got := len(server.grants()); got != 1 {
+		t.Errorf("token requests = %d, want 1", got)
+	}
+}
+
+func TestClientCredentialsErrors(t *testing.T) {
+	tests := []struct {
+		name         string
+		clientSecret string
+		handler      http.HandlerFunc
+		wantErr      string
+		wantTokenErr *TokenError
+	}{
+		{
+			name:         "invalid client",
+			clientSecret: "wrong",
+			wantTokenErr: &TokenError{StatusCode: http.StatusUnauthorized, Code: "invalid_client", Description: "unknown client"},
+			wantErr:      "token request failed with HTTP status 401: invalid_client: unknown client",
+		},
+		{
+			name: "error without JSON body",
+			handler: func(w http.ResponseWriter, _ *http.Request) {
+				http.Error(w, "unavailable", http.StatusServiceUnavailable)
+			},
+			wantTokenErr: &TokenError{StatusCode: http.StatusServiceUnavailable},
+			wantErr:      "token request failed with HTTP status 503",
+		},
+		{
+			name: "malformed response",
+			handler: func(w http.ResponseWriter, _ *http.Request) {
+				_, _ = w.Write([]byte("not json"))
+			},
+			wantErr: "failed to decode token response",
+		},
+		{
+			name: "no access token",
+			handler: func(w http.ResponseWriter, _ *http.Request) {
+				_, _ = w.Write([]byte(`{"token_type":"Bearer"}`))
+			},
+			wantErr: "token response has no access token",
+		},
+		{
+			name: "unsupported token type",
+			handler: func(w http.ResponseWriter, _ *http.Request) {
+				_, _ = w.Write([]byte(`{"access_token":"t","token_type":"mac"}`))
+			},
+			wantErr: `unsupported token type "mac"`,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			server := newTokenServer(t)
+			if tc.handler != nil {
+				server.Config.Handler = tc.handler
+			}
+			secret := testClientSecret
+			if tc.clientSecret != "" {
+				secret = tc.clientSecret
+			}
+			c := NewClientCredentials(testClientID, secret, WithTokenHTTPClient(server.Client()))
+			_, err := c.Credential(context.Background(), server.schemeRequest("read"))
+			if err == nil || !strings.Contains(err.Error(), tc.wantErr) {
+				t.Fatalf("Credential() error = %v, want %q", err, tc.wantErr)
+			}
+			var tokenErr *TokenError
+			if errors.As(err, &tokenErr) != (tc.wantTokenErr != nil) {
+				t.Fatalf("Credential() error = %v, want TokenError: %v", err, tc.wantTokenErr != nil)
+			}
+			if tc.wantTokenErr != nil && *tokenErr != *tc.wantTokenErr {
+				t.Errorf("TokenError = %+v, want %+v", tokenErr, tc.wantTokenErr)
+			}
+		})
+	}
+}
+
+func TestClientCredentialsOtherSchemes(t *testing.T) {
+	tests := []struct {
+		name   string
+		scheme *a2apb.SecurityScheme
+	}{
+		{name: "API key", scheme: apiKeyScheme("header", "X-API-Key")},
+		{name: "OAuth2 without flows", scheme: oauth2Scheme(nil)},
+		{
+			name: "authorization code flow",
+			scheme: oauth2Scheme(&a2apb.OAuthFlows{Flow: &a2apb.OAuthFlows_AuthorizationCode{
+				AuthorizationCode: &a2apb.AuthorizationCodeOAuthFlow{TokenUrl: "https://auth.example.com/token"},
+			}}),
+		},
+		{name: "client credentials flow without token URL", scheme: clientCredentialsScheme("", "")},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			req := SchemeRequest{Card: &a2apb.AgentCard{}, Name: "scheme", Scheme: tc.scheme}
+			if _, err := NewClientCredentials(testClientID, testClientSecret).Credential(context.Background(), req); !errors.Is(err, ErrNoCredential) {
+				t.Errorf("Credential() error = %v, want %v", err, ErrNoCredential)
+			}
+		})
+	}
+}
+
+func TestClientCredentialsTokenURLs(t *testing.T) {
+	server := newTokenServer(t)
+	server.expiresIn, server.refreshToken = 60, "refresh"
+	// localhost reaches the token server under another host name.
+	otherHost := strings.Replace(server.URL, "127.0.0.1", "localhost", 1)
+	tests := []struct {
+		name       string
+		cardURL    string
+		tokenURL   string
+		refreshURL string
+		opts       []ClientCredentialsOption
+		wantPaths  []string
+		wantErr    error
+	}{
+		{
+			name:      "token URL on the agent host",
+			cardURL:   "https://127.0.0.1/agent",
+			tokenURL:  server.URL + "/token",
+			wantPaths: []string{"/token", "/token"},
+		},
+		{
+			name:     "token URL on another host",
+			cardURL:  "https://agent.example.com/",
+			tokenURL: server.URL + "/token",
+			wantErr:  ErrUntrustedTokenURL,
+		},
+		{
+			name:       "refresh URL on another host",
+			cardURL:    "https://127.0.0.1/agent",
+			tokenURL:   server.URL + "/token",
+			refreshURL: otherHost + "/refresh",
+			wantErr:    ErrUntrustedTokenURL,
+		},
+		{
+			name:     "card without URL",
+			tokenURL: server.URL + "/token",
+			wantErr:  ErrUntrustedTokenURL,
+		},
+		{
+			name:     "token URL with other scheme",
+			cardURL:  "https://127.0.0.1/agent",
+			tokenURL: "ftp://127.0.0.1/token",
+			wantErr:  ErrUntrustedTokenURL,
+		},
+		{
+			name:       "allowed host",
+			cardURL:    "https://agent.example.com/",
+			tokenURL:   server.URL + "/token",
+			refreshURL: server.URL + "/refresh",
+			opts:       []ClientCredentialsOption{WithAllowedTokenHosts("auth.example.com", "127.0.0.1")},
+			wantPaths:  []string{"/token", "/refresh"},
+		},
+		{
+			name:     "allowed hosts replace the agent host",
+			cardURL:  "https://127.0.0.1/agent",
+			tokenURL: server.URL + "/token",
+			opts:     []ClientCredentialsOption{WithAllowedTokenHosts("auth.example.com")},
+			wantErr:  ErrUntrustedTokenURL,
+		},
+		{
+			name:       "configured token URL",
+			cardURL:    "https://agent.example.com/",
+			tokenURL:   "https://attacker.example.com/token",
+			refreshURL: "https://attacker.example.com/refresh",
+			opts:       []ClientCredentialsOption{WithTokenURL(server.URL + "/issuer")},
+			wantPaths:  []string{"/issuer", "/issuer"},
+		},
+		{
+			name:      "configured token URL without declared token URL",
+			cardURL:   "https://agent.example.com/",
+			opts:      []ClientCredentialsOption{WithTokenURL(server.URL + "/issuer")},
+			wantPaths: []string{"/issuer", "/issuer"},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			server.mu.Lock()
+			server.requests = nil
+			server.mu.Unlock()
+			opts := append([]ClientCredentialsOption{WithTokenHTTPClient(server.Client()), WithExpiryDelta(2 * time.Minute)}, tc.opts...)
+			c := NewClientCredentials(testClientID, testClientSecret, opts...)
+			req := SchemeRequest{
+				Card:   &a2apb.AgentCard{Url: tc.cardURL},
+				Name:   "oauth",
+				Scheme: clientCredentialsScheme(tc.tokenURL, tc.refreshURL),
+			}
+			// The second call refreshes the token, which expires within the expiry delta.
+			for range 2 {
+				if _, err := c.Credential(context.Background(), req); !errors.Is(err, tc.wantErr) {
+					t.Fatalf("Credential() error = %v, want %v", err, tc.wantErr)
+				}
+			}
+			if paths := server.paths(); strings.Join(paths, ",") != strings.Join(tc.wantPaths, ",") {
+				t.Errorf("token request paths = %v, want %v", paths, tc.wantPaths)
+			}
+		})
+	}
+}
+
+func TestClientCredentialsInsecureTokenURLs(t *testing.T) {
+	server := &tokenServer{}
+	server.Server = httptest.NewServer(http.HandlerFunc(server.handle))
+	defer server.Close()
+	tests := []struct {
+		name      string
+		opts      []ClientCredentialsOption
+		wantPaths []string
+		wantErr   error
+	}{
+		{name: "http token URL", wantErr: ErrUntrustedTokenURL},
+		{
+			name:      "insecure token URLs allowed",
+			opts:      []ClientCredentialsOption{WithInsecureTokenURLs()},
+			wantPaths: []string{"/token"},
+		},
+		{
+			name:      "configured http token URL",
+			opts:      []ClientCredentialsOption{WithTokenURL(server.URL + "/issuer")},
+			wantPaths: []string{"/issuer"},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			server.mu.Lock()
+			server.requests = nil
+			server.mu.Unlock()
+			req := SchemeRequest{
+				Card:   &a2apb.AgentCard{Url: "http://127.0.0.1/agent"},
+				Name:   "oauth",
+				Scheme: clientCredentialsScheme(server.URL+"/token", ""),
+			}
+			c := NewClientCredentials(testClientID, testClientSecret, tc.opts...)
+			if _, err := c.Credential(context.Background(), req); !errors.Is(err, tc.wantErr) {
+				t.Fatalf("Credential() error = %v, want %v", err, tc.wantErr)
+			}
+			if paths := server.paths(); strings.Join(paths, ",") != strings.Join(tc.wantPaths, ",") {
+				t.Errorf("token request paths = %v, want %v", paths, tc.wantPaths)
+			}
+		})
+	}
+}
@@ -0,0 +1,89 @@
+// Copyright 2025 The A2A Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package auth
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+
+	a2apb "github.com/a2aproject/a2a-go/grpc"
+)
+
+// ErrNoCredential is returned by a CredentialProvider which has no credential for a
+// security scheme. The Interceptor then tries the next security requirement.
+var ErrNoCredential = errors.New("no credential for security scheme")
+
+// SchemeRequest describes the security scheme a credential is requested for.
+type SchemeRequest struct {
+	// Card is the AgentCard of the called agent.
+	Card *a2apb.AgentCard
+	// Name is the name of the scheme in the card.
+	Name string
+	// Scheme is the declaration of the scheme.
+	Scheme *a2apb.SecurityScheme
+	// Scopes are the scopes required by the security requirement being satisfied.
+	Scopes []string
+}
+
+// CredentialProvider supplies the credentials of the caller. Credentials are API keys
+// for API key schemes, and the value of the Authorization header following the scheme
+// name for the other schemes, such as a token for Bearer, OAuth2 and OpenID Connect
+// schemes, or a BasicCredential for Basic schemes.
+type CredentialProvider interface {
+	// Credential returns the credential for the scheme, or ErrNoCredential.
+	Credential(ctx context.Context, req SchemeRequest) (string, error)
+}
+
+// CredentialProviderFunc is a function implementing CredentialProvider.
+type CredentialProviderFunc func(ctx context.Context, req SchemeRequest) (string, error)
+
+// Credential implements CredentialProvider.
+func (f CredentialProviderFunc) Credential(ctx context.Context, req SchemeRequest) (string, error) {
+	return f(ctx, req)
+}
+
+// StaticCredentials is a CredentialProvider returning fixed credentials by scheme name.
+type StaticCredentials map[string]string
+
+// Credential implements CredentialProvider.
+func (s StaticCredentials) Credential(_ context.Context, req SchemeRequest) (string, error) {
+	credential, ok := s[req.Name]
+	if !ok || credential == "" {
+		return "", ErrNoCredential
+	}
+	return credential, nil
+}
+
+// BasicCredential returns the credential of an HTTP Basic scheme for the user name
+// and password.
+func BasicCredential(username, password string) string {
+	return base64.StdEncoding.EncodeToString([]byte(username + ":" + password))
+}
+
+// Chain returns a CredentialProvider asking the providers in order, until one of them
+// has a credential for the scheme.
+func Chain(providers ...CredentialProvider) CredentialProvider {
+	return CredentialProviderFunc(func(ctx context.Context, req SchemeRequest) (string, error) {
+		for _, p := range providers {
+			credential, err := p.Credential(ctx, req)
+			if errors.Is(err, ErrNoCredential) {
+				continue
+			}
+			return credential, err
+		}
+		return "", ErrNoCredential
+	})
+}
@@ -0,0 +1,87 @@
+// Copyright 2025 The A2A Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package auth
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"testing"
+)
+
+func TestStaticCredentials(t *testing.T) {
+	provider := StaticCredentials{"key": "k1", "empty": ""}
+	tests := []struct {
+		name    string
+		scheme  string
+		want    string
+		wantErr error
+	}{
+		{name: "known scheme", scheme: "key", want: "k1"},
+		{name: "unknown scheme", scheme: "oauth", wantErr: ErrNoCredential},
+		{name: "empty credential", scheme: "empty", wantErr: ErrNoCredential},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := provider.Credential(context.Background(), SchemeRequest{Name: tc.scheme})
+			if !errors.Is(err, tc.wantErr) {
+				t.Fatalf("Credential() error = %v, want %v", err, tc.wantErr)
+			}
+			if got != tc.want {
+				t.Errorf("Credential() = %q, want %q", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestBasicCredential(t *testing.T) {
+	got, err := base64.StdEncoding.DecodeString(BasicCredential("alice", "se:cret"))
+	if err != nil {
+		t.Fatalf("DecodeString() error = %v", err)
+	}
+	if want := "alice:se:cret"; string(got) != want {
+		t.Errorf("BasicCredential() decodes to %q, want %q", got, want)
+	}
+}
+
+func TestChain(t *testing.T) {
+	errProvider := errors.New("provider failed")
+	failing := CredentialProviderFunc(func(context.Context, SchemeRequest) (string, error) {
+		return "", errProvider
+	})
+	tests := []struct {
+		name      string
+		providers []CredentialProvider
+		want      string
+		wantErr   error
+	}{
+		{name: "no providers", wantErr: ErrNoCredential},
+		{name: "first provider", providers: []CredentialProvider{StaticCredentials{"key": "k1"}, StaticCredentials{"key": "k2"}}, want: "k1"},
+		{name: "next provider", providers: []CredentialProvider{StaticCredentials{}, StaticCredentials{"key": "k2"}}, want: "k2"},
+		{name: "no credential", providers: []CredentialProvider{StaticCredentials{}, StaticCredentials{}}, wantErr: ErrNoCredential},
+		{name: "failing provider", providers: []CredentialProvider{failing, StaticCredentials{"key": "k2"}}, wantErr: errProvider},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := Chain(tc.providers...).Credential(context.Background(), SchemeRequest{Name: "key"})
+			if !errors.Is(err, tc.wantErr) {
+				t.Fatalf("Credential() error = %v, want %v", err, tc.wantErr)
+			}
+			if got != tc.want {
+				t.Errorf("Credential() = %q, want %q", got, tc.want)
+			}
+		})
+	}
+}
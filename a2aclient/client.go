@@ -0,0 +1,45 @@
+// Copyright 2025 The A2A Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package a2aclient
+
+import (
+	a2apb "github.com/a2aproject/a2a-go/grpc"
+)
+
+// Client is a connection to an A2A agent over one of its interfaces.
+type Client struct {
+	a2apb.A2AServiceClient
+
+	// Transport is the protocol used for communicating with the agent.
+	Transport string
+	// URL is the address of the agent interface.
+	URL string
+
+	close func() error
+}
+
+// NewClient wraps a transport-specific client. The close function is optional and is
+// invoked when the Client is closed.
+func NewClient(transport, url string, client a2apb.A2AServiceClient, close func() error) *Client {
+	return &Client{A2AServiceClient: client, Transport: transport, URL: url, close: close}
+}
+
+// Close releases the resources associated with the client.
+func (c *Client) Close() error {
+	if c.close == nil {
+		return nil
+	}
+	return c.close()
+}
@@ -0,0 +1,83 @@
+// Copyright 2025 The A2A Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package a2aclient
+
+import (
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/a2aproject/a2a-go/a2a"
+	a2apb "github.com/a2aproject/a2a-go/grpc"
+	"github.com/a2aproject/a2a-go/internal/taskupdate"
+)
+
+// StreamResult is the outcome of a streaming call collected by CollectStream.
+type StreamResult struct {
+	// Task is the last state of the task. It is nil if the agent responded with a message.
+	Task *a2apb.Task
+	// Message is the response of the agent if it did not create a task.
+	Message *a2apb.Message
+	// Events are the events received from the stream, in order.
+	Events []*a2apb.StreamResponse
+}
+
+// CollectStream reads the stream, applying every event to the initial task, until the
+// task reaches a terminal or interrupted state, the agent responds with a message or
+// the stream ends. The initial task may be nil for streams of new tasks.
+//
+// The stream is not read further once the result is determined, so the caller should
+// cancel the context of the call afterwards. If reading the stream fails, the result
+// collected so far is returned together with the error.
+func CollectStream(stream EventStream, initial *a2apb.Task) (*StreamResult, error) {
+	aggregator := NewTaskAggregator(initial)
+	result := &StreamResult{}
+	for {
+		event, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			result.Task = aggregator.Task()
+			return result, err
+		}
+		result.Events = append(result.Events, event)
+		if msg := event.GetMsg(); msg != nil {
+			result.Message = msg
+			return result, nil
+		}
+		if err := aggregator.Apply(event); err != nil {
+			result.Task = aggregator.Task()
+			return result, err
+		}
+		if isFinal(event, aggregator) {
+			break
+		}
+	}
+	result.Task = aggregator.Task()
+	if result.Task == nil {
+		return result, fmt.Errorf("%w: stream ended without a task or message", a2a.ErrInvalidAgentResponse)
+	}
+	return result, nil
+}
+
+// isFinal reports whether the event ends the stream of the task.
+func isFinal(event *a2apb.StreamResponse, aggregator *TaskAggregator) bool {
+	if event.GetStatusUpdate().GetFinal() {
+		return true
+	}
+	state := aggregator.state()
+	return taskupdate.IsTerminal(state) || taskupdate.IsInterrupted(state)
+}
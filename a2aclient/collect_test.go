@@ -0,0 +1,126 @@
+// Copyright 2025 The A2A Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package a2aclient
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/a2aproject/a2a-go/a2a"
+	a2apb "github.com/a2aproject/a2a-go/grpc"
+)
+
+func TestCollectStream(t *testing.T) {
+	working := statusUpdateEvent(a2apb.TaskState_TASK_STATE_WORKING, "", false)
+	completed := statusUpdateEvent(a2apb.TaskState_TASK_STATE_COMPLETED, "", false)
+	message := &a2apb.StreamResponse{Payload: &a2apb.StreamResponse_Msg{Msg: &a2apb.Message{MessageId: "m1"}}}
+	tests := []struct {
+		name       string
+		initial    *a2apb.Task
+		events     []*a2apb.StreamResponse
+		wantState  a2apb.TaskState
+		wantEvents int
+		wantMsg    bool
+		wantErr    error
+	}{
+		{
+			name:       "terminal status",
+			events:     []*a2apb.StreamResponse{taskSnapshotEvent(a2apb.TaskState_TASK_STATE_SUBMITTED), working, artifactChunkEvent("a1", "x", false, true), completed, working},
+			wantState:  a2apb.TaskState_TASK_STATE_COMPLETED,
+			wantEvents: 4,
+		},
+		{
+			name:       "final status",
+			events:     []*a2apb.StreamResponse{statusUpdateEvent(a2apb.TaskState_TASK_STATE_WORKING, "", true), completed},
+			wantState:  a2apb.TaskState_TASK_STATE_WORKING,
+			wantEvents: 1,
+		},
+		{
+			name:       "interrupted task",
+			events:     []*a2apb.StreamResponse{working, statusUpdateEvent(a2apb.TaskState_TASK_STATE_INPUT_REQUIRED, "", false), completed},
+			wantState:  a2apb.TaskState_TASK_STATE_INPUT_REQUIRED,
+			wantEvents: 2,
+		},
+		{
+			name:       "terminal task snapshot",
+			events:     []*a2apb.StreamResponse{taskSnapshotEvent(a2apb.TaskState_TASK_STATE_FAILED), working},
+			wantState:  a2apb.TaskState_TASK_STATE_FAILED,
+			wantEvents: 1,
+		},
+		{
+			name:       "message",
+			events:     []*a2apb.StreamResponse{message, working},
+			wantEvents: 1,
+			wantMsg:    true,
+		},
+		{
+			name:       "stream ends",
+			events:     []*a2apb.StreamResponse{working},
+			wantState:  a2apb.TaskState_TASK_STATE_WORKING,
+			wantEvents: 1,
+		},
+		{
+			name:       "initial task",
+			initial:    &a2apb.Task{Id: "t1", Status: &a2apb.TaskStatus{State: a2apb.TaskState_TASK_STATE_WORKING}},
+			wantState:  a2apb.TaskState_TASK_STATE_WORKING,
+			wantEvents: 0,
+		},
+		{
+			name:    "empty stream",
+			wantErr: a2a.ErrInvalidAgentResponse,
+		},
+		{
+			name:       "invalid event",
+			initial:    &a2apb.Task{Id: "t2"},
+			events:     []*a2apb.StreamResponse{working},
+			wantEvents: 1,
+			wantErr:    a2a.ErrInvalidAgentResponse,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			result, err := CollectStream(&sliceStream{events: tc.events}, tc.initial)
+			if !errors.Is(err, tc.wantErr) {
+				t.Fatalf("CollectStream() error = %v, want %v", err, tc.wantErr)
+			}
+			if len(result.Events) != tc.wantEvents {
+				t.Errorf("CollectStream() collected %d events, want %d", len(result.Events), tc.wantEvents)
+			}
+			if tc.wantErr != nil {
+				return
+			}
+			if got := result.Message != nil; got != tc.wantMsg {
+				t.Errorf("CollectStream() message = %v, want message: %v", result.Message, tc.wantMsg)
+			}
+			if !tc.wantMsg && result.Task.GetStatus().GetState() != tc.wantState {
+				t.Errorf("CollectStream() task state = %v, want %v", result.Task.GetStatus().GetState(), tc.wantState)
+			}
+		})
+	}
+}
+
+func TestCollectStreamError(t *testing.T) {
+	streamErr := errors.New("connection reset")
+	result, err := CollectStream(&sliceStream{
+		events: []*a2apb.StreamResponse{statusUpdateEvent(a2apb.TaskState_TASK_STATE_WORKING, "", false)},
+		err:    streamErr,
+	}, nil)
+	if !errors.Is(err, streamErr) {
+		t.Fatalf("CollectStream() error = %v, want %v", err, streamErr)
+	}
+	if result.Task.GetStatus().GetState() != a2apb.TaskState_TASK_STATE_WORKING || len(result.Events) != 1 {
+		t.Errorf("CollectStream() = %v, want the result collected before the error", result)
+	}
+}
@@ -0,0 +1,28 @@
+// Copyright 2025 The A2A Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package a2aclient provides the building blocks for calling A2A agents.
+//
+// Factory creates a Client for an agent described by an AgentCard. It selects one of the
+// transports advertised by the agent which the caller has registered a TransportFactory
+// for, so the calling code does not depend on the transport the agent is exposed with.
+//
+// TaskAggregator assembles the events of a streaming call into a live view of the task,
+// including artifacts streamed in chunks. CollectStream uses it to turn a stream into
+// its final Task or Message for callers which only need the end state.
+//
+// The auth package attaches the credentials required by the security schemes of an
+// agent to the calls of both transports, and the webhook package receives the push
+// notifications sent by agents.
+package a2aclient
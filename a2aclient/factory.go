@@ -0,0 +1,134 @@
+// Copyright 2025 The A2A Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package a2aclient
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"google.golang.org/grpc"
+
+	a2apb "github.com/a2aproject/a2a-go/grpc"
+	"github.com/a2aproject/a2a-go/jsonrpc"
+)
+
+// ErrNoCompatibleTransport is returned when none of the transports advertised by an agent
+// is registered in a Factory.
+var ErrNoCompatibleTransport = errors.New("no compatible transport")
+
+// Factory creates clients for agents using the transports registered by the caller.
+type Factory struct {
+	transports map[string]TransportFactory
+}
+
+// FactoryOption configures a Factory.
+type FactoryOption func(*Factory)
+
+// WithTransport registers a TransportFactory for the transport protocol.
+func WithTransport(protocol string, factory TransportFactory) FactoryOption {
+	return func(f *Factory) {
+		f.transports[normalizeTransport(protocol)] = factory
+	}
+}
+
+// WithJSONRPCTransport registers the JSON-RPC transport.
+func WithJSONRPCTransport(opts ...jsonrpc.ClientOption) FactoryOption {
+	return WithTransport(TransportJSONRPC, &jsonrpcTransport{opts: opts})
+}
+
+// WithGRPCTransport registers the gRPC transport. Dial options must include transport
+// credentials, e.g. grpc.WithTransportCredentials(insecure.NewCredentials()).
+func WithGRPCTransport(opts ...grpc.DialOption) FactoryOption {
+	return WithTransport(TransportGRPC, &grpcTransport{opts: opts})
+}
+
+// NewFactory creates a Factory with the provided transports.
+func NewFactory(opts ...FactoryOption) *Factory {
+	f := &Factory{transports: make(map[string]TransportFactory)}
+	for _, opt := range opts {
+		opt(f)
+	}
+	return f
+}
+
+// CreateFromCard returns a client for the agent described by the card. Interfaces
+// are tried in the order of preference: interfaces using the preferred transport first,
+// starting with the main one, followed by the other additional interfaces in the order
+// they are listed in the card.
+// Interfaces using transports not registered in the Factory are skipped. If creating
+// a client for an interface fails, the next one is tried.
+func (f *Factory) CreateFromCard(ctx context.Context, card *a2apb.AgentCard) (*Client, error) {
+	var errs []error
+	for _, iface := range f.candidates(card) {
+		transport := f.transports[normalizeTransport(iface.GetTransport())]
+		client, err := transport.Create(ctx, iface.GetUrl(), card)
+		if err == nil {
+			return client, nil
+		}
+		errs = append(errs, fmt.Errorf("%s interface at %s: %w", iface.GetTransport(), iface.GetUrl(), err))
+		if ctx.Err() != nil {
+			break
+		}
+	}
+	if len(errs) == 0 {
+		return nil, ErrNoCompatibleTransport
+	}
+	return nil, fmt.Errorf("failed to connect to the agent: %w", errors.Join(errs...))
+}
+
+// candidates returns the interfaces of the agent which can be used with the registered
+// transports, in the order they should be tried.
+func (f *Factory) candidates(card *a2apb.AgentCard) []*a2apb.AgentInterface {
+	preferred := card.GetPreferredTransport()
+	if preferred == "" {
+		// The specification defines JSON-RPC as the default transport of the main interface.
+		preferred = TransportJSONRPC
+	}
+	all := append([]*a2apb.AgentInterface{{Url: card.GetUrl(), Transport: preferred}}, card.GetAdditionalInterfaces()...)
+
+	type key struct{ url, transport string }
+	seen := make(map[key]bool)
+	var result []*a2apb.AgentInterface
+	for _, iface := range all {
+		k := key{url: iface.GetUrl(), transport: normalizeTransport(iface.GetTransport())}
+		if k.url == "" || seen[k] {
+			continue
+		}
+		seen[k] = true
+		if _, ok := f.transports[k.transport]; ok {
+			result = append(result, iface)
+		}
+	}
+	slices.SortStableFunc(result, func(a, b *a2apb.AgentInterface) int {
+		aPreferred := normalizeTransport(a.GetTransport()) == normalizeTransport(preferred)
+		bPreferred := normalizeTransport(b.GetTransport()) == normalizeTransport(preferred)
+		switch {
+		case aPreferred && !bPreferred:
+			return -1
+		case !aPreferred && bPreferred:
+			return 1
+		default:
+			return 0
+		}
+	})
+	return result
+}
+
+func normalizeTransport(protocol string) string {
+	return strings.ToUpper(strings.TrimSpace(protocol))
+}
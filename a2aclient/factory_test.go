@@ -0,0 +1,127 @@
+// Copyright 2025 The A2A Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package a2aclient
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"testing"
+
+	a2apb "github.com/a2aproject/a2a-go/grpc"
+	"github.com/a2aproject/a2a-go/jsonrpc"
+)
+
+func TestFactoryCandidatesOrder(t *testing.T) {
+	card := &a2apb.AgentCard{
+		Url:                "https://agent.example.com/grpc",
+		PreferredTransport: "grpc",
+		AdditionalInterfaces: []*a2apb.AgentInterface{
+			{Url: "https://agent.example.com/jsonrpc", Transport: TransportJSONRPC},
+			{Url: "https://agent.example.com/rest", Transport: TransportHTTPJSON},
+			{Url: "https://agent.example.com/grpc", Transport: TransportGRPC},
+			{Url: "https://backup.example.com/grpc", Transport: TransportGRPC},
+		},
+	}
+	var tried []string
+	failing := TransportFactoryFunc(func(_ context.Context, url string, _ *a2apb.AgentCard) (*Client, error) {
+		tried = append(tried, url)
+		return nil, errors.New("unreachable")
+	})
+	factory := NewFactory(WithTransport(TransportGRPC, failing), WithTransport(TransportJSONRPC, failing))
+
+	if _, err := factory.CreateFromCard(context.Background(), card); err == nil {
+		t.Fatal("CreateFromCard() error = nil, want error")
+	}
+	want := []string{"https://agent.example.com/grpc", "https://backup.example.com/grpc", "https://agent.example.com/jsonrpc"}
+	if !slices.Equal(tried, want) {
+		t.Errorf("tried interfaces %v, want %v", tried, want)
+	}
+}
+
+func TestFactoryCreateFromCard(t *testing.T) {
+	server := httptest.NewServer(jsonrpc.NewHandler(&a2apb.UnimplementedA2AServiceServer{}))
+	t.Cleanup(server.Close)
+	stopped := httptest.NewServer(http.NotFoundHandler())
+	stopped.Close()
+
+	tests := []struct {
+		name    string
+		card    *a2apb.AgentCard
+		opts    []FactoryOption
+		wantURL string
+		wantErr error
+	}{
+		{
+			name:    "main interface",
+			card:    &a2apb.AgentCard{Url: server.URL},
+			opts:    []FactoryOption{WithJSONRPCTransport()},
+			wantURL: server.URL,
+		},
+		{
+			name: "fall back on connection failure",
+			card: &a2apb.AgentCard{
+				Url:                  stopped.URL,
+				AdditionalInterfaces: []*a2apb.AgentInterface{{Url: server.URL, Transport: TransportJSONRPC}},
+			},
+			opts:    []FactoryOption{WithJSONRPCTransport()},
+			wantURL: server.URL,
+		},
+		{
+			name: "skip unregistered transports",
+			card: &a2apb.AgentCard{
+				Url:                  "localhost:1",
+				PreferredTransport:   TransportGRPC,
+				AdditionalInterfaces: []*a2apb.AgentInterface{{Url: server.URL, Transport: "jsonrpc"}},
+			},
+			opts:    []FactoryOption{WithJSONRPCTransport()},
+			wantURL: server.URL,
+		},
+		{
+			name:    "no compatible transport",
+			card:    &a2apb.AgentCard{Url: server.URL, PreferredTransport: TransportHTTPJSON},
+			opts:    []FactoryOption{WithJSONRPCTransport()},
+			wantErr: ErrNoCompatibleTransport,
+		},
+		{
+			name: "all interfaces unreachable",
+			card: &a2apb.AgentCard{Url: stopped.URL},
+			opts: []FactoryOption{WithJSONRPCTransport()},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client, err := NewFactory(tc.opts...).CreateFromCard(context.Background(), tc.card)
+			if tc.wantURL == "" {
+				if err == nil {
+					t.Fatalf("CreateFromCard() = %v, want error", client.URL)
+				}
+				if tc.wantErr != nil && !errors.Is(err, tc.wantErr) {
+					t.Errorf("CreateFromCard() error = %v, want %v", err, tc.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("CreateFromCard() error = %v", err)
+			}
+			defer func() { _ = client.Close() }()
+			if client.URL != tc.wantURL || client.Transport != TransportJSONRPC {
+				t.Errorf("CreateFromCard() = %s %s, want %s %s", client.Transport, client.URL, TransportJSONRPC, tc.wantURL)
+			}
+		})
+	}
+}
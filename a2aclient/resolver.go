@@ -0,0 +1,221 @@
+// Copyright 2025 The A2A Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package a2aclient
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"maps"
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/a2aproject/a2a-go/a2a"
+	a2apb "github.com/a2aproject/a2a-go/grpc"
+)
+
+// Resolver discovers agents by fetching the AgentCard they publish at a well-known path.
+type Resolver struct {
+	client       *http.Client
+	path         string
+	header       http.Header
+	authenticate ExtendedCardAuthenticator
+}
+
+// ExtendedCardAuthenticator adds credentials to the request fetching the extended card
+// of an agent. The public card of the agent is provided for selecting the credentials
+// matching its security requirements.
+type ExtendedCardAuthenticator func(ctx context.Context, card *a2apb.AgentCard, req *http.Request) error
+
+// ResolverOption configures a Resolver.
+type ResolverOption func(*Resolver)
+
+// WithResolverHTTPClient sets the HTTP client used for fetching cards.
+// http.DefaultClient is used by default.
+func WithResolverHTTPClient(client *http.Client) ResolverOption {
+	return func(r *Resolver) {
+		r.client = client
+	}
+}
+
+// WithResolverPath sets the path of the card relative to the base URL of an agent.
+// a2a.WellKnownAgentCardPath is used by default.
+func WithResolverPath(path string) ResolverOption {
+	return func(r *Resolver) {
+		r.path = path
+	}
+}
+
+// WithResolverHeader adds a header to the requests made by the Resolver.
+func WithResolverHeader(key, value string) ResolverOption {
+	return func(r *Resolver) {
+		r.header.Add(key, value)
+	}
+}
+
+// WithExtendedCardAuthenticator makes the Resolver fetch the authenticated extended card
+// of agents which set SupportsAuthenticatedExtendedCard in their public card.
+func WithExtendedCardAuthenticator(authenticate ExtendedCardAuthenticator) ResolverOption {
+	return func(r *Resolver) {
+		r.authenticate = authenticate
+	}
+}
+
+// NewResolver creates a Resolver.
+func NewResolver(opts ...ResolverOption) *Resolver {
+	r := &Resolver{client: http.DefaultClient, path: a2a.WellKnownAgentCardPath, header: make(http.Header)}
+	for _, opt := range opts {
+		opt(r)
+	}
+	return r
+}
+
+// Resolve fetches the public AgentCard of the agent served at the base URL and validates
+// that the fields required by the specification are present. If the agent supports
+// an authenticated extended card and the Resolver has an ExtendedCardAuthenticator,
+// the extended card is fetched after authenticating and returned instead.
+func (r *Resolver) Resolve(ctx context.Context, baseURL string) (*a2apb.AgentCard, error) {
+	card, err := r.fetch(ctx, joinURL(baseURL, r.path), nil)
+	if err != nil {
+		return nil, err
+	}
+	if err := ValidateAgentCard(card); err != nil {
+		return nil, err
+	}
+	if !card.GetSupportsAuthenticatedExtendedCard() || r.authenticate == nil {
+		return card, nil
+	}
+
+	authenticate := func(req *http.Request) error {
+		return r.authenticate(ctx, card, req)
+	}
+	extended, err := r.fetch(ctx, joinURL(baseURL, a2a.ExtendedAgentCardPath), authenticate)
+	if err != nil {
+		return nil, fmt.Errorf("extended card: %w", err)
+	}
+	if err := ValidateAgentCard(extended); err != nil {
+		return nil, fmt.Errorf("extended card: %w", err)
+	}
+	return extended, nil
+}
+
+func (r *Resolver) fetch(ctx context.Context, cardURL string, authenticate func(*http.Request) error) (*a2apb.AgentCard, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, cardURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	for k, v := range r.header {
+		req.Header[k] = v
+	}
+	req.Header.Set("Accept", "application/json")
+	if authenticate != nil {
+		if err := authenticate(req); err != nil {
+			return nil, fmt.Errorf("failed to authenticate: %w", err)
+		}
+	}
+	resp, err := r.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch agent card: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected HTTP status %q fetching agent card from %s", resp.Status, cardURL)
+	}
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read agent card: %w", err)
+	}
+	card, err := a2a.UnmarshalAgentCard(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode agent card: %w", err)
+	}
+	if err := checkRequiredLists(data); err != nil {
+		return nil, err
+	}
+	return card, nil
+}
+
+// checkRequiredLists checks that the lists required by the specification are present
+// in the JSON encoding of a card. The lists are allowed to be empty.
+func checkRequiredLists(data []byte) error {
+	var obj map[string]json.RawMessage
+	if err := json.Unmarshal(data, &obj); err != nil {
+		return fmt.Errorf("failed to decode agent card: %w", err)
+	}
+	var errs []error
+	for _, name := range []string{"defaultInputModes", "defaultOutputModes", "skills"} {
+		if value, ok := obj[name]; !ok || string(value) == "null" {
+			errs = append(errs, fmt.Errorf("%s is required", name))
+		}
+	}
+	if len(errs) > 0 {
+		return fmt.Errorf("invalid agent card: %w", errors.Join(errs...))
+	}
+	return nil
+}
+
+func joinURL(baseURL, path string) string {
+	return strings.TrimSuffix(baseURL, "/") + "/" + strings.TrimPrefix(path, "/")
+}
+
+// ValidateAgentCard checks that the fields required by the specification are set and
+// that the security requirements only reference the declared security schemes.
+// The proto representation does not distinguish a missing list from an empty one, so
+// the presence of defaultInputModes, defaultOutputModes and skills is only checked by
+// the Resolver, when the card is decoded from JSON.
+func ValidateAgentCard(card *a2apb.AgentCard) error {
+	required := []struct {
+		name  string
+		value string
+	}{
+		{name: "name", value: card.GetName()},
+		{name: "description", value: card.GetDescription()},
+		{name: "url", value: card.GetUrl()},
+		{name: "version", value: card.GetVersion()},
+	}
+	var errs []error
+	for _, field := range required {
+		if field.value == "" {
+			errs = append(errs, fmt.Errorf("%s is required", field.name))
+		}
+	}
+	if card.GetCapabilities() == nil {
+		errs = append(errs, errors.New("capabilities are required"))
+	}
+	for i, skill := range card.GetSkills() {
+		if skill.GetId() == "" || skill.GetName() == "" {
+			errs = append(errs, fmt.Errorf("skill %d must have id and name", i))
+		}
+	}
+	for i, iface := range card.GetAdditionalInterfaces() {
+		if iface.GetUrl() == "" || iface.GetTransport() == "" {
+			errs = append(errs, fmt.Errorf("additional interface %d must have url and transport", i))
+		}
+	}
+	for i, requirement := range card.GetSecurity() {
+		for _, name := range slices.Sorted(maps.Keys(requirement.GetSchemes())) {
+			if _, ok := card.GetSecuritySchemes()[name]; !ok {
+				errs = append(errs, fmt.Errorf("security requirement %d references undeclared scheme %q", i, name))
+			}
+		}
+	}
+	if len(errs) > 0 {
+		return fmt.Errorf("invalid agent card: %w", errors.Join(errs...))
+	}
+	return nil
+}
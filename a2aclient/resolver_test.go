@@ -0,0 +1,213 @@
+// Copyright 2025 The A2A Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package a2aclient
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/a2aproject/a2a-go/a2a"
+	a2apb "github.com/a2aproject/a2a-go/grpc"
+)
+
+func newTestCard() *a2apb.AgentCard {
+	return &a2apb.AgentCard{
+		Name:               "test agent",
+		Description:        "an agent for tests",
+		Url:                "https://agent.example.com",
+		Version:            "1.0.0",
+		Capabilities:       &a2apb.AgentCapabilities{Streaming: true},
+		DefaultInputModes:  []string{"text/plain"},
+		DefaultOutputModes: []string{"text/plain"},
+		Skills:             []*a2apb.AgentSkill{{Id: "echo", Name: "Echo", Description: "Echoes the input"}},
+		SecuritySchemes: map[string]*a2apb.SecurityScheme{
+			"bearer": {Scheme: &a2apb.SecurityScheme_HttpAuthSecurityScheme{HttpAuthSecurityScheme: &a2apb.HTTPAuthSecurityScheme{Scheme: "Bearer"}}},
+		},
+		Security: []*a2apb.Security{{Schemes: map[string]*a2apb.StringList{"bearer": {}}}},
+	}
+}
+
+func TestValidateAgentCard(t *testing.T) {
+	tests := []struct {
+		name    string
+		modify  func(card *a2apb.AgentCard)
+		wantErr string
+	}{
+		{name: "valid", modify: func(*a2apb.AgentCard) {}},
+		{name: "empty lists", modify: func(card *a2apb.AgentCard) {
+			card.DefaultInputModes, card.DefaultOutputModes, card.Skills = nil, nil, nil
+		}},
+		{name: "missing name", modify: func(card *a2apb.AgentCard) { card.Name = "" }, wantErr: "name is required"},
+		{name: "missing description", modify: func(card *a2apb.AgentCard) { card.Description = "" }, wantErr: "description is required"},
+		{name: "missing url", modify: func(card *a2apb.AgentCard) { card.Url = "" }, wantErr: "url is required"},
+		{name: "missing version", modify: func(card *a2apb.AgentCard) { card.Version = "" }, wantErr: "version is required"},
+		{name: "missing capabilities", modify: func(card *a2apb.AgentCard) { card.Capabilities = nil }, wantErr: "capabilities are required"},
+		{
+			name:    "skill without id",
+			modify:  func(card *a2apb.AgentCard) { card.Skills[0].Id = "" },
+			wantErr: "skill 0 must have id and name",
+		},
+		{
+			name: "interface without transport",
+			modify: func(card *a2apb.AgentCard) {
+				card.AdditionalInterfaces = []*a2apb.AgentInterface{{Url: "https://agent.example.com/grpc"}}
+			},
+			wantErr: "additional interface 0",
+		},
+		{
+			name: "undeclared security scheme",
+			modify: func(card *a2apb.AgentCard) {
+				card.Security = append(card.Security, &a2apb.Security{Schemes: map[string]*a2apb.StringList{"oauth": {List: []string{"read"}}}})
+			},
+			wantErr: `security requirement 1 references undeclared scheme "oauth"`,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			card := newTestCard()
+			tc.modify(card)
+			err := ValidateAgentCard(card)
+			if tc.wantErr == "" {
+				if err != nil {
+					t.Errorf("ValidateAgentCard() error = %v, want nil", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tc.wantErr) {
+				t.Errorf("ValidateAgentCard() error = %v, want containing %q", err, tc.wantErr)
+			}
+		})
+	}
+}
+
+func marshalTestCard(t *testing.T, card *a2apb.AgentCard, drop ...string) []byte {
+	t.Helper()
+	data, err := a2a.MarshalAgentCard(card)
+	if err != nil {
+		t.Fatalf("MarshalAgentCard() error = %v", err)
+	}
+	var obj map[string]any
+	if err := json.Unmarshal(data, &obj); err != nil {
+		t.Fatalf("json.Unmarshal() error = %v", err)
+	}
+	for _, field := range drop {
+		delete(obj, field)
+	}
+	data, err = json.Marshal(obj)
+	if err != nil {
+		t.Fatalf("json.Marshal() error = %v", err)
+	}
+	return data
+}
+
+func TestResolverResolve(t *testing.T) {
+	tests := []struct {
+		name    string
+		drop    []string
+		status  int
+		wantErr string
+	}{
+		{name: "valid"},
+		{name: "missing skills", drop: []string{"skills"}, wantErr: "skills is required"},
+		{name: "missing modes", drop: []string{"defaultInputModes", "defaultOutputModes"}, wantErr: "defaultInputModes is required"},
+		{name: "missing name", drop: []string{"name"}, wantErr: "name is required"},
+		{name: "not found", status: http.StatusNotFound, wantErr: "unexpected HTTP status"},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			data := marshalTestCard(t, newTestCard(), tc.drop...)
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path != a2a.WellKnownAgentCardPath || tc.status != 0 {
+					http.NotFound(w, r)
+					return
+				}
+				_, _ = w.Write(data)
+			}))
+			defer server.Close()
+
+			card, err := NewResolver().Resolve(context.Background(), server.URL+"/")
+			if tc.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tc.wantErr) {
+					t.Errorf("Resolve() error = %v, want containing %q", err, tc.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Resolve() error = %v", err)
+			}
+			if card.GetName() != "test agent" || len(card.GetSkills()) != 1 {
+				t.Errorf("Resolve() = %v, want the served card", card)
+			}
+		})
+	}
+}
+
+func TestResolverExtendedCard(t *testing.T) {
+	public := newTestCard()
+	public.SupportsAuthenticatedExtendedCard = true
+	extended := newTestCard()
+	extended.Skills = append(extended.Skills, &a2apb.AgentSkill{Id: "admin", Name: "Admin"})
+	publicData, extendedData := marshalTestCard(t, public), marshalTestCard(t, extended)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case a2a.WellKnownAgentCardPath:
+			_, _ = w.Write(publicData)
+		case a2a.ExtendedAgentCardPath:
+			if r.Header.Get("Authorization") != "Bearer secret" {
+				http.Error(w, "unauthorized", http.StatusUnauthorized)
+				return
+			}
+			_, _ = w.Write(extendedData)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	authenticate := func(token string) ExtendedCardAuthenticator {
+		return func(_ context.Context, card *a2apb.AgentCard, req *http.Request) error {
+			if card.GetName() != public.GetName() {
+				t.Errorf("authenticator got card %q, want the public card", card.GetName())
+			}
+			req.Header.Set("Authorization", "Bearer "+token)
+			return nil
+		}
+	}
+
+	card, err := NewResolver(WithExtendedCardAuthenticator(authenticate("secret"))).Resolve(context.Background(), server.URL)
+	if err != nil {
+		t.Fatalf("Resolve() error = %v", err)
+	}
+	if len(card.GetSkills()) != 2 {
+		t.Errorf("Resolve() skills = %v, want the extended card skills", card.GetSkills())
+	}
+
+	if _, err := NewResolver(WithExtendedCardAuthenticator(authenticate("wrong"))).Resolve(context.Background(), server.URL); err == nil {
+		t.Error("Resolve() with wrong credentials error = nil, want error")
+	}
+
+	card, err = NewResolver().Resolve(context.Background(), server.URL)
+	if err != nil {
+		t.Fatalf("Resolve() without an authenticator error = %v", err)
+	}
+	if len(card.GetSkills()) != 1 {
+		t.Errorf("Resolve() without an authenticator skills = %v, want the public card skills", card.GetSkills())
+	}
+}
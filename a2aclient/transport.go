@@ -0,0 +1,108 @@
+// Copyright 2025 The A2A Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package a2aclient
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/connectivity"
+
+	a2apb "github.com/a2aproject/a2a-go/grpc"
+	"github.com/a2aproject/a2a-go/jsonrpc"
+)
+
+// Transport protocols defined by the A2A specification.
+const (
+	TransportJSONRPC  = "JSONRPC"
+	TransportGRPC     = "GRPC"
+	TransportHTTPJSON = "HTTP+JSON"
+)
+
+// TransportFactory creates clients for a transport protocol.
+type TransportFactory interface {
+	// Create returns a client for the agent interface at the provided URL.
+	// An error means the interface can not be used and the next one is tried.
+	Create(ctx context.Context, url string, card *a2apb.AgentCard) (*Client, error)
+}
+
+// TransportFactoryFunc is an adapter allowing to use a function as a TransportFactory.
+type TransportFactoryFunc func(ctx context.Context, url string, card *a2apb.AgentCard) (*Client, error)
+
+// Create implements TransportFactory.
+func (fn TransportFactoryFunc) Create(ctx context.Context, url string, card *a2apb.AgentCard) (*Client, error) {
+	return fn(ctx, url, card)
+}
+
+type jsonrpcTransport struct {
+	opts []jsonrpc.ClientOption
+}
+
+// Create checks that the endpoint is reachable, so that unreachable interfaces are
+// detected before the client is returned.
+func (t *jsonrpcTransport) Create(ctx context.Context, url string, card *a2apb.AgentCard) (*Client, error) {
+	client := jsonrpc.NewClient(url, t.opts...)
+	if err := client.Ping(ctx); err != nil {
+		return nil, err
+	}
+	return NewClient(TransportJSONRPC, url, client, nil), nil
+}
+
+type grpcTransport struct {
+	opts []grpc.DialOption
+}
+
+// Create establishes a connection and waits for it to become ready, so that
+// unreachable interfaces are detected before the client is returned.
+func (t *grpcTransport) Create(ctx context.Context, url string, card *a2apb.AgentCard) (*Client, error) {
+	conn, err := grpc.NewClient(grpcTarget(url), t.opts...)
+	if err != nil {
+		return nil, err
+	}
+	if err := waitForReady(ctx, conn); err != nil {
+		_ = conn.Close()
+		return nil, err
+	}
+	return NewClient(TransportGRPC, url, a2apb.NewA2AServiceClient(conn), conn.Close), nil
+}
+
+func waitForReady(ctx context.Context, conn *grpc.ClientConn) error {
+	conn.Connect()
+	for {
+		state := conn.GetState()
+		switch state {
+		case connectivity.Ready:
+			return nil
+		case connectivity.TransientFailure, connectivity.Shutdown:
+			return fmt.Errorf("connection failed: %s", state)
+		}
+		if !conn.WaitForStateChange(ctx, state) {
+			return errors.Join(errors.New("connection is not ready"), ctx.Err())
+		}
+	}
+}
+
+// grpcTarget converts an interface URL to a gRPC target. Agent cards can advertise
+// gRPC interfaces both as host:port and as http(s) URLs.
+func grpcTarget(rawURL string) string {
+	u, err := url.Parse(rawURL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") {
+		return rawURL
+	}
+	return u.Host
+}
@@ -0,0 +1,39 @@
+// Copyright 2025 The A2A Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package webhook helps clients receive the push notifications sent by A2A agents.
+//
+// Receiver is the webhook handler. It issues the push notification configs sent to
+// agents, accepts only notifications carrying the token of one of them, drops
+// redeliveries and passes the notified tasks to a callback:
+//
+//	receiver := webhook.NewReceiver(func(ctx context.Context, task *a2apb.Task) error {
+//		...
+//	})
+//	http.Handle("/notifications", receiver)
+//	resp, err := client.SendMessage(ctx, &a2apb.SendMessageRequest{
+//		Request: msg,
+//		Configuration: &a2apb.SendMessageConfiguration{
+//			PushNotification: receiver.NewConfig("https://client.example.com/notifications"),
+//		},
+//	})
+//
+// Verifier checks the signature agents attach to their notifications against the
+// keys published in the JSON Web Key Set of the agent, which it fetches and caches.
+// Its Middleware rejects unsigned or tampered notifications before they reach the
+// Receiver:
+//
+//	verifier := webhook.NewVerifier("https://agent.example.com" + a2a.WellKnownJWKSPath)
+//	http.Handle("/notifications", verifier.Middleware(receiver))
+package webhook
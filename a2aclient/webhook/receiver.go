@@ -0,0 +1,259 @@
+// Copyright 2025 The A2A Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package webhook
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/a2aproject/a2a-go/a2a"
+	a2apb "github.com/a2aproject/a2a-go/grpc"
+)
+
+// DefaultDedupeWindow is how long a Receiver remembers delivered notifications by default.
+const DefaultDedupeWindow = 10 * time.Minute
+
+// maxRemembered bounds the number of notifications remembered for deduplication.
+const maxRemembered = 100_000
+
+// NotificationFunc is called with the task of every notification accepted by a
+// Receiver. An error makes the Receiver respond with HTTP status 500, so that the
+// agent delivers the notification again.
+type NotificationFunc func(ctx context.Context, task *a2apb.Task) error
+
+// ReceiverOption configures a Receiver.
+type ReceiverOption func(*Receiver)
+
+// WithDedupeWindow sets how long a delivered notification is remembered, so that
+// redeliveries of the notification are acknowledged without calling the callback again.
+// DefaultDedupeWindow is used by default.
+func WithDedupeWindow(window time.Duration) ReceiverOption {
+	return func(r *Receiver) {
+		r.window = window
+	}
+}
+
+// WithReceiverMaxBodySize sets the maximum size of the notifications accepted by the
+// Receiver. DefaultMaxBodySize is used by default.
+func WithReceiverMaxBodySize(size int64) ReceiverOption {
+	return func(r *Receiver) {
+		r.maxBodySize = size
+	}
+}
+
+// Receiver is an http.Handler receiving the push notifications of the configs
+// registered with it. It checks the notification token, decodes the task, drops
+// redeliveries of notifications which were already handled and calls the callback
+// with the task.
+//
+// A token is bound to the task of the first notification carrying it, and
+// notifications about other tasks carrying the same token are rejected.
+type Receiver struct {
+	callback    NotificationFunc
+	window      time.Duration
+	maxBodySize int64
+
+	mu sync.Mutex
+	// tokens maps the registered tokens to the task they are bound to, which is
+	// empty until the first notification.
+	tokens map[string]string
+	// delivered maps the digests of the handled notifications to when they expire,
+	// and order lists them by delivery time.
+	delivered map[[sha256.Size]byte]time.Time
+	order     [][sha256.Size]byte
+	// pending contains the digests of the notifications being handled.
+	pending map[[sha256.Size]byte]bool
+}
+
+// NewReceiver returns a Receiver calling the callback with the notified tasks.
+func NewReceiver(callback NotificationFunc, opts ...ReceiverOption) *Receiver {
+	r := &Receiver{
+		callback:    callback,
+		window:      DefaultDedupeWindow,
+		maxBodySize: DefaultMaxBodySize,
+		tokens:      make(map[string]string),
+		delivered:   make(map[[sha256.Size]byte]time.Time),
+		pending:     make(map[[sha256.Size]byte]bool),
+	}
+	for _, opt := range opts {
+		opt(r)
+	}
+	return r
+}
+
+// NewConfig returns a push notification config delivering to the URL of the Receiver,
+// with a random token registered with the Receiver. The config is meant to be sent
+// with a message in SendMessageConfiguration.PushNotification or to be set on a task.
+func (r *Receiver) NewConfig(url string) *a2apb.PushNotificationConfig {
+	b := make([]byte, 32)
+	_, _ = rand.Read(b)
+	config := &a2apb.PushNotificationConfig{Url: url, Token: base64.RawURLEncoding.EncodeToString(b)}
+	r.Register(config)
+	return config
+}
+
+// Register accepts the notifications carrying the token of the config. Configs
+// without a token cannot be registered, because their notifications cannot be told
+// apart from forged ones.
+func (r *Receiver) Register(config *a2apb.PushNotificationConfig) {
+	if config.GetToken() == "" {
+		return
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, ok := r.tokens[config.GetToken()]; !ok {
+		r.tokens[config.GetToken()] = ""
+	}
+}
+
+// Unregister stops accepting the notifications carrying the token of the config,
+// typically once its task reached a terminal state.
+func (r *Receiver) Unregister(config *a2apb.PushNotificationConfig) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	delete(r.tokens, config.GetToken())
+}
+
+// ServeHTTP implements http.Handler.
+func (r *Receiver) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	if req.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	body, err := io.ReadAll(http.MaxBytesReader(w, req.Body, r.maxBodySize))
+	if err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			http.Error(w, "notification too large", http.StatusRequestEntityTooLarge)
+			return
+		}
+		http.Error(w, "failed to read notification", http.StatusBadRequest)
+		return
+	}
+	var t a2a.Task
+	if err := json.Unmarshal(body, &t); err != nil {
+		http.Error(w, "invalid notification: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+	task, err := a2a.TaskToProto(&t)
+	if err != nil || task.GetId() == "" {
+		http.Error(w, "invalid notification task", http.StatusBadRequest)
+		return
+	}
+	token := req.Header.Get(a2a.NotificationTokenHeader)
+	if status, msg := r.authorize(token, task.GetId()); status != http.StatusOK {
+		http.Error(w, msg, status)
+		return
+	}
+
+	digest := sha256.Sum256(append([]byte(token+"\x00"), body...))
+	switch r.begin(digest) {
+	case duplicate:
+		w.WriteHeader(http.StatusNoContent)
+		return
+	case inFlight:
+		// The agent retries, and the retry is acknowledged if this delivery succeeds.
+		w.Header().Set("Retry-After", "1")
+		http.Error(w, "notification is being processed", http.StatusServiceUnavailable)
+		return
+	}
+	err = r.callback(req.Context(), task)
+	r.end(digest, err == nil)
+	if err != nil {
+		http.Error(w, "failed to handle notification", http.StatusInternalServerError)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// authorize checks that the token is registered and binds it to the task. It returns
+// the HTTP status and message of the rejection, or http.StatusOK.
+func (r *Receiver) authorize(token, taskID string) (int, string) {
+	if token == "" {
+		return http.StatusUnauthorized, "missing notification token"
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	bound, ok := r.tokens[token]
+	switch {
+	case !ok:
+		return http.StatusUnauthorized, "unknown notification token"
+	case bound == "":
+		r.tokens[token] = taskID
+	case bound != taskID:
+		return http.StatusForbidden, "notification token is registered for another task"
+	}
+	return http.StatusOK, ""
+}
+
+type delivery int
+
+const (
+	fresh delivery = iota
+	duplicate
+	inFlight
+)
+
+// begin marks the notification with the digest as being handled, unless it was
+// already handled or is being handled.
+func (r *Receiver) begin(digest [sha256.Size]byte) delivery {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.expire(time.Now())
+	if _, ok := r.delivered[digest]; ok {
+		return duplicate
+	}
+	if r.pending[digest] {
+		return inFlight
+	}
+	r.pending[digest] = true
+	return fresh
+}
+
+// end finishes the handling of the notification with the digest. Delivered
+// notifications are remembered for the dedupe window.
+func (r *Receiver) end(digest [sha256.Size]byte, delivered bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	delete(r.pending, digest)
+	if !delivered || r.window <= 0 {
+		return
+	}
+	if len(r.order) >= maxRemembered {
+		delete(r.delivered, r.order[0])
+		r.order = r.order[1:]
+	}
+	r.delivered[digest] = time.Now().Add(r.window)
+	r.order = append(r.order, digest)
+}
+
+// expire forgets the notifications delivered before the dedupe window.
+func (r *Receiver) expire(now time.Time) {
+	n := 0
+	for n < len(r.order) && !now.Before(r.delivered[r.order[n]]) {
+		delete(r.delivered, r.order[n])
+		n++
+	}
+	r.order = r.order[n:]
+}
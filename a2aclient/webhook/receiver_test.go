@@ -0,0 +1,255 @@
+// Copyright 2025 The A2A Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/a2aproject/a2a-go/a2a"
+	"github.com/a2aproject/a2a-go/a2asrv/push"
+	a2apb "github.com/a2aproject/a2a-go/grpc"
+)
+
+// taskRecorder is a NotificationFunc keeping the notified tasks.
+type taskRecorder struct {
+	mu    sync.Mutex
+	tasks []*a2apb.Task
+	err   error
+}
+
+func (r *taskRecorder) notify(_ context.Context, task *a2apb.Task) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.err != nil {
+		return r.err
+	}
+	r.tasks = append(r.tasks, task)
+	return nil
+}
+
+func (r *taskRecorder) count() int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return len(r.tasks)
+}
+
+func notificationBody(t *testing.T, taskID string, state a2apb.TaskState) string {
+	t.Helper()
+	task, err := a2a.TaskFromProto(&a2apb.Task{Id: taskID, ContextId: "c1", Status: &a2apb.TaskStatus{State: state}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	body, err := json.Marshal(task)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(body)
+}
+
+func deliver(r http.Handler, method, token, body string) int {
+	req := httptest.NewRequest(method, "/webhook", strings.NewReader(body))
+	if token != "" {
+		req.Header.Set(a2a.NotificationTokenHeader, token)
+	}
+	rec := httptest.NewRecorder()
+	r.ServeHTTP(rec, req)
+	return rec.Code
+}
+
+func TestReceiver(t *testing.T) {
+	working := notificationBody(t, "t1", a2apb.TaskState_TASK_STATE_WORKING)
+	completed := notificationBody(t, "t1", a2apb.TaskState_TASK_STATE_COMPLETED)
+	otherTask := notificationBody(t, "t2", a2apb.TaskState_TASK_STATE_WORKING)
+	type delivery struct {
+		method, token, body string
+		wantStatus          int
+	}
+	tests := []struct {
+		name       string
+		deliveries []delivery
+		wantTasks  int
+	}{
+		{
+			name:       "delivered",
+			deliveries: []delivery{{http.MethodPost, "tok", working, http.StatusNoContent}, {http.MethodPost, "tok", completed, http.StatusNoContent}},
+			wantTasks:  2,
+		},
+		{
+			name:       "redelivery",
+			deliveries: []delivery{{http.MethodPost, "tok", working, http.StatusNoContent}, {http.MethodPost, "tok", working, http.StatusNoContent}},
+			wantTasks:  1,
+		},
+		{
+			name:       "missing token",
+			deliveries: []delivery{{http.MethodPost, "", working, http.StatusUnauthorized}},
+		},
+		{
+			name:       "unknown token",
+			deliveries: []delivery{{http.MethodPost, "forged", working, http.StatusUnauthorized}},
+		},
+		{
+			name:       "token of another task",
+			deliveries: []delivery{{http.MethodPost, "tok", working, http.StatusNoContent}, {http.MethodPost, "tok", otherTask, http.StatusForbidden}},
+			wantTasks:  1,
+		},
+		{
+			name:       "method not allowed",
+			deliveries: []delivery{{http.MethodGet, "tok", "", http.StatusMethodNotAllowed}},
+		},
+		{
+			name:       "invalid JSON",
+			deliveries: []delivery{{http.MethodPost, "tok", "{", http.StatusBadRequest}},
+		},
+		{
+			name:       "task without ID",
+			deliveries: []delivery{{http.MethodPost, "tok", `{"contextId":"c1","status":{"state":"working"}}`, http.StatusBadRequest}},
+		},
+		{
+			name:       "too large",
+			deliveries: []delivery{{http.MethodPost, "tok", working + strings.Repeat(" ", 1024), http.StatusRequestEntityTooLarge}},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			recorder := &taskRecorder{}
+			r := NewReceiver(recorder.notify, WithReceiverMaxBodySize(512))
+			r.Register(&a2apb.PushNotificationConfig{Token: "tok"})
+			for i, d := range tc.deliveries {
+				if got := deliver(r, d.method, d.token, d.body); got != d.wantStatus {
+					t.Errorf("delivery %d status = %d, want %d", i, got, d.wantStatus)
+				}
+			}
+			if got := recorder.count(); got != tc.wantTasks {
+				t.Errorf("the callback received %d tasks, want %d", got, tc.wantTasks)
+			}
+		})
+	}
+}
+
+func TestReceiverCallbackError(t *testing.T) {
+	recorder := &taskRecorder{err: errors.New("database unavailable")}
+	r := NewReceiver(recorder.notify)
+	config := r.NewConfig("https://client.example.com/webhook")
+	body := notificationBody(t, "t1", a2apb.TaskState_TASK_STATE_COMPLETED)
+	if got := deliver(r, http.MethodPost, config.GetToken(), body); got != http.StatusInternalServerError {
+		t.Fatalf("status = %d, want %d", got, http.StatusInternalServerError)
+	}
+	// A failed notification is not remembered, so its redelivery is handled.
+	recorder.err = nil
+	if got := deliver(r, http.MethodPost, config.GetToken(), body); got != http.StatusNoContent {
+		t.Errorf("redelivery status = %d, want %d", got, http.StatusNoContent)
+	}
+	if recorder.count() != 1 {
+		t.Errorf("the callback received %d tasks, want 1", recorder.count())
+	}
+}
+
+func TestReceiverConcurrentRedelivery(t *testing.T) {
+	release := make(chan struct{})
+	started := make(chan struct{})
+	r := NewReceiver(func(context.Context, *a2apb.Task) error {
+		close(started)
+		<-release
+		return nil
+	})
+	config := r.NewConfig("https://client.example.com/webhook")
+	body := notificationBody(t, "t1", a2apb.TaskState_TASK_STATE_COMPLETED)
+	first := make(chan int, 1)
+	go func() { first <- deliver(r, http.MethodPost, config.GetToken(), body) }()
+	<-started
+	if got := deliver(r, http.MethodPost, config.GetToken(), body); got != http.StatusServiceUnavailable {
+		t.Errorf("status of a redelivery in flight = %d, want %d", got, http.StatusServiceUnavailable)
+	}
+	close(release)
+	if got := <-first; got != http.StatusNoContent {
+		t.Errorf("status = %d, want %d", got, http.StatusNoContent)
+	}
+}
+
+func TestReceiverDedupeWindow(t *testing.T) {
+	recorder := &taskRecorder{}
+	r := NewReceiver(recorder.notify, WithDedupeWindow(20*time.Millisecond))
+	config := r.NewConfig("https://client.example.com/webhook")
+	body := notificationBody(t, "t1", a2apb.TaskState_TASK_STATE_COMPLETED)
+	deliver(r, http.MethodPost, config.GetToken(), body)
+	time.Sleep(30 * time.Millisecond)
+	deliver(r, http.MethodPost, config.GetToken(), body)
+	if recorder.count() != 2 {
+		t.Errorf("the callback received %d tasks, want the redelivery after the window handled", recorder.count())
+	}
+}
+
+func TestReceiverRegistration(t *testing.T) {
+	recorder := &taskRecorder{}
+	r := NewReceiver(recorder.notify)
+	body := notificationBody(t, "t1", a2apb.TaskState_TASK_STATE_WORKING)
+
+	r.Register(&a2apb.PushNotificationConfig{})
+	if got := deliver(r, http.MethodPost, "", body); got != http.StatusUnauthorized {
+		t.Errorf("status without token = %d, want %d", got, http.StatusUnauthorized)
+	}
+	config := r.NewConfig("https://client.example.com/webhook")
+	if config.GetToken() == "" || config.GetUrl() != "https://client.example.com/webhook" {
+		t.Fatalf("NewConfig() = %v, want a config with a token", config)
+	}
+	if other := r.NewConfig(config.GetUrl()); other.GetToken() == config.GetToken() {
+		t.Error("NewConfig() returned the same token twice")
+	}
+	if got := deliver(r, http.MethodPost, config.GetToken(), body); got != http.StatusNoContent {
+		t.Errorf("status = %d, want %d", got, http.StatusNoContent)
+	}
+	r.Unregister(config)
+	body = notificationBody(t, "t1", a2apb.TaskState_TASK_STATE_COMPLETED)
+	if got := deliver(r, http.MethodPost, config.GetToken(), body); got != http.StatusUnauthorized {
+		t.Errorf("status after Unregister() = %d, want %d", got, http.StatusUnauthorized)
+	}
+}
+
+// TestReceiverWithSender delivers notifications from a push.Sender to a Receiver.
+func TestReceiverWithSender(t *testing.T) {
+	recorder := &taskRecorder{}
+	r := NewReceiver(recorder.notify)
+	server := httptest.NewServer(r)
+	defer server.Close()
+	config := r.NewConfig(server.URL)
+
+	task := &a2apb.Task{
+		Id:        "t1",
+		ContextId: "c1",
+		Status:    &a2apb.TaskStatus{State: a2apb.TaskState_TASK_STATE_COMPLETED},
+		Artifacts: []*a2apb.Artifact{{ArtifactId: "a1", Parts: []*a2apb.Part{{Part: &a2apb.Part_Text{Text: "hello"}}}}},
+	}
+	sender := push.NewSender()
+	for range 2 {
+		if outcome := sender.Send(context.Background(), task, config); !outcome.Delivered() {
+			t.Fatalf("Send() = %+v, want delivered", outcome)
+		}
+	}
+	if recorder.count() != 1 {
+		t.Fatalf("the callback received %d tasks, want 1", recorder.count())
+	}
+	got := recorder.tasks[0]
+	if got.GetId() != "t1" || got.GetStatus().GetState() != a2apb.TaskState_TASK_STATE_COMPLETED || got.GetArtifacts()[0].GetParts()[0].GetText() != "hello" {
+		t.Errorf("received task = %v, want %v", got, task)
+	}
+}
@@ -0,0 +1,183 @@
+// Copyright 2025 The A2A Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/a2aproject/a2a-go/a2a"
+	"github.com/a2aproject/a2a-go/internal/jwt"
+)
+
+// Default settings of a Verifier.
+const (
+	DefaultMaxAge      = 5 * time.Minute
+	DefaultCacheTTL    = time.Hour
+	DefaultMaxBodySize = 10 << 20
+)
+
+// clockSkew is the tolerance for signatures issued in the future.
+const clockSkew = time.Minute
+
+var (
+	// ErrMissingSignature is returned when a notification is not signed.
+	ErrMissingSignature = errors.New("missing notification signature")
+	// ErrInvalidSignature is returned when the signature of a notification cannot be verified.
+	ErrInvalidSignature = errors.New("invalid notification signature")
+)
+
+// VerifierOption configures a Verifier.
+type VerifierOption func(*Verifier)
+
+// WithHTTPClient sets the client used to fetch the key set. http.DefaultClient is
+// used by default.
+func WithHTTPClient(client *http.Client) VerifierOption {
+	return func(v *Verifier) {
+		v.client = client
+	}
+}
+
+// WithMaxAge sets how long after being issued a signature is accepted.
+// DefaultMaxAge is used by default.
+func WithMaxAge(maxAge time.Duration) VerifierOption {
+	return func(v *Verifier) {
+		v.maxAge = maxAge
+	}
+}
+
+// WithCacheTTL sets how long the fetched key set is used before being fetched again.
+// DefaultCacheTTL is used by default. The key set is also refetched when a notification
+// is signed with a key it does not contain.
+func WithCacheTTL(ttl time.Duration) VerifierOption {
+	return func(v *Verifier) {
+		v.cacheTTL = ttl
+	}
+}
+
+// WithMaxBodySize sets the maximum size of the notifications accepted by the
+// middleware. DefaultMaxBodySize is used by default.
+func WithMaxBodySize(size int64) VerifierOption {
+	return func(v *Verifier) {
+		v.maxBodySize = size
+	}
+}
+
+// Verifier verifies the signatures of push notifications with the keys an agent
+// publishes at a JWKS URL.
+type Verifier struct {
+	jwksURL     string
+	client      *http.Client
+	maxAge      time.Duration
+	cacheTTL    time.Duration
+	maxBodySize int64
+	keys        *jwt.RemoteKeySet
+}
+
+// NewVerifier returns a Verifier using the key set published at the URL, usually
+// a2a.WellKnownJWKSPath of the agent server.
+func NewVerifier(jwksURL string, opts ...VerifierOption) *Verifier {
+	v := &Verifier{
+		jwksURL:     jwksURL,
+		client:      http.DefaultClient,
+		maxAge:      DefaultMaxAge,
+		cacheTTL:    DefaultCacheTTL,
+		maxBodySize: DefaultMaxBodySize,
+	}
+	for _, opt := range opts {
+		opt(v)
+	}
+	v.keys = jwt.NewRemoteKeySet(jwksURL, v.client, v.cacheTTL)
+	return v
+}
+
+// Verify checks that the signature was issued by the agent for the body within the
+// maximum age.
+func (v *Verifier) Verify(ctx context.Context, signature string, body []byte) error {
+	if signature == "" {
+		return ErrMissingSignature
+	}
+	token, err := jwt.Parse(signature)
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrInvalidSignature, err)
+	}
+	if token.Header.Kid == "" {
+		return fmt.Errorf("%w: missing key ID", ErrInvalidSignature)
+	}
+	key, err := v.keys.Key(ctx, token.Header.Kid)
+	if errors.Is(err, jwt.ErrUnknownKey) {
+		return fmt.Errorf("%w: %w", ErrInvalidSignature, err)
+	}
+	if err != nil {
+		return err
+	}
+	if err := token.Verify(key); err != nil {
+		return fmt.Errorf("%w: %w", ErrInvalidSignature, err)
+	}
+
+	var claims map[string]any
+	if err := json.Unmarshal(token.Claims, &claims); err != nil {
+		return fmt.Errorf("%w: malformed claims: %w", ErrInvalidSignature, err)
+	}
+	iat, ok := claims["iat"].(float64)
+	if !ok {
+		return fmt.Errorf("%w: missing iat claim", ErrInvalidSignature)
+	}
+	issued := time.Unix(int64(iat), 0)
+	now := time.Now()
+	if issued.After(now.Add(clockSkew)) || now.Sub(issued) > v.maxAge {
+		return fmt.Errorf("%w: signature issued at %s has expired", ErrInvalidSignature, issued.Format(time.RFC3339))
+	}
+	hash, _ := claims[a2a.NotificationBodyHashClaim].(string)
+	sum := sha256.Sum256(body)
+	if subtle.ConstantTimeCompare([]byte(strings.ToLower(hash)), []byte(hex.EncodeToString(sum[:]))) != 1 {
+		return fmt.Errorf("%w: body does not match the signature", ErrInvalidSignature)
+	}
+	return nil
+}
+
+// Middleware returns a handler which verifies the signature of the notifications
+// before passing them to next. Notifications failing verification are rejected with
+// HTTP status 401. The body of the request remains readable by next.
+func (v *Verifier) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, v.maxBodySize))
+		if err != nil {
+			var tooLarge *http.MaxBytesError
+			if errors.As(err, &tooLarge) {
+				http.Error(w, "notification too large", http.StatusRequestEntityTooLarge)
+				return
+			}
+			http.Error(w, "failed to read notification", http.StatusBadRequest)
+			return
+		}
+		if err := v.Verify(r.Context(), r.Header.Get(a2a.NotificationSignatureHeader), body); err != nil {
+			http.Error(w, err.Error(), http.StatusUnauthorized)
+			return
+		}
+		r.Body = io.NopCloser(bytes.NewReader(body))
+		next.ServeHTTP(w, r)
+	})
+}
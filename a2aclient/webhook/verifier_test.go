@@ -0,0 +1,234 @@
+// Copyright 2025 The A2A Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package webhook
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/a2aproject/a2a-go/a2a"
+	"github.com/a2aproject/a2a-go/a2asrv/push"
+	a2apb "github.com/a2aproject/a2a-go/grpc"
+	"github.com/a2aproject/a2a-go/internal/jwt"
+)
+
+// jwksServer serves the key set of the signers and counts the requests.
+type jwksServer struct {
+	*httptest.Server
+	requests atomic.Int32
+}
+
+func newJWKSServer(t *testing.T, signers ...*push.Signer) *jwksServer {
+	t.Helper()
+	handler, err := push.NewJWKSHandler(signers...)
+	if err != nil {
+		t.Fatalf("NewJWKSHandler() error = %v", err)
+	}
+	s := &jwksServer{}
+	s.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		s.requests.Add(1)
+		handler.ServeHTTP(w, r)
+	}))
+	t.Cleanup(s.Close)
+	return s
+}
+
+func newTestSigner(t *testing.T, kid string) (*push.Signer, crypto.Signer) {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	signer, err := push.NewSigner(kid, key)
+	if err != nil {
+		t.Fatalf("NewSigner() error = %v", err)
+	}
+	return signer, key
+}
+
+// signClaims signs a notification signature with custom claims.
+func signClaims(t *testing.T, key crypto.Signer, kid string, claims map[string]any) string {
+	t.Helper()
+	alg, err := jwt.Algorithm(key.Public())
+	if err != nil {
+		t.Fatal(err)
+	}
+	token, err := jwt.Sign(jwt.Header{Alg: alg, Kid: kid, Typ: "JWT"}, claims, key)
+	if err != nil {
+		t.Fatalf("jwt.Sign() error = %v", err)
+	}
+	return token
+}
+
+func bodyHash(body []byte) string {
+	sum := sha256.Sum256(body)
+	return hex.EncodeToString(sum[:])
+}
+
+func TestVerifierVerify(t *testing.T) {
+	signer, key := newTestSigner(t, "k1")
+	_, otherKey := newTestSigner(t, "k1")
+	server := newJWKSServer(t, signer)
+	body := []byte(`{"id":"t1"}`)
+	valid, err := signer.Sign(body)
+	if err != nil {
+		t.Fatalf("Sign() error = %v", err)
+	}
+	now := time.Now().Unix()
+	parts := strings.Split(valid, ".")
+
+	tests := []struct {
+		name      string
+		signature string
+		body      []byte
+		wantErr   error
+	}{
+		{name: "valid", signature: valid, body: body},
+		{name: "missing signature", body: body, wantErr: ErrMissingSignature},
+		{name: "malformed signature", signature: "not a jws", body: body, wantErr: ErrInvalidSignature},
+		{name: "other body", signature: valid, body: []byte(`{"id":"t2"}`), wantErr: ErrInvalidSignature},
+		{name: "other key", signature: signClaims(t, otherKey, "k1", map[string]any{"iat": now, a2a.NotificationBodyHashClaim: bodyHash(body)}), body: body, wantErr: ErrInvalidSignature},
+		{name: "unknown key", signature: signClaims(t, key, "k2", map[string]any{"iat": now, a2a.NotificationBodyHashClaim: bodyHash(body)}), body: body, wantErr: ErrInvalidSignature},
+		{name: "missing key ID", signature: signClaims(t, key, "", map[string]any{"iat": now, a2a.NotificationBodyHashClaim: bodyHash(body)}), body: body, wantErr: ErrInvalidSignature},
+		{name: "expired", signature: signClaims(t, key, "k1", map[string]any{"iat": now - 600, a2a.NotificationBodyHashClaim: bodyHash(body)}), body: body, wantErr: ErrInvalidSignature},
+		{name: "issued in the future", signature: signClaims(t, key, "k1", map[string]any{"iat": now + 600, a2a.NotificationBodyHashClaim: bodyHash(body)}), body: body, wantErr: ErrInvalidSignature},
+		{name: "small clock skew", signature: signClaims(t, key, "k1", map[string]any{"iat": now + 30, a2a.NotificationBodyHashClaim: bodyHash(body)}), body: body},
+		{name: "missing iat", signature: signClaims(t, key, "k1", map[string]any{a2a.NotificationBodyHashClaim: bodyHash(body)}), body: body, wantErr: ErrInvalidSignature},
+		{name: "missing body hash", signature: signClaims(t, key, "k1", map[string]any{"iat": now}), body: body, wantErr: ErrInvalidSignature},
+		{name: "uppercase body hash", signature: signClaims(t, key, "k1", map[string]any{"iat": now, a2a.NotificationBodyHashClaim: strings.ToUpper(bodyHash(body))}), body: body},
+		{name: "alg none", signature: "eyJhbGciOiJub25lIiwia2lkIjoiazEifQ." + parts[1] + ".", body: body, wantErr: ErrInvalidSignature},
+	}
+	v := NewVerifier(server.URL)
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if err := v.Verify(context.Background(), tc.signature, tc.body); !errors.Is(err, tc.wantErr) {
+				t.Errorf("Verify() error = %v, want %v", err, tc.wantErr)
+			}
+		})
+	}
+	// The key set is cached, and unknown keys do not trigger a refetch every time.
+	if got := server.requests.Load(); got != 1 {
+		t.Errorf("the key set was fetched %d times, want 1", got)
+	}
+}
+
+func TestVerifierMaxAge(t *testing.T) {
+	signer, key := newTestSigner(t, "k1")
+	v := NewVerifier(newJWKSServer(t, signer).URL, WithMaxAge(time.Hour))
+	body := []byte("{}")
+	signature := signClaims(t, key, "k1", map[string]any{"iat": time.Now().Add(-30 * time.Minute).Unix(), a2a.NotificationBodyHashClaim: bodyHash(body)})
+	if err := v.Verify(context.Background(), signature, body); err != nil {
+		t.Errorf("Verify() error = %v", err)
+	}
+}
+
+func TestVerifierKeySetUnavailable(t *testing.T) {
+	signer, _ := newTestSigner(t, "k1")
+	server := httptest.NewServer(http.NotFoundHandler())
+	defer server.Close()
+	signature, err := signer.Sign([]byte("{}"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = NewVerifier(server.URL).Verify(context.Background(), signature, []byte("{}"))
+	if err == nil || errors.Is(err, ErrInvalidSignature) {
+		t.Errorf("Verify() error = %v, want a key set error", err)
+	}
+}
+
+func TestVerifierMiddleware(t *testing.T) {
+	signer, _ := newTestSigner(t, "k1")
+	v := NewVerifier(newJWKSServer(t, signer).URL, WithMaxBodySize(64))
+	var received []byte
+	handler := v.Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received, _ = io.ReadAll(r.Body)
+	}))
+
+	body := `{"id":"t1"}`
+	signature, err := signer.Sign([]byte(body))
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		name       string
+		body       string
+		signature  string
+		wantStatus int
+	}{
+		{name: "valid", body: body, signature: signature, wantStatus: http.StatusOK},
+		{name: "unsigned", body: body, wantStatus: http.StatusUnauthorized},
+		{name: "tampered", body: `{"id":"t2"}`, signature: signature, wantStatus: http.StatusUnauthorized},
+		{name: "too large", body: strings.Repeat("x", 65), signature: signature, wantStatus: http.StatusRequestEntityTooLarge},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			received = nil
+			req := httptest.NewRequest(http.MethodPost, "/webhook", strings.NewReader(tc.body))
+			if tc.signature != "" {
+				req.Header.Set(a2a.NotificationSignatureHeader, tc.signature)
+			}
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, req)
+			if rec.Code != tc.wantStatus {
+				t.Fatalf("status = %d, want %d", rec.Code, tc.wantStatus)
+			}
+			if tc.wantStatus == http.StatusOK && string(received) != tc.body {
+				t.Errorf("the next handler read %q, want %q", received, tc.body)
+			}
+			if tc.wantStatus != http.StatusOK && received != nil {
+				t.Error("the next handler was called for a rejected notification")
+			}
+		})
+	}
+}
+
+// TestSignedNotifications sends notifications signed by a push.Sender to a webhook
+// verifying them.
+func TestSignedNotifications(t *testing.T) {
+	_, edKey, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	signer, err := push.NewSigner("ed", edKey)
+	if err != nil {
+		t.Fatalf("NewSigner() error = %v", err)
+	}
+	verifier := NewVerifier(newJWKSServer(t, signer).URL)
+	hook := httptest.NewServer(verifier.Middleware(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {})))
+	defer hook.Close()
+
+	task := &a2apb.Task{Id: "t1", ContextId: "c1", Status: &a2apb.TaskStatus{State: a2apb.TaskState_TASK_STATE_COMPLETED}}
+	config := &a2apb.PushNotificationConfig{Url: hook.URL}
+	if outcome := push.NewSender(push.WithSigner(signer)).Send(context.Background(), task, config); !outcome.Delivered() {
+		t.Errorf("Send() of a signed notification = %+v, want delivered", outcome)
+	}
+	if outcome := push.NewSender(push.WithRetry(1, 0, 0)).Send(context.Background(), task, config); outcome.StatusCode != http.StatusUnauthorized {
+		t.Errorf("Send() of an unsigned notification status = %d, want %d", outcome.StatusCode, http.StatusUnauthorized)
+	}
+}
@@ -0,0 +1,113 @@
+// Copyright 2025 The A2A Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package a2asrv
+
+import (
+	"fmt"
+	"net/http"
+
+	"google.golang.org/protobuf/proto"
+
+	"github.com/a2aproject/a2a-go/a2a"
+	a2apb "github.com/a2aproject/a2a-go/grpc"
+)
+
+// CardAuthenticator checks whether the caller is allowed to retrieve the extended card.
+// A nil error means the request is authenticated.
+type CardAuthenticator func(r *http.Request) error
+
+// AgentCardHandler is an http.Handler serving the public AgentCard at
+// a2a.WellKnownAgentCardPath without authentication and, if configured, the extended
+// AgentCard at a2a.ExtendedAgentCardPath to authenticated callers.
+// The handler is expected to be mounted at the base URL of the agent server.
+type AgentCardHandler struct {
+	public       []byte
+	extended     []byte
+	authenticate CardAuthenticator
+}
+
+// AgentCardOption configures an AgentCardHandler.
+type AgentCardOption func(*agentCardConfig)
+
+type agentCardConfig struct {
+	extended     *a2apb.AgentCard
+	authenticate CardAuthenticator
+}
+
+// WithExtendedCard configures the card served to callers accepted by the authenticator.
+// The extended card usually lists additional skills or security details which must
+// not be disclosed publicly.
+func WithExtendedCard(card *a2apb.AgentCard, authenticate CardAuthenticator) AgentCardOption {
+	return func(c *agentCardConfig) {
+		c.extended = card
+		c.authenticate = authenticate
+	}
+}
+
+// NewAgentCardHandler creates an AgentCardHandler for the public card. If an extended card
+// is configured, SupportsAuthenticatedExtendedCard is set on the served public card.
+func NewAgentCardHandler(public *a2apb.AgentCard, opts ...AgentCardOption) (*AgentCardHandler, error) {
+	config := &agentCardConfig{}
+	for _, opt := range opts {
+		opt(config)
+	}
+	h := &AgentCardHandler{authenticate: config.authenticate}
+	if config.extended != nil {
+		public = proto.Clone(public).(*a2apb.AgentCard)
+		public.SupportsAuthenticatedExtendedCard = true
+		extended, err := a2a.MarshalAgentCard(config.extended)
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode extended agent card: %w", err)
+		}
+		h.extended = extended
+	}
+	data, err := a2a.MarshalAgentCard(public)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode agent card: %w", err)
+	}
+	h.public = data
+	return h, nil
+}
+
+// ServeHTTP implements http.Handler.
+func (h *AgentCardHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		w.Header().Set("Allow", "GET, HEAD")
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	switch r.URL.Path {
+	case a2a.WellKnownAgentCardPath:
+		writeCard(w, h.public)
+	case a2a.ExtendedAgentCardPath:
+		if h.extended == nil {
+			http.NotFound(w, r)
+			return
+		}
+		if h.authenticate == nil || h.authenticate(r) != nil {
+			http.Error(w, "unauthorized", http.StatusUnauthorized)
+			return
+		}
+		w.Header().Set("Cache-Control", "private")
+		writeCard(w, h.extended)
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+func writeCard(w http.ResponseWriter, card []byte) {
+	w.Header().Set("Content-Type", "application/json")
+	_, _ = w.Write(card)
+}
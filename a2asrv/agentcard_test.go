@@ -0,0 +1,137 @@
+// Copyright 2025 The A2A Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package a2asrv
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/a2aproject/a2a-go/a2a"
+	a2apb "github.com/a2aproject/a2a-go/grpc"
+)
+
+func TestAgentCardHandler(t *testing.T) {
+	public := &a2apb.AgentCard{Name: "public", Url: "https://agent.example.com", Version: "1"}
+	extended := &a2apb.AgentCard{Name: "extended", Url: "https://agent.example.com", Version: "1"}
+	authenticate := func(r *http.Request) error {
+		if r.Header.Get("Authorization") != "Bearer secret" {
+			return errors.New("unauthorized")
+		}
+		return nil
+	}
+	withExtended, err := NewAgentCardHandler(public, WithExtendedCard(extended, authenticate))
+	if err != nil {
+		t.Fatalf("NewAgentCardHandler() error = %v", err)
+	}
+	publicOnly, err := NewAgentCardHandler(public)
+	if err != nil {
+		t.Fatalf("NewAgentCardHandler() error = %v", err)
+	}
+
+	tests := []struct {
+		name         string
+		handler      http.Handler
+		method       string
+		path         string
+		token        string
+		wantStatus   int
+		wantName     string
+		wantExtended bool
+	}{
+		{
+			name:         "public card",
+			handler:      withExtended,
+			path:         a2a.WellKnownAgentCardPath,
+			wantStatus:   http.StatusOK,
+			wantName:     "public",
+			wantExtended: true,
+		},
+		{
+			name:       "public card without extended card",
+			handler:    publicOnly,
+			path:       a2a.WellKnownAgentCardPath,
+			wantStatus: http.StatusOK,
+			wantName:   "public",
+		},
+		{
+			name:       "extended card",
+			handler:    withExtended,
+			path:       a2a.ExtendedAgentCardPath,
+			token:      "secret",
+			wantStatus: http.StatusOK,
+			wantName:   "extended",
+		},
+		{
+			name:       "extended card unauthenticated",
+			handler:    withExtended,
+			path:       a2a.ExtendedAgentCardPath,
+			token:      "wrong",
+			wantStatus: http.StatusUnauthorized,
+		},
+		{
+			name:       "extended card not configured",
+			handler:    publicOnly,
+			path:       a2a.ExtendedAgentCardPath,
+			token:      "secret",
+			wantStatus: http.StatusNotFound,
+		},
+		{
+			name:       "unknown path",
+			handler:    withExtended,
+			path:       "/agent.json",
+			wantStatus: http.StatusNotFound,
+		},
+		{
+			name:       "method not allowed",
+			handler:    withExtended,
+			method:     http.MethodPost,
+			path:       a2a.WellKnownAgentCardPath,
+			wantStatus: http.StatusMethodNotAllowed,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			method := tc.method
+			if method == "" {
+				method = http.MethodGet
+			}
+			req := httptest.NewRequest(method, tc.path, nil)
+			if tc.token != "" {
+				req.Header.Set("Authorization", "Bearer "+tc.token)
+			}
+			rec := httptest.NewRecorder()
+			tc.handler.ServeHTTP(rec, req)
+			if rec.Code != tc.wantStatus {
+				t.Fatalf("status = %d, want %d", rec.Code, tc.wantStatus)
+			}
+			if tc.wantStatus != http.StatusOK {
+				return
+			}
+			card, err := a2a.UnmarshalAgentCard(rec.Body.Bytes())
+			if err != nil {
+				t.Fatalf("UnmarshalAgentCard() error = %v", err)
+			}
+			if card.GetName() != tc.wantName || card.GetSupportsAuthenticatedExtendedCard() != tc.wantExtended {
+				t.Errorf("served card = %v, want %q with extended card support %v", card, tc.wantName, tc.wantExtended)
+			}
+		})
+	}
+
+	if public.GetSupportsAuthenticatedExtendedCard() {
+		t.Error("NewAgentCardHandler() modified the provided public card")
+	}
+}
@@ -0,0 +1,274 @@
+// Copyright 2025 The A2A Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package auth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	a2apb "github.com/a2aproject/a2a-go/grpc"
+)
+
+var (
+	// ErrUnauthenticated is returned when a call does not satisfy any security
+	// requirement of the AgentCard. It can be matched using errors.Is.
+	ErrUnauthenticated = status.Error(codes.Unauthenticated, "unauthenticated")
+	// ErrInsufficientScope is returned when the credentials of a call are valid but
+	// lack the scopes required by the AgentCard. It can be matched using errors.Is.
+	ErrInsufficientScope = status.Error(codes.PermissionDenied, "insufficient scope")
+)
+
+// Option configures an Authenticator.
+type Option func(*Authenticator)
+
+// WithVerifier sets the verifier of the credentials presented for the security scheme
+// with the name in the AgentCard.
+func WithVerifier(scheme string, verifier Verifier) Option {
+	return func(a *Authenticator) {
+		a.verifiers[scheme] = verifier
+	}
+}
+
+// Authenticator enforces the security requirements of an AgentCard. A call is
+// authenticated if it satisfies any of the requirements listed in Security, and
+// satisfies a requirement if it presents valid credentials with the required scopes
+// for all the schemes of the requirement. Calls to agents without requirements are
+// accepted without authentication.
+type Authenticator struct {
+	schemes      map[string]*a2apb.SecurityScheme
+	requirements []requirement
+	verifiers    map[string]Verifier
+}
+
+// requirement is a security requirement of the card with its schemes sorted by name.
+type requirement []schemeScopes
+
+type schemeScopes struct {
+	name   string
+	scopes []string
+}
+
+// NewAuthenticator returns an Authenticator for the security requirements declared by
+// the card. Every scheme used by a requirement must have a verifier.
+func NewAuthenticator(card *a2apb.AgentCard, opts ...Option) (*Authenticator, error) {
+	a := &Authenticator{schemes: card.GetSecuritySchemes(), verifiers: make(map[string]Verifier)}
+	for _, opt := range opts {
+		opt(a)
+	}
+	for i, security := range card.GetSecurity() {
+		var req requirement
+		for name, scopes := range security.GetSchemes() {
+			scheme, ok := a.schemes[name]
+			if !ok {
+				return nil, fmt.Errorf("security requirement %d uses undeclared scheme %q", i, name)
+			}
+			if scheme.GetScheme() == nil {
+				return nil, fmt.Errorf("security scheme %q has no type", name)
+			}
+			if a.verifiers[name] == nil {
+				return nil, fmt.Errorf("no verifier for security scheme %q", name)
+			}
+			req = append(req, schemeScopes{name: name, scopes: scopes.GetList()})
+		}
+		slices.SortFunc(req, func(a, b schemeScopes) int { return strings.Compare(a.name, b.name) })
+		a.requirements = append(a.requirements, req)
+	}
+	return a, nil
+}
+
+// Authenticate checks the credentials of the call against the security requirements
+// and returns a context carrying the authenticated principals. It fails with
+// ErrUnauthenticated or ErrInsufficientScope.
+func (a *Authenticator) Authenticate(ctx context.Context, creds Credentials) (context.Context, error) {
+	if len(a.requirements) == 0 {
+		return ctx, nil
+	}
+	// Schemes shared by several requirements are only verified once.
+	verified := make(map[string]*Principal)
+	failures := make(map[string]error)
+	var errs []error
+	insufficientScope := false
+	for _, req := range a.requirements {
+		principals := make([]*Principal, 0, len(req))
+		for _, s := range req {
+			p, err := a.verify(ctx, s.name, creds, verified, failures)
+			if err != nil {
+				errs = append(errs, err)
+				principals = nil
+				break
+			}
+			if !p.HasScopes(s.scopes...) {
+				insufficientScope = true
+				principals = nil
+				break
+			}
+			principals = append(principals, p)
+		}
+		if principals != nil {
+			return NewContext(ctx, principals...), nil
+		}
+	}
+	if insufficientScope {
+		return nil, ErrInsufficientScope
+	}
+	return nil, &authError{err: ErrUnauthenticated, reasons: errs}
+}
+
+// authError attaches the reasons of an authentication failure to one of the errors
+// of the package, keeping its gRPC status code.
+type authError struct {
+	err     error
+	reasons []error
+}
+
+func (e *authError) Error() string {
+	msg := status.Convert(e.err).Message()
+	for i, reason := range e.reasons {
+		if i == 0 {
+			msg += ": "
+		} else {
+			msg += "; "
+		}
+		msg += reason.Error()
+	}
+	return msg
+}
+
+func (e *authError) Unwrap() []error {
+	return append([]error{e.err}, e.reasons...)
+}
+
+// GRPCStatus returns the status of the error for gRPC servers.
+func (e *authError) GRPCStatus() *status.Status {
+	return status.New(status.Code(e.err), e.Error())
+}
+
+// AuthenticateRequest authenticates an HTTP request. It can be used as the
+// a2asrv.CardAuthenticator of the extended AgentCard.
+func (a *Authenticator) AuthenticateRequest(r *http.Request) error {
+	_, err := a.Authenticate(r.Context(), HTTPCredentials(r))
+	return err
+}
+
+// verify returns the principal of the credentials presented for the scheme,
+// memoizing the result in verified or failures.
+func (a *Authenticator) verify(ctx context.Context, name string, creds Credentials, verified map[string]*Principal, failures map[string]error) (*Principal, error) {
+	if p, ok := verified[name]; ok {
+		return p, nil
+	}
+	if err, ok := failures[name]; ok {
+		return nil, err
+	}
+	p, err := a.verifyScheme(ctx, name, creds)
+	if err != nil {
+		failures[name] = err
+		return nil, err
+	}
+	verified[name] = p
+	return p, nil
+}
+
+func (a *Authenticator) verifyScheme(ctx context.Context, name string, creds Credentials) (*Principal, error) {
+	credential, err := extract(a.schemes[name], creds)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", name, err)
+	}
+	p, err := a.verifiers[name].Verify(ctx, credential)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", name, err)
+	}
+	// The principal is copied, as verifiers may return shared values.
+	principal := Principal{}
+	if p != nil {
+		principal = *p
+	}
+	principal.Scheme = name
+	return &principal, nil
+}
+
+// extract returns the credential presented for the scheme.
+func extract(scheme *a2apb.SecurityScheme, creds Credentials) (string, error) {
+	switch s := scheme.GetScheme().(type) {
+	case *a2apb.SecurityScheme_ApiKeySecurityScheme:
+		key := s.ApiKeySecurityScheme
+		var value string
+		switch strings.ToLower(key.GetLocation()) {
+		case "header":
+			value = creds.Header(key.GetName())
+		case "query":
+			value = creds.Query(key.GetName())
+		case "cookie":
+			value = creds.Cookie(key.GetName())
+		default:
+			return "", fmt.Errorf("unsupported API key location %q", key.GetLocation())
+		}
+		if value == "" {
+			return "", fmt.Errorf("missing API key %s", key.GetName())
+		}
+		return value, nil
+	case *a2apb.SecurityScheme_HttpAuthSecurityScheme:
+		return authorization(creds, s.HttpAuthSecurityScheme.GetScheme())
+	case *a2apb.SecurityScheme_Oauth2SecurityScheme, *a2apb.SecurityScheme_OpenIdConnectSecurityScheme:
+		return authorization(creds, "Bearer")
+	default:
+		return "", fmt.Errorf("unsupported security scheme %T", s)
+	}
+}
+
+// authorization returns the credentials of the Authorization header with the scheme.
+func authorization(creds Credentials, scheme string) (string, error) {
+	header := creds.Header("Authorization")
+	if header == "" {
+		return "", errors.New("missing Authorization header")
+	}
+	name, credential, ok := strings.Cut(header, " ")
+	if !ok || !strings.EqualFold(name, scheme) || strings.TrimSpace(credential) == "" {
+		return "", fmt.Errorf("expected %s Authorization header", scheme)
+	}
+	return strings.TrimSpace(credential), nil
+}
+
+// challenge returns the value of the WWW-Authenticate header for the HTTP schemes
+// of the card, or an empty string if none is declared.
+func (a *Authenticator) challenge() string {
+	var challenges []string
+	for _, req := range a.requirements {
+		for _, s := range req {
+			var scheme string
+			switch t := a.schemes[s.name].GetScheme().(type) {
+			case *a2apb.SecurityScheme_HttpAuthSecurityScheme:
+				scheme = t.HttpAuthSecurityScheme.GetScheme()
+			case *a2apb.SecurityScheme_Oauth2SecurityScheme, *a2apb.SecurityScheme_OpenIdConnectSecurityScheme:
+				scheme = "Bearer"
+			default:
+				continue
+			}
+			if len(scheme) > 0 {
+				scheme = strings.ToUpper(scheme[:1]) + strings.ToLower(scheme[1:])
+			}
+			if !slices.Contains(challenges, scheme) {
+				challenges = append(challenges, scheme)
+			}
+		}
+	}
+	return strings.Join(challenges, ", ")
+}
@@ -0,0 +1,394 @@
+// Copyright 2025 The A2A Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package auth
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	a2apb "github.com/a2aproject/a2a-go/grpc"
+)
+
+func apiKeyScheme(location, name string) *a2apb.SecurityScheme {
+	return &a2apb.SecurityScheme{Scheme: &a2apb.SecurityScheme_ApiKeySecurityScheme{
+		ApiKeySecurityScheme: &a2apb.APIKeySecurityScheme{Location: location, Name: name},
+	}}
+}
+
+func httpScheme(scheme string) *a2apb.SecurityScheme {
+	return &a2apb.SecurityScheme{Scheme: &a2apb.SecurityScheme_HttpAuthSecurityScheme{
+		HttpAuthSecurityScheme: &a2apb.HTTPAuthSecurityScheme{Scheme: scheme},
+	}}
+}
+
+func oauth2Scheme() *a2apb.SecurityScheme {
+	return &a2apb.SecurityScheme{Scheme: &a2apb.SecurityScheme_Oauth2SecurityScheme{
+		Oauth2SecurityScheme: &a2apb.OAuth2SecurityScheme{},
+	}}
+}
+
+// requires returns a security requirement of the schemes with their scopes.
+func requires(schemes map[string][]string) *a2apb.Security {
+	security := &a2apb.Security{Schemes: make(map[string]*a2apb.StringList)}
+	for name, scopes := range schemes {
+		security.Schemes[name] = &a2apb.StringList{List: scopes}
+	}
+	return security
+}
+
+// newTestCard returns a card accepting an API key, an OAuth2 token with the write
+// scope, or HTTP basic credentials together with a tenant query parameter.
+func newTestCard() *a2apb.AgentCard {
+	return &a2apb.AgentCard{
+		SecuritySchemes: map[string]*a2apb.SecurityScheme{
+			"key":    apiKeyScheme("header", "X-API-Key"),
+			"oauth":  oauth2Scheme(),
+			"basic":  httpScheme("basic"),
+			"tenant": apiKeyScheme("query", "tenant"),
+		},
+		Security: []*a2apb.Security{
+			requires(map[string][]string{"key": nil}),
+			requires(map[string][]string{"oauth": {"write"}}),
+			requires(map[string][]string{"basic": nil, "tenant": nil}),
+		},
+	}
+}
+
+func newTestVerifiers() []Option {
+	return []Option{
+		WithVerifier("key", VerifierFunc(func(_ context.Context, key string) (*Principal, error) {
+			if key != "k1" {
+				return nil, errors.New("unknown key")
+			}
+			return &Principal{Subject: "service"}, nil
+		})),
+		WithVerifier("oauth", VerifierFunc(func(_ context.Context, token string) (*Principal, error) {
+			switch token {
+			case "rw":
+				return &Principal{Subject: "alice", Scopes: []string{"read", "write"}}, nil
+			case "ro":
+				return &Principal{Subject: "bob", Scopes: []string{"read"}}, nil
+			}
+			return nil, errors.New("invalid token")
+		})),
+		WithVerifier("basic", BasicVerifier(func(_ context.Context, username, password string) (*Principal, error) {
+			if password != "secret" {
+				return nil, errors.New("wrong password")
+			}
+			return &Principal{Subject: username}, nil
+		})),
+		WithVerifier("tenant", VerifierFunc(func(_ context.Context, tenant string) (*Principal, error) {
+			return &Principal{Subject: tenant}, nil
+		})),
+	}
+}
+
+func newTestAuthenticator(t *testing.T) *Authenticator {
+	t.Helper()
+	a, err := NewAuthenticator(newTestCard(), newTestVerifiers()...)
+	if err != nil {
+		t.Fatalf("NewAuthenticator() error = %v", err)
+	}
+	return a
+}
+
+// newRequest returns a request to the target with the headers.
+func newRequest(target string, headers map[string]string) *http.Request {
+	r := httptest.NewRequest(http.MethodPost, target, nil)
+	for name, value := range headers {
+		r.Header.Set(name, value)
+	}
+	return r
+}
+
+// subjects returns the scheme and subject of the principals.
+func subjects(principals []*Principal) []string {
+	var result []string
+	for _, p := range principals {
+		result = append(result, p.Scheme+":"+p.Subject)
+	}
+	return result
+}
+
+func TestNewAuthenticator(t *testing.T) {
+	tests := []struct {
+		name    string
+		card    *a2apb.AgentCard
+		opts    []Option
+		wantErr string
+	}{
+		{name: "valid", card: newTestCard(), opts: newTestVerifiers()},
+		{name: "no requirements", card: &a2apb.AgentCard{}},
+		{
+			name:    "missing verifier",
+			card:    newTestCard(),
+			opts:    newTestVerifiers()[1:],
+			wantErr: `no verifier for security scheme "key"`,
+		},
+		{
+			name: "undeclared scheme",
+			card: &a2apb.AgentCard{
+				Security: []*a2apb.Security{requires(map[string][]string{"key": nil})},
+			},
+			opts:    newTestVerifiers(),
+			wantErr: `uses undeclared scheme "key"`,
+		},
+		{
+			name: "scheme without type",
+			card: &a2apb.AgentCard{
+				SecuritySchemes: map[string]*a2apb.SecurityScheme{"key": {}},
+				Security:        []*a2apb.Security{requires(map[string][]string{"key": nil})},
+			},
+			opts:    newTestVerifiers(),
+			wantErr: `security scheme "key" has no type`,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := NewAuthenticator(tc.card, tc.opts...)
+			if tc.wantErr == "" {
+				if err != nil {
+					t.Errorf("NewAuthenticator() error = %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tc.wantErr) {
+				t.Errorf("NewAuthenticator() error = %v, want %q", err, tc.wantErr)
+			}
+		})
+	}
+}
+
+func TestAuthenticate(t *testing.T) {
+	a := newTestAuthenticator(t)
+	tests := []struct {
+		name    string
+		target  string
+		headers map[string]string
+		want    []string
+		wantErr error
+	}{
+		{name: "no credentials", wantErr: ErrUnauthenticated},
+		{name: "API key", headers: map[string]string{"X-API-Key": "k1"}, want: []string{"key:service"}},
+		{name: "unknown API key", headers: map[string]string{"X-API-Key": "k2"}, wantErr: ErrUnauthenticated},
+		{name: "API key in query", target: "/?X-API-Key=k1", wantErr: ErrUnauthenticated},
+		{name: "bearer token", headers: map[string]string{"Authorization": "Bearer rw"}, want: []string{"oauth:alice"}},
+		{name: "bearer scheme in lower case", headers: map[string]string{"Authorization": "bearer  rw "}, want: []string{"oauth:alice"}},
+		{name: "invalid token", headers: map[string]string{"Authorization": "Bearer xx"}, wantErr: ErrUnauthenticated},
+		{name: "empty token", headers: map[string]string{"Authorization": "Bearer "}, wantErr: ErrUnauthenticated},
+		{name: "token without scheme", headers: map[string]string{"Authorization": "rw"}, wantErr: ErrUnauthenticated},
+		{name: "insufficient scope", headers: map[string]string{"Authorization": "Bearer ro"}, wantErr: ErrInsufficientScope},
+		{
+			name:    "insufficient scope with another requirement satisfied",
+			headers: map[string]string{"Authorization": "Bearer ro", "X-API-Key": "k1"},
+			want:    []string{"key:service"},
+		},
+		{
+			name:    "basic credentials without tenant",
+			headers: map[string]string{"Authorization": "Basic dXNlcjpzZWNyZXQ="},
+			wantErr: ErrUnauthenticated,
+		},
+		{
+			name:    "basic credentials with tenant",
+			target:  "/?tenant=acme",
+			headers: map[string]string{"Authorization": "Basic dXNlcjpzZWNyZXQ="},
+			want:    []string{"basic:user", "tenant:acme"},
+		},
+		{
+			name:    "wrong password",
+			target:  "/?tenant=acme",
+			headers: map[string]string{"Authorization": "Basic dXNlcjp3cm9uZw=="},
+			wantErr: ErrUnauthenticated,
+		},
+		{
+			name:    "malformed basic credentials",
+			target:  "/?tenant=acme",
+			headers: map[string]string{"Authorization": "Basic !!!"},
+			wantErr: ErrUnauthenticated,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			target := tc.target
+			if target == "" {
+				target = "/"
+			}
+			ctx, err := a.Authenticate(context.Background(), HTTPCredentials(newRequest(target, tc.headers)))
+			if tc.wantErr != nil {
+				if !errors.Is(err, tc.wantErr) {
+					t.Fatalf("Authenticate() error = %v, want %v", err, tc.wantErr)
+				}
+				if got, want := status.Code(err), status.Code(tc.wantErr); got != want {
+					t.Errorf("status.Code() = %v, want %v", got, want)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Authenticate() error = %v", err)
+			}
+			if got := subjects(PrincipalsFromContext(ctx)); !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("PrincipalsFromContext() = %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestAuthenticateReportsReasons(t *testing.T) {
+	a := newTestAuthenticator(t)
+	_, err := a.Authenticate(context.Background(), HTTPCredentials(newRequest("/", map[string]string{"X-API-Key": "k2"})))
+	if !errors.Is(err, ErrUnauthenticated) {
+		t.Fatalf("Authenticate() error = %v, want %v", err, ErrUnauthenticated)
+	}
+	for _, want := range []string{"key: unknown key", "oauth: missing Authorization header"} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("Authenticate() error = %q, want it to contain %q", err, want)
+		}
+		if msg := status.Convert(err).Message(); !strings.Contains(msg, want) {
+			t.Errorf("status message = %q, want it to contain %q", msg, want)
+		}
+	}
+}
+
+func TestAuthenticateWithoutRequirements(t *testing.T) {
+	a, err := NewAuthenticator(&a2apb.AgentCard{})
+	if err != nil {
+		t.Fatalf("NewAuthenticator() error = %v", err)
+	}
+	ctx, err := a.Authenticate(context.Background(), HTTPCredentials(newRequest("/", nil)))
+	if err != nil {
+		t.Fatalf("Authenticate() error = %v", err)
+	}
+	if p, ok := FromContext(ctx); ok {
+		t.Errorf("FromContext() = %+v, want no principal", p)
+	}
+}
+
+func TestAuthenticateVerifiesSchemesOnce(t *testing.T) {
+	card := &a2apb.AgentCard{
+		SecuritySchemes: map[string]*a2apb.SecurityScheme{"oauth": oauth2Scheme()},
+		Security: []*a2apb.Security{
+			requires(map[string][]string{"oauth": {"admin"}}),
+			requires(map[string][]string{"oauth": {"read"}}),
+			requires(map[string][]string{"oauth": {"write"}}),
+		},
+	}
+	tests := []struct {
+		name    string
+		token   string
+		wantErr error
+	}{
+		{name: "valid", token: "Bearer ro"},
+		{name: "insufficient scope", token: "Bearer none", wantErr: ErrInsufficientScope},
+		{name: "invalid", token: "Bearer invalid", wantErr: ErrUnauthenticated},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			calls := 0
+			a, err := NewAuthenticator(card, WithVerifier("oauth", VerifierFunc(func(_ context.Context, token string) (*Principal, error) {
+				calls++
+				switch token {
+				case "ro":
+					return &Principal{Subject: "bob", Scopes: []string{"read"}}, nil
+				case "none":
+					return &Principal{Subject: "carol"}, nil
+				}
+				return nil, errors.New("invalid token")
+			})))
+			if err != nil {
+				t.Fatalf("NewAuthenticator() error = %v", err)
+			}
+			_, err = a.Authenticate(context.Background(), HTTPCredentials(newRequest("/", map[string]string{"Authorization": tc.token})))
+			if !errors.Is(err, tc.wantErr) {
+				t.Errorf("Authenticate() error = %v, want %v", err, tc.wantErr)
+			}
+			if calls != 1 {
+				t.Errorf("the verifier was called %d times, want 1", calls)
+			}
+		})
+	}
+}
+
+func TestAuthenticateCopiesPrincipals(t *testing.T) {
+	shared := &Principal{Subject: "service", Scopes: []string{"read"}}
+	card := &a2apb.AgentCard{
+		SecuritySchemes: map[string]*a2apb.SecurityScheme{
+			"key":   apiKeyScheme("header", "X-API-Key"),
+			"other": apiKeyScheme("cookie", "session"),
+		},
+		Security: []*a2apb.Security{requires(map[string][]string{"key": nil, "other": nil})},
+	}
+	a, err := NewAuthenticator(card,
+		WithVerifier("key", VerifierFunc(func(context.Context, string) (*Principal, error) { return shared, nil })),
+		WithVerifier("other", VerifierFunc(func(context.Context, string) (*Principal, error) { return nil, nil })),
+	)
+	if err != nil {
+		t.Fatalf("NewAuthenticator() error = %v", err)
+	}
+	r := newRequest("/", map[string]string{"X-API-Key": "k1"})
+	r.AddCookie(&http.Cookie{Name: "session", Value: "s1"})
+	ctx, err := a.Authenticate(context.Background(), HTTPCredentials(r))
+	if err != nil {
+		t.Fatalf("Authenticate() error = %v", err)
+	}
+	if got, want := subjects(PrincipalsFromContext(ctx)), []string{"key:service", "other:"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("PrincipalsFromContext() = %v, want %v", got, want)
+	}
+	if shared.Scheme != "" {
+		t.Errorf("the principal returned by the verifier was modified: Scheme = %q", shared.Scheme)
+	}
+}
+
+func TestAuthenticateUnsupportedAPIKeyLocation(t *testing.T) {
+	card := &a2apb.AgentCard{
+		SecuritySchemes: map[string]*a2apb.SecurityScheme{"key": apiKeyScheme("body", "key")},
+		Security:        []*a2apb.Security{requires(map[string][]string{"key": nil})},
+	}
+	a, err := NewAuthenticator(card, newTestVerifiers()[0])
+	if err != nil {
+		t.Fatalf("NewAuthenticator() error = %v", err)
+	}
+	_, err = a.Authenticate(context.Background(), HTTPCredentials(newRequest("/", map[string]string{"key": "k1"})))
+	if !errors.Is(err, ErrUnauthenticated) || !strings.Contains(err.Error(), `unsupported API key location "body"`) {
+		t.Errorf("Authenticate() error = %v, want an unsupported location", err)
+	}
+}
+
+func TestAuthenticateRequest(t *testing.T) {
+	a := newTestAuthenticator(t)
+	if err := a.AuthenticateRequest(newRequest("/", map[string]string{"X-API-Key": "k1"})); err != nil {
+		t.Errorf("AuthenticateRequest() error = %v", err)
+	}
+	if err := a.AuthenticateRequest(newRequest("/", nil)); !errors.Is(err, ErrUnauthenticated) {
+		t.Errorf("AuthenticateRequest() error = %v, want %v", err, ErrUnauthenticated)
+	}
+}
+
+func TestAuthErrorStatus(t *testing.T) {
+	err := &authError{err: ErrUnauthenticated, reasons: []error{errors.New("a"), errors.New("b")}}
+	if got, want := err.Error(), "unauthenticated: a; b"; got != want {
+		t.Errorf("Error() = %q, want %q", got, want)
+	}
+	if got := status.Code(err); got != codes.Unauthenticated {
+		t.Errorf("status.Code() = %v, want %v", got, codes.Unauthenticated)
+	}
+}
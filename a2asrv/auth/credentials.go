@@ -0,0 +1,86 @@
+// Copyright 2025 The A2A Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package auth
+
+import (
+	"net/http"
+	"strings"
+
+	"google.golang.org/grpc/metadata"
+)
+
+// Credentials gives access to the credentials of a call independently of its transport.
+type Credentials interface {
+	// Header returns the first value of the header or metadata key.
+	Header(name string) string
+	// Query returns the value of the query parameter.
+	Query(name string) string
+	// Cookie returns the value of the cookie.
+	Cookie(name string) string
+}
+
+// HTTPCredentials returns the Credentials of an HTTP request.
+func HTTPCredentials(r *http.Request) Credentials {
+	return httpCredentials{r}
+}
+
+type httpCredentials struct {
+	r *http.Request
+}
+
+func (c httpCredentials) Header(name string) string {
+	return c.r.Header.Get(name)
+}
+
+func (c httpCredentials) Query(name string) string {
+	return c.r.URL.Query().Get(name)
+}
+
+func (c httpCredentials) Cookie(name string) string {
+	cookie, err := c.r.Cookie(name)
+	if err != nil {
+		return ""
+	}
+	return cookie.Value
+}
+
+// GRPCCredentials returns the Credentials of the incoming metadata of a gRPC call.
+// gRPC calls have no query parameters, and cookies are read from the cookie metadata.
+func GRPCCredentials(md metadata.MD) Credentials {
+	return grpcCredentials(md)
+}
+
+type grpcCredentials metadata.MD
+
+func (c grpcCredentials) Header(name string) string {
+	values := metadata.MD(c).Get(name)
+	if len(values) == 0 {
+		return ""
+	}
+	return values[0]
+}
+
+func (c grpcCredentials) Query(string) string {
+	return ""
+}
+
+func (c grpcCredentials) Cookie(name string) string {
+	header := http.Header{"Cookie": metadata.MD(c).Get("cookie")}
+	cookie, err := (&http.Request{Header: header}).Cookie(strings.TrimSpace(name))
+	if err != nil {
+		return ""
+	}
+	return cookie.Value
+}
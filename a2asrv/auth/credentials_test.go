@@ -0,0 +1,77 @@
+// Copyright 2025 The A2A Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package auth
+
+import (
+	"net/http"
+	"testing"
+
+	"google.golang.org/grpc/metadata"
+)
+
+func TestHTTPCredentials(t *testing.T) {
+	r := newRequest("/?tenant=acme", map[string]string{"X-API-Key": "k1"})
+	r.AddCookie(&http.Cookie{Name: "session", Value: "s1"})
+	creds := HTTPCredentials(r)
+	tests := []struct {
+		name string
+		get  func(string) string
+		key  string
+		want string
+	}{
+		{name: "header", get: creds.Header, key: "x-api-key", want: "k1"},
+		{name: "missing header", get: creds.Header, key: "Authorization"},
+		{name: "query", get: creds.Query, key: "tenant", want: "acme"},
+		{name: "missing query", get: creds.Query, key: "other"},
+		{name: "cookie", get: creds.Cookie, key: "session", want: "s1"},
+		{name: "missing cookie", get: creds.Cookie, key: "other"},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := tc.get(tc.key); got != tc.want {
+				t.Errorf("%s(%q) = %q, want %q", tc.name, tc.key, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestGRPCCredentials(t *testing.T) {
+	md := metadata.Pairs(
+		"x-api-key", "k1",
+		"x-api-key", "k2",
+		"cookie", "theme=dark; session=s1",
+		"tenant", "acme",
+	)
+	creds := GRPCCredentials(md)
+	tests := []struct {
+		name string
+		get  func(string) string
+		key  string
+		want string
+	}{
+		{name: "header", get: creds.Header, key: "X-API-Key", want: "k1"},
+		{name: "missing header", get: creds.Header, key: "authorization"},
+		{name: "query", get: creds.Query, key: "tenant"},
+		{name: "cookie", get: creds.Cookie, key: "session", want: "s1"},
+		{name: "missing cookie", get: creds.Cookie, key: "other"},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := tc.get(tc.key); got != tc.want {
+				t.Errorf("%s(%q) = %q, want %q", tc.name, tc.key, got, tc.want)
+			}
+		})
+	}
+}
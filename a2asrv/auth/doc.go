@@ -0,0 +1,44 @@
+// Copyright 2025 The A2A Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package auth enforces the security requirements declared by the AgentCard of an agent.
+//
+// An Authenticator reads the security schemes and requirements of the card. It extracts
+// the credentials of every scheme from the call, checks them with the Verifier
+// registered for the scheme and evaluates the requirements: the call must satisfy one
+// of the requirements, and a requirement is satisfied when the credentials of all its
+// schemes are valid and grant the required scopes. The authenticated principals are
+// stored in the context of the call and can be retrieved by the AgentExecutor with
+// FromContext:
+//
+//	authn, err := auth.NewAuthenticator(card,
+//		auth.WithVerifier("apiKey", auth.VerifierFunc(lookupAPIKey)),
+//		auth.WithVerifier("oauth", tokenVerifier))
+//	grpcServer := grpc.NewServer(
+//		grpc.UnaryInterceptor(authn.UnaryInterceptor()),
+//		grpc.StreamInterceptor(authn.StreamInterceptor()))
+//	http.Handle("/", authn.Middleware(jsonrpc.NewHandler(handler)))
+//
+// API keys are read from the header, query parameter or cookie named by their scheme.
+// The credentials of HTTP, OAuth2 and OpenID Connect schemes are read from the
+// Authorization header, the latter two using the Bearer scheme.
+//
+// OIDCVerifier validates the tokens of OpenID Connect schemes. It discovers the issuer
+// and the keys of the provider from the OpenIdConnectUrl of the scheme, checks the
+// signature, issuer, audience and validity period of tokens, and exposes their claims
+// in the Principal. WithOpenIDConnect configures it for the OpenID Connect schemes of
+// the card:
+//
+//	authn, err := auth.NewAuthenticator(card, auth.WithOpenIDConnect([]string{clientID}))
+package auth
@@ -0,0 +1,129 @@
+// Copyright 2025 The A2A Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package auth
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	a2apb "github.com/a2aproject/a2a-go/grpc"
+)
+
+// testStream is a grpc.ServerStream with a context.
+type testStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *testStream) Context() context.Context {
+	return s.ctx
+}
+
+func TestUnaryInterceptor(t *testing.T) {
+	interceptor := newTestAuthenticator(t).UnaryInterceptor()
+	tests := []struct {
+		name     string
+		method   string
+		md       metadata.MD
+		want     []string
+		wantCode codes.Code
+	}{
+		{name: "unauthenticated", method: a2apb.A2AService_SendMessage_FullMethodName, wantCode: codes.Unauthenticated},
+		{
+			name:   "API key",
+			method: a2apb.A2AService_SendMessage_FullMethodName,
+			md:     metadata.Pairs("x-api-key", "k1"),
+			want:   []string{"key:service"},
+		},
+		{
+			name:   "bearer token",
+			method: a2apb.A2AService_GetTask_FullMethodName,
+			md:     metadata.Pairs("authorization", "Bearer rw"),
+			want:   []string{"oauth:alice"},
+		},
+		{
+			name:     "insufficient scope",
+			method:   a2apb.A2AService_SendMessage_FullMethodName,
+			md:       metadata.Pairs("authorization", "Bearer ro"),
+			wantCode: codes.PermissionDenied,
+		},
+		{name: "agent card", method: a2apb.A2AService_GetAgentCard_FullMethodName},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := metadata.NewIncomingContext(context.Background(), tc.md)
+			called := false
+			handler := func(ctx context.Context, req any) (any, error) {
+				called = true
+				if got := subjects(PrincipalsFromContext(ctx)); !reflect.DeepEqual(got, tc.want) {
+					t.Errorf("PrincipalsFromContext() = %v, want %v", got, tc.want)
+				}
+				return req, nil
+			}
+			resp, err := interceptor(ctx, "request", &grpc.UnaryServerInfo{FullMethod: tc.method}, handler)
+			if got := status.Code(err); got != tc.wantCode {
+				t.Fatalf("interceptor() error = %v, want code %v", err, tc.wantCode)
+			}
+			if called != (tc.wantCode == codes.OK) {
+				t.Errorf("handler called = %v, want %v", called, tc.wantCode == codes.OK)
+			}
+			if tc.wantCode == codes.OK && resp != "request" {
+				t.Errorf("interceptor() = %v, want the response of the handler", resp)
+			}
+		})
+	}
+}
+
+func TestStreamInterceptor(t *testing.T) {
+	interceptor := newTestAuthenticator(t).StreamInterceptor()
+	info := &grpc.StreamServerInfo{FullMethod: a2apb.A2AService_SendStreamingMessage_FullMethodName, IsServerStream: true}
+	tests := []struct {
+		name    string
+		md      metadata.MD
+		want    []string
+		wantErr error
+	}{
+		{name: "unauthenticated", wantErr: ErrUnauthenticated},
+		{name: "API key", md: metadata.Pairs("x-api-key", "k1"), want: []string{"key:service"}},
+		{name: "insufficient scope", md: metadata.Pairs("authorization", "Bearer ro"), wantErr: ErrInsufficientScope},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			stream := &testStream{ctx: metadata.NewIncomingContext(context.Background(), tc.md)}
+			called := false
+			handler := func(_ any, ss grpc.ServerStream) error {
+				called = true
+				if got := subjects(PrincipalsFromContext(ss.Context())); !reflect.DeepEqual(got, tc.want) {
+					t.Errorf("PrincipalsFromContext() = %v, want %v", got, tc.want)
+				}
+				return nil
+			}
+			err := interceptor(nil, stream, info, handler)
+			if !errors.Is(err, tc.wantErr) {
+				t.Fatalf("interceptor() error = %v, want %v", err, tc.wantErr)
+			}
+			if called != (tc.wantErr == nil) {
+				t.Errorf("handler called = %v, want %v", called, tc.wantErr == nil)
+			}
+		})
+	}
+}
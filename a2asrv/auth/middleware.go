@@ -0,0 +1,41 @@
+// Copyright 2025 The A2A Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package auth
+
+import (
+	"errors"
+	"net/http"
+)
+
+// Middleware returns an HTTP handler authenticating requests before passing them to
+// next, typically the handler returned by jsonrpc.NewHandler. Requests which are not
+// authenticated are rejected with HTTP status 401 or 403.
+func (a *Authenticator) Middleware(next http.Handler) http.Handler {
+	challenge := a.challenge()
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx, err := a.Authenticate(r.Context(), HTTPCredentials(r))
+		if err != nil {
+			code := http.StatusUnauthorized
+			if errors.Is(err, ErrInsufficientScope) {
+				code = http.StatusForbidden
+			} else if challenge != "" {
+				w.Header().Set("WWW-Authenticate", challenge)
+			}
+			http.Error(w, http.StatusText(code), code)
+			return
+		}
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
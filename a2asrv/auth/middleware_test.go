@@ -0,0 +1,94 @@
+// Copyright 2025 The A2A Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package auth
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	a2apb "github.com/a2aproject/a2a-go/grpc"
+)
+
+func TestMiddleware(t *testing.T) {
+	a := newTestAuthenticator(t)
+	tests := []struct {
+		name          string
+		headers       map[string]string
+		wantStatus    int
+		wantChallenge string
+		want          []string
+	}{
+		{name: "unauthenticated", wantStatus: http.StatusUnauthorized, wantChallenge: "Bearer, Basic"},
+		{
+			name:          "invalid token",
+			headers:       map[string]string{"Authorization": "Bearer xx"},
+			wantStatus:    http.StatusUnauthorized,
+			wantChallenge: "Bearer, Basic",
+		},
+		{name: "insufficient scope", headers: map[string]string{"Authorization": "Bearer ro"}, wantStatus: http.StatusForbidden},
+		{
+			name:       "authenticated",
+			headers:    map[string]string{"Authorization": "Bearer rw"},
+			wantStatus: http.StatusOK,
+			want:       []string{"oauth:alice"},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			called := false
+			next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				called = true
+				if got := subjects(PrincipalsFromContext(r.Context())); !reflect.DeepEqual(got, tc.want) {
+					t.Errorf("PrincipalsFromContext() = %v, want %v", got, tc.want)
+				}
+			})
+			rec := httptest.NewRecorder()
+			a.Middleware(next).ServeHTTP(rec, newRequest("/", tc.headers))
+			if rec.Code != tc.wantStatus {
+				t.Errorf("status = %d, want %d", rec.Code, tc.wantStatus)
+			}
+			if got := rec.Header().Get("WWW-Authenticate"); got != tc.wantChallenge {
+				t.Errorf("WWW-Authenticate = %q, want %q", got, tc.wantChallenge)
+			}
+			if called != (tc.wantStatus == http.StatusOK) {
+				t.Errorf("next called = %v, want %v", called, tc.wantStatus == http.StatusOK)
+			}
+		})
+	}
+}
+
+func TestMiddlewareWithoutChallenge(t *testing.T) {
+	card := &a2apb.AgentCard{
+		SecuritySchemes: map[string]*a2apb.SecurityScheme{"key": apiKeyScheme("header", "X-API-Key")},
+		Security:        []*a2apb.Security{requires(map[string][]string{"key": nil})},
+	}
+	a, err := NewAuthenticator(card, WithVerifier("key", VerifierFunc(func(context.Context, string) (*Principal, error) {
+		return &Principal{}, nil
+	})))
+	if err != nil {
+		t.Fatalf("NewAuthenticator() error = %v", err)
+	}
+	rec := httptest.NewRecorder()
+	a.Middleware(http.NotFoundHandler()).ServeHTTP(rec, newRequest("/", nil))
+	if rec.Code != http.StatusUnauthorized {
+		t.Errorf("status = %d, want %d", rec.Code, http.StatusUnauthorized)
+	}
+	if got := rec.Header().Values("WWW-Authenticate"); got != nil {
+		t.Errorf("WWW-Authenticate = %q, want none", got)
+	}
+}
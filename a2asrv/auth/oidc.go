@@ -0,0 +1,350 @@
+// Copyright 2025 The A2A Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package auth
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/a2aproject/a2a-go/internal/jwt"
+)
+
+// Default settings of an OIDCVerifier.
+const (
+	DefaultClockSkew   = time.Minute
+	DefaultKeyCacheTTL = time.Hour
+)
+
+// discoverySuffix is the path of the discovery document relative to the issuer.
+const discoverySuffix = "/.well-known/openid-configuration"
+
+const (
+	// discoveryRetryInterval is how long a failed discovery is reported to callers
+	// before the discovery document is fetched again, so that an unavailable provider
+	// is not requested for every call.
+	discoveryRetryInterval = 5 * time.Second
+	// discoveryTimeout bounds the fetch of the discovery document, which is not
+	// cancelled with the call starting it.
+	discoveryTimeout = 30 * time.Second
+)
+
+// OIDCOption configures an OIDCVerifier.
+type OIDCOption func(*OIDCVerifier)
+
+// WithOIDCHTTPClient sets the client used to fetch the discovery document and the
+// keys of the provider. http.DefaultClient is used by default.
+func WithOIDCHTTPClient(client *http.Client) OIDCOption {
+	return func(v *OIDCVerifier) {
+		v.client = client
+	}
+}
+
+// WithClockSkew sets the tolerance applied to the expiry, not-before and issue times
+// of tokens. DefaultClockSkew is used by default.
+func WithClockSkew(skew time.Duration) OIDCOption {
+	return func(v *OIDCVerifier) {
+		v.skew = skew
+	}
+}
+
+// WithKeyCacheTTL sets how long the keys of the provider are cached before they are
+// fetched again. Tokens signed with unknown keys also make the verifier fetch the keys,
+// so rotated keys are picked up before the TTL expires. DefaultKeyCacheTTL is used by
+// default.
+func WithKeyCacheTTL(ttl time.Duration) OIDCOption {
+	return func(v *OIDCVerifier) {
+		v.keyTTL = ttl
+	}
+}
+
+// OIDCVerifier is a Verifier of the JWT access or ID tokens issued by an OpenID Connect
+// provider. It fetches the discovery document of the provider and the keys it
+// publishes, and accepts tokens signed by one of the keys which were issued by the
+// provider for one of the audiences and are not expired.
+//
+// The principal of a token has the subject of the token, the scopes of its "scope"
+// or "scp" claim, and all its claims.
+type OIDCVerifier struct {
+	discoveryURL string
+	audiences    []string
+	client       *http.Client
+	skew         time.Duration
+	keyTTL       time.Duration
+
+	mu     sync.Mutex
+	issuer string
+	keys   *jwt.RemoteKeySet
+	// discovery is the fetch of the discovery document in progress, if any.
+	discovery *discovery
+	// err is the error of the last failed discovery, which happened at failedAt.
+	err      error
+	failedAt time.Time
+}
+
+var _ Verifier = (*OIDCVerifier)(nil)
+
+// NewOIDCVerifier returns an OIDCVerifier for the provider with the discovery document
+// at the URL, which is the OpenIdConnectUrl of OpenID Connect security schemes. Tokens
+// must be issued for one of the audiences, usually the client ID of the agent.
+func NewOIDCVerifier(discoveryURL string, audiences []string, opts ...OIDCOption) *OIDCVerifier {
+	v := &OIDCVerifier{
+		discoveryURL: discoveryURL,
+		audiences:    audiences,
+		client:       http.DefaultClient,
+		skew:         DefaultClockSkew,
+		keyTTL:       DefaultKeyCacheTTL,
+	}
+	for _, opt := range opts {
+		opt(v)
+	}
+	return v
+}
+
+// WithOpenIDConnect registers an OIDCVerifier for every OpenID Connect scheme of the
+// card without a verifier, using the OpenIdConnectUrl of the scheme.
+func WithOpenIDConnect(audiences []string, opts ...OIDCOption) Option {
+	return func(a *Authenticator) {
+		for name, scheme := range a.schemes {
+			oidc := scheme.GetOpenIdConnectSecurityScheme()
+			if oidc == nil || a.verifiers[name] != nil {
+				continue
+			}
+			a.verifiers[name] = NewOIDCVerifier(oidc.GetOpenIdConnectUrl(), audiences, opts...)
+		}
+	}
+}
+
+// Verify implements Verifier.
+func (v *OIDCVerifier) Verify(ctx context.Context, credential string) (*Principal, error) {
+	issuer, keys, err := v.provider(ctx)
+	if err != nil {
+		return nil, err
+	}
+	token, err := jwt.Parse(credential)
+	if err != nil {
+		return nil, fmt.Errorf("invalid token: %w", err)
+	}
+	key, err := keys.Key(ctx, token.Header.Kid)
+	if err != nil {
+		return nil, fmt.Errorf("invalid token: %w", err)
+	}
+	if err := token.Verify(key); err != nil {
+		return nil, fmt.Errorf("invalid token: %w", err)
+	}
+
+	decoder := json.NewDecoder(strings.NewReader(string(token.Claims)))
+	decoder.UseNumber()
+	var claims map[string]any
+	if err := decoder.Decode(&claims); err != nil {
+		return nil, fmt.Errorf("invalid token claims: %w", err)
+	}
+	if err := v.validateClaims(claims, issuer); err != nil {
+		return nil, fmt.Errorf("invalid token: %w", err)
+	}
+	subject, _ := claims["sub"].(string)
+	return &Principal{Subject: subject, Scopes: scopes(claims), Claims: claims}, nil
+}
+
+func (v *OIDCVerifier) validateClaims(claims map[string]any, issuer string) error {
+	if iss, _ := claims["iss"].(string); iss != issuer {
+		return fmt.Errorf("issuer %q is not %q", iss, issuer)
+	}
+	var audiences []string
+	switch aud := claims["aud"].(type) {
+	case string:
+		audiences = []string{aud}
+	case []any:
+		for _, a := range aud {
+			if s, ok := a.(string); ok {
+				audiences = append(audiences, s)
+			}
+		}
+	}
+	if !slices.ContainsFunc(audiences, func(aud string) bool { return slices.Contains(v.audiences, aud) }) {
+		return errors.New("token is not issued for the agent audience")
+	}
+
+	now := time.Now()
+	exp, ok, err := numericDate(claims, "exp")
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return errors.New("missing exp claim")
+	}
+	if now.After(exp.Add(v.skew)) {
+		return fmt.Errorf("token expired at %s", exp.Format(time.RFC3339))
+	}
+	nbf, ok, err := numericDate(claims, "nbf")
+	if err != nil {
+		return err
+	}
+	if ok && now.Add(v.skew).Before(nbf) {
+		return fmt.Errorf("token is not valid before %s", nbf.Format(time.RFC3339))
+	}
+	iat, ok, err := numericDate(claims, "iat")
+	if err != nil {
+		return err
+	}
+	if ok && now.Add(v.skew).Before(iat) {
+		return fmt.Errorf("token is issued in the future at %s", iat.Format(time.RFC3339))
+	}
+	return nil
+}
+
+// provider returns the issuer and the keys of the provider, discovering them on first
+// use. Concurrent callers share a single fetch of the discovery document, which is
+// made without holding the lock of the verifier. A failure is returned to the callers
+// for discoveryRetryInterval before the document is fetched again.
+func (v *OIDCVerifier) provider(ctx context.Context) (string, *jwt.RemoteKeySet, error) {
+	v.mu.Lock()
+	if v.keys != nil {
+		defer v.mu.Unlock()
+		return v.issuer, v.keys, nil
+	}
+	if v.err != nil && time.Since(v.failedAt) < discoveryRetryInterval {
+		defer v.mu.Unlock()
+		return "", nil, v.err
+	}
+	d := v.discovery
+	if d == nil {
+		d = &discovery{done: make(chan struct{})}
+		v.discovery = d
+		// The fetch outlives the caller starting it, as other callers may wait for it.
+		fetchCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), discoveryTimeout)
+		go func() {
+			defer cancel()
+			v.discover(fetchCtx, d)
+		}()
+	}
+	v.mu.Unlock()
+
+	select {
+	case <-d.done:
+		return d.issuer, d.keys, d.err
+	case <-ctx.Done():
+		return "", nil, ctx.Err()
+	}
+}
+
+// discovery is a fetch of the discovery document shared by concurrent callers. Its
+// results are set before done is closed.
+type discovery struct {
+	done   chan struct{}
+	issuer string
+	keys   *jwt.RemoteKeySet
+	err    error
+}
+
+// discover fetches the discovery document and records the result in the verifier.
+func (v *OIDCVerifier) discover(ctx context.Context, d *discovery) {
+	d.issuer, d.keys, d.err = v.fetchDiscovery(ctx)
+	v.mu.Lock()
+	if d.err != nil {
+		v.err, v.failedAt = d.err, time.Now()
+	} else {
+		v.issuer, v.keys, v.err = d.issuer, d.keys, nil
+	}
+	v.discovery = nil
+	v.mu.Unlock()
+	close(d.done)
+}
+
+// fetchDiscovery fetches the discovery document and returns the issuer and the keys of
+// the provider.
+func (v *OIDCVerifier) fetchDiscovery(ctx context.Context) (string, *jwt.RemoteKeySet, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, v.discoveryURL, nil)
+	if err != nil {
+		return "", nil, err
+	}
+	req.Header.Set("Accept", "application/json")
+	resp, err := v.client.Do(req)
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to fetch OpenID Connect discovery document: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if resp.StatusCode != http.StatusOK {
+		return "", nil, fmt.Errorf("unexpected HTTP status %q fetching OpenID Connect discovery document from %s", resp.Status, v.discoveryURL)
+	}
+	var doc struct {
+		Issuer  string `json:"issuer"`
+		JWKSURI string `json:"jwks_uri"`
+	}
+	if err := json.NewDecoder(io.LimitReader(resp.Body, 1<<20)).Decode(&doc); err != nil {
+		return "", nil, fmt.Errorf("failed to decode OpenID Connect discovery document: %w", err)
+	}
+	if doc.Issuer == "" || doc.JWKSURI == "" {
+		return "", nil, errors.New("OpenID Connect discovery document must contain issuer and jwks_uri")
+	}
+	// The issuer must match the URL the document was retrieved from, which prevents a
+	// compromised document from impersonating another provider.
+	if strings.HasSuffix(v.discoveryURL, discoverySuffix) &&
+		strings.TrimSuffix(doc.Issuer, "/") != strings.TrimSuffix(strings.TrimSuffix(v.discoveryURL, discoverySuffix), "/") {
+		return "", nil, fmt.Errorf("OpenID Connect issuer %q does not match the discovery URL %s", doc.Issuer, v.discoveryURL)
+	}
+	return doc.Issuer, jwt.NewRemoteKeySet(doc.JWKSURI, v.client, v.keyTTL), nil
+}
+
+// maxNumericDate is the last second of year 9999, the latest date accepted in claims.
+const maxNumericDate = 253402300799
+
+// numericDate returns the JWT NumericDate claim with the name, decoded with UseNumber,
+// and whether it is present. It fails for claims which are not a number of seconds
+// between the Unix epoch and maxNumericDate.
+func numericDate(claims map[string]any, name string) (time.Time, bool, error) {
+	claim, ok := claims[name]
+	if !ok {
+		return time.Time{}, false, nil
+	}
+	n, ok := claim.(json.Number)
+	if !ok {
+		return time.Time{}, false, fmt.Errorf("%s claim is not a number", name)
+	}
+	seconds, err := n.Float64()
+	if err != nil || math.IsNaN(seconds) || seconds < 0 || seconds > maxNumericDate {
+		return time.Time{}, false, fmt.Errorf("%s claim %s is out of range", name, n)
+	}
+	sec, frac := math.Modf(seconds)
+	return time.Unix(int64(sec), int64(frac*float64(time.Second))), true, nil
+}
+
+// scopes returns the scopes of the token, which are listed in the space-separated
+// "scope" claim of RFC 8693 or in the "scp" array used by some providers.
+func scopes(claims map[string]any) []string {
+	if scope, ok := claims["scope"].(string); ok {
+		return strings.Fields(scope)
+	}
+	var result []string
+	switch scp := claims["scp"].(type) {
+	case string:
+		result = strings.Fields(scp)
+	case []any:
+		for _, s := range scp {
+			if s, ok := s.(string); ok {
+				result = append(result, s)
+			}
+		}
+	}
+	return result
+}
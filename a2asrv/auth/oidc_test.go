@@ -0,0 +1,419 @@
+// Copyright 2025 The A2A Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package auth
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	a2apb "github.com/a2aproject/a2a-go/grpc"
+	"github.com/a2aproject/a2a-go/internal/jwt"
+)
+
+const testAudience = "agent"
+
+// testProvider is an OpenID Connect provider publishing a discovery document and a
+// key set.
+type testProvider struct {
+	*httptest.Server
+	key crypto.Signer
+
+	mu sync.Mutex
+	// discovery is the discovery document, which points to the provider by default.
+	discovery map[string]string
+	// status is the HTTP status of the discovery document.
+	status int
+	// release blocks requests for the discovery document until it is closed, if set.
+	release     chan struct{}
+	discoveries int
+}
+
+func newTestProvider(t *testing.T) *testProvider {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	p := &testProvider{key: key, status: http.StatusOK}
+	p.Server = httptest.NewServer(http.HandlerFunc(p.handle))
+	t.Cleanup(p.Close)
+	p.discovery = map[string]string{"issuer": p.URL, "jwks_uri": p.URL + "/keys"}
+	return p
+}
+
+func (p *testProvider) handle(w http.ResponseWriter, r *http.Request) {
+	switch r.URL.Path {
+	case discoverySuffix:
+		p.mu.Lock()
+		p.discoveries++
+		release := p.release
+		p.mu.Unlock()
+		if release != nil {
+			<-release
+		}
+		p.mu.Lock()
+		defer p.mu.Unlock()
+		w.WriteHeader(p.status)
+		_ = json.NewEncoder(w).Encode(p.discovery)
+	case "/keys":
+		jwk, err := jwt.NewJWK("k1", p.key.Public())
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		_ = json.NewEncoder(w).Encode(jwt.JWKS{Keys: []jwt.JWK{jwk}})
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+func (p *testProvider) discoveryURL() string {
+	return p.URL + discoverySuffix
+}
+
+func (p *testProvider) discoveryCount() int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.discoveries
+}
+
+// claims returns the claims of a valid token issued by the provider.
+func (p *testProvider) claims() map[string]any {
+	now := time.Now().Unix()
+	return map[string]any{
+		"iss":   p.URL,
+		"aud":   []string{"other", testAudience},
+		"sub":   "alice",
+		"iat":   now,
+		"exp":   now + 300,
+		"scope": "tasks read",
+		"email": "alice@example.com",
+	}
+}
+
+// sign returns a token with the claims signed by the key with the ID.
+func (p *testProvider) sign(t *testing.T, kid string, key crypto.Signer, claims map[string]any) string {
+	t.Helper()
+	alg, err := jwt.Algorithm(key.Public())
+	if err != nil {
+		t.Fatal(err)
+	}
+	token, err := jwt.Sign(jwt.Header{Alg: alg, Kid: kid, Typ: "JWT"}, claims, key)
+	if err != nil {
+		t.Fatalf("Sign() error = %v", err)
+	}
+	return token
+}
+
+func TestOIDCVerifierVerify(t *testing.T) {
+	provider := newTestProvider(t)
+	otherKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	v := NewOIDCVerifier(provider.discoveryURL(), []string{testAudience}, WithClockSkew(30*time.Second))
+	now := time.Now().Unix()
+	tests := []struct {
+		name       string
+		kid        string
+		key        crypto.Signer
+		claims     func(map[string]any)
+		tamper     bool
+		wantScopes []string
+		wantErr    string
+	}{
+		{name: "valid", wantScopes: []string{"tasks", "read"}},
+		{name: "single audience", claims: func(c map[string]any) { c["aud"] = testAudience }, wantScopes: []string{"tasks", "read"}},
+		{
+			name: "scp claim",
+			claims: func(c map[string]any) {
+				delete(c, "scope")
+				c["scp"] = []string{"tasks"}
+			},
+			wantScopes: []string{"tasks"},
+		},
+		{name: "expired within the clock skew", claims: func(c map[string]any) { c["exp"] = now - 10 }, wantScopes: []string{"tasks", "read"}},
+		{name: "other audience", claims: func(c map[string]any) { c["aud"] = "other" }, wantErr: "not issued for the agent audience"},
+		{name: "no audience", claims: func(c map[string]any) { delete(c, "aud") }, wantErr: "not issued for the agent audience"},
+		{name: "other issuer", claims: func(c map[string]any) { c["iss"] = "https://attacker.example.com" }, wantErr: "issuer"},
+		{name: "expired", claims: func(c map[string]any) { c["exp"] = now - 120 }, wantErr: "token expired"},
+		{name: "no expiry", claims: func(c map[string]any) { delete(c, "exp") }, wantErr: "missing exp claim"},
+		{name: "not yet valid", claims: func(c map[string]any) { c["nbf"] = now + 600 }, wantErr: "not valid before"},
+		{name: "issued in the future", claims: func(c map[string]any) { c["iat"] = now + 600 }, wantErr: "issued in the future"},
+		// 1e13 seconds overflow a time.Duration in nanoseconds.
+		{name: "not valid before a far future date", claims: func(c map[string]any) { c["nbf"] = 1e13 }, wantErr: "nbf claim"},
+		{name: "expiry at a far future date", claims: func(c map[string]any) { c["exp"] = 1e13 }, wantErr: "exp claim"},
+		{name: "expiry which is not a number", claims: func(c map[string]any) { c["exp"] = "tomorrow" }, wantErr: "exp claim"},
+		{name: "unknown key", kid: "k2", wantErr: "unknown key"},
+		{name: "signed by another key", key: otherKey, wantErr: "invalid token"},
+		{name: "tampered signature", tamper: true, wantErr: "invalid token"},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			claims := provider.claims()
+			if tc.claims != nil {
+				tc.claims(claims)
+			}
+			kid, key := "k1", provider.key
+			if tc.kid != "" {
+				kid = tc.kid
+			}
+			if tc.key != nil {
+				key = tc.key
+			}
+			token := provider.sign(t, kid, key, claims)
+			if tc.tamper {
+				token = token[:len(token)-4] + "AAAA"
+			}
+			p, err := v.Verify(context.Background(), token)
+			if tc.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tc.wantErr) {
+					t.Errorf("Verify() error = %v, want %q", err, tc.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Verify() error = %v", err)
+			}
+			if p.Subject != "alice" {
+				t.Errorf("Subject = %q, want alice", p.Subject)
+			}
+			if !reflect.DeepEqual(p.Scopes, tc.wantScopes) {
+				t.Errorf("Scopes = %v, want %v", p.Scopes, tc.wantScopes)
+			}
+			if p.Claims["email"] != "alice@example.com" {
+				t.Errorf("Claims[email] = %v, want alice@example.com", p.Claims["email"])
+			}
+		})
+	}
+	if _, err := v.Verify(context.Background(), "not a token"); err == nil {
+		t.Error("Verify() of a malformed token succeeded")
+	}
+	if got := provider.discoveryCount(); got != 1 {
+		t.Errorf("discovery document fetched %d times, want 1", got)
+	}
+}
+
+func TestNumericDate(t *testing.T) {
+	tests := []struct {
+		name    string
+		claims  map[string]any
+		want    time.Time
+		wantOK  bool
+		wantErr bool
+	}{
+		{name: "seconds", claims: map[string]any{"exp": json.Number("1700000000")}, want: time.Unix(1700000000, 0), wantOK: true},
+		{name: "fraction", claims: map[string]any{"exp": json.Number("1700000000.25")}, want: time.Unix(1700000000, 250000000), wantOK: true},
+		{name: "end of year 9999", claims: map[string]any{"exp": json.Number("253402300799")}, want: time.Unix(253402300799, 0), wantOK: true},
+		{name: "missing", claims: map[string]any{}},
+		{name: "after year 9999", claims: map[string]any{"exp": json.Number("253402300800")}, wantErr: true},
+		{name: "overflowing duration", claims: map[string]any{"exp": json.Number("1e13")}, wantErr: true},
+		{name: "overflowing float", claims: map[string]any{"exp": json.Number("1e400")}, wantErr: true},
+		{name: "negative", claims: map[string]any{"exp": json.Number("-1")}, wantErr: true},
+		{name: "string", claims: map[string]any{"exp": "1700000000"}, wantErr: true},
+		{name: "null", claims: map[string]any{"exp": nil}, wantErr: true},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got, ok, err := numericDate(tc.claims, "exp")
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("numericDate() error = %v, want error: %v", err, tc.wantErr)
+			}
+			if ok != tc.wantOK || !got.Equal(tc.want) {
+				t.Errorf("numericDate() = %v, %v, want %v, %v", got, ok, tc.want, tc.wantOK)
+			}
+		})
+	}
+}
+
+func TestOIDCVerifierDiscoveryErrors(t *testing.T) {
+	tests := []struct {
+		name      string
+		status    int
+		discovery func(p *testProvider) map[string]string
+		wantErr   string
+	}{
+		{name: "HTTP error", status: http.StatusInternalServerError, wantErr: "unexpected HTTP status"},
+		{
+			name:      "missing key set",
+			discovery: func(p *testProvider) map[string]string { return map[string]string{"issuer": p.URL} },
+			wantErr:   "must contain issuer and jwks_uri",
+		},
+		{
+			name: "other issuer",
+			discovery: func(p *testProvider) map[string]string {
+				return map[string]string{"issuer": "https://attacker.example.com", "jwks_uri": p.URL + "/keys"}
+			},
+			wantErr: "does not match the discovery URL",
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			provider := newTestProvider(t)
+			if tc.status != 0 {
+				provider.status = tc.status
+			}
+			if tc.discovery != nil {
+				provider.discovery = tc.discovery(provider)
+			}
+			v := NewOIDCVerifier(provider.discoveryURL(), []string{testAudience})
+			_, err := v.Verify(context.Background(), provider.sign(t, "k1", provider.key, provider.claims()))
+			if err == nil || !strings.Contains(err.Error(), tc.wantErr) {
+				t.Errorf("Verify() error = %v, want %q", err, tc.wantErr)
+			}
+		})
+	}
+}
+
+func TestOIDCVerifierRetriesFailedDiscovery(t *testing.T) {
+	provider := newTestProvider(t)
+	provider.status = http.StatusServiceUnavailable
+	v := NewOIDCVerifier(provider.discoveryURL(), []string{testAudience})
+	token := provider.sign(t, "k1", provider.key, provider.claims())
+	for range 3 {
+		if _, err := v.Verify(context.Background(), token); err == nil {
+			t.Fatal("Verify() succeeded with an unavailable provider")
+		}
+	}
+	if got := provider.discoveryCount(); got != 1 {
+		t.Errorf("discovery document fetched %d times within the retry interval, want 1", got)
+	}
+
+	provider.mu.Lock()
+	provider.status = http.StatusOK
+	provider.mu.Unlock()
+	v.mu.Lock()
+	v.failedAt = v.failedAt.Add(-discoveryRetryInterval)
+	v.mu.Unlock()
+	if _, err := v.Verify(context.Background(), token); err != nil {
+		t.Fatalf("Verify() error = %v after the retry interval", err)
+	}
+	if got := provider.discoveryCount(); got != 2 {
+		t.Errorf("discovery document fetched %d times, want 2", got)
+	}
+}
+
+func TestOIDCVerifierSharesDiscovery(t *testing.T) {
+	provider := newTestProvider(t)
+	release := make(chan struct{})
+	provider.release = release
+	v := NewOIDCVerifier(provider.discoveryURL(), []string{testAudience})
+	token := provider.sign(t, "k1", provider.key, provider.claims())
+
+	var wg sync.WaitGroup
+	for range 10 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := v.Verify(context.Background(), token); err != nil {
+				t.Errorf("Verify() error = %v", err)
+			}
+		}()
+	}
+	for provider.discoveryCount() == 0 {
+		time.Sleep(time.Millisecond)
+	}
+
+	// Callers waiting for the discovery give up when their context is done.
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if _, err := v.Verify(ctx, token); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Verify() error = %v, want %v", err, context.DeadlineExceeded)
+	}
+
+	close(release)
+	wg.Wait()
+	if got := provider.discoveryCount(); got != 1 {
+		t.Errorf("discovery document fetched %d times, want 1", got)
+	}
+}
+
+func TestOIDCVerifierDiscoveryOutlivesCaller(t *testing.T) {
+	provider := newTestProvider(t)
+	release := make(chan struct{})
+	provider.release = release
+	v := NewOIDCVerifier(provider.discoveryURL(), []string{testAudience})
+	token := provider.sign(t, "k1", provider.key, provider.claims())
+
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		for provider.discoveryCount() == 0 {
+			time.Sleep(time.Millisecond)
+		}
+		cancel()
+	}()
+	if _, err := v.Verify(ctx, token); !errors.Is(err, context.Canceled) {
+		t.Errorf("Verify() error = %v, want %v", err, context.Canceled)
+	}
+	close(release)
+	// The cancelled caller does not fail the discovery for the next callers.
+	if _, err := v.Verify(context.Background(), token); err != nil {
+		t.Errorf("Verify() error = %v", err)
+	}
+	if got := provider.discoveryCount(); got != 1 {
+		t.Errorf("discovery document fetched %d times, want 1", got)
+	}
+}
+
+func TestWithOpenIDConnect(t *testing.T) {
+	provider := newTestProvider(t)
+	card := &a2apb.AgentCard{
+		SecuritySchemes: map[string]*a2apb.SecurityScheme{
+			"oidc": {Scheme: &a2apb.SecurityScheme_OpenIdConnectSecurityScheme{
+				OpenIdConnectSecurityScheme: &a2apb.OpenIdConnectSecurityScheme{OpenIdConnectUrl: provider.discoveryURL()},
+			}},
+			"key": apiKeyScheme("header", "X-API-Key"),
+		},
+		Security: []*a2apb.Security{
+			requires(map[string][]string{"oidc": {"tasks"}}),
+			requires(map[string][]string{"key": nil}),
+		},
+	}
+	a, err := NewAuthenticator(card, newTestVerifiers()[0], WithOpenIDConnect([]string{testAudience}))
+	if err != nil {
+		t.Fatalf("NewAuthenticator() error = %v", err)
+	}
+	if _, ok := a.verifiers["key"].(*OIDCVerifier); ok {
+		t.Error("WithOpenIDConnect() replaced the verifier of an API key scheme")
+	}
+
+	token := provider.sign(t, "k1", provider.key, provider.claims())
+	ctx, err := a.Authenticate(context.Background(), HTTPCredentials(newRequest("/", map[string]string{"Authorization": "Bearer " + token})))
+	if err != nil {
+		t.Fatalf("Authenticate() error = %v", err)
+	}
+	if got, want := subjects(PrincipalsFromContext(ctx)), []string{"oidc:alice"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("PrincipalsFromContext() = %v, want %v", got, want)
+	}
+
+	claims := provider.claims()
+	claims["scope"] = "read"
+	_, err = a.Authenticate(context.Background(), HTTPCredentials(newRequest("/", map[string]string{"Authorization": "Bearer " + provider.sign(t, "k1", provider.key, claims)})))
+	if !errors.Is(err, ErrInsufficientScope) {
+		t.Errorf("Authenticate() error = %v, want %v", err, ErrInsufficientScope)
+	}
+}
@@ -0,0 +1,104 @@
+// Copyright 2025 The A2A Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package auth
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"slices"
+	"strings"
+)
+
+// Principal is the authenticated caller of an agent.
+type Principal struct {
+	// Subject identifies the caller, for example the subject of a token or the owner
+	// of an API key.
+	Subject string
+	// Scheme is the name of the security scheme of the AgentCard which authenticated
+	// the caller. It is set by the Authenticator.
+	Scheme string
+	// Scopes are the scopes granted to the credentials of the caller.
+	Scopes []string
+	// Claims are additional attributes of the caller provided by the verifier,
+	// for example the claims of a token.
+	Claims map[string]any
+}
+
+// HasScopes reports whether all the scopes were granted to the principal.
+func (p *Principal) HasScopes(scopes ...string) bool {
+	for _, scope := range scopes {
+		if !slices.Contains(p.Scopes, scope) {
+			return false
+		}
+	}
+	return true
+}
+
+type principalsKey struct{}
+
+// NewContext returns a context carrying the principals authenticated for a call.
+func NewContext(ctx context.Context, principals ...*Principal) context.Context {
+	return context.WithValue(ctx, principalsKey{}, principals)
+}
+
+// FromContext returns the principal authenticated by the first scheme of the security
+// requirement satisfied by the call. It returns false for unauthenticated calls.
+func FromContext(ctx context.Context) (*Principal, bool) {
+	principals := PrincipalsFromContext(ctx)
+	if len(principals) == 0 {
+		return nil, false
+	}
+	return principals[0], true
+}
+
+// PrincipalsFromContext returns the principals authenticated by every scheme of the
+// security requirement satisfied by the call, ordered by scheme name.
+func PrincipalsFromContext(ctx context.Context) []*Principal {
+	principals, _ := ctx.Value(principalsKey{}).([]*Principal)
+	return principals
+}
+
+// Verifier validates the credential presented for a security scheme and returns the
+// principal it belongs to. The credential is the API key for API key schemes, and the
+// value of the Authorization header following the scheme name for the other schemes,
+// such as the token of Bearer, OAuth2 and OpenID Connect schemes.
+type Verifier interface {
+	Verify(ctx context.Context, credential string) (*Principal, error)
+}
+
+// VerifierFunc is a function implementing Verifier.
+type VerifierFunc func(ctx context.Context, credential string) (*Principal, error)
+
+// Verify implements Verifier.
+func (f VerifierFunc) Verify(ctx context.Context, credential string) (*Principal, error) {
+	return f(ctx, credential)
+}
+
+// BasicVerifier returns a Verifier for HTTP Basic schemes, which decodes the
+// credential and checks the user name and password with the function.
+func BasicVerifier(check func(ctx context.Context, username, password string) (*Principal, error)) Verifier {
+	return VerifierFunc(func(ctx context.Context, credential string) (*Principal, error) {
+		decoded, err := base64.StdEncoding.DecodeString(credential)
+		if err != nil {
+			return nil, errors.New("malformed basic credentials")
+		}
+		username, password, ok := strings.Cut(string(decoded), ":")
+		if !ok {
+			return nil, errors.New("malformed basic credentials")
+		}
+		return check(ctx, username, password)
+	})
+}
@@ -0,0 +1,94 @@
+// Copyright 2025 The A2A Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package auth
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"testing"
+)
+
+func TestPrincipalHasScopes(t *testing.T) {
+	p := &Principal{Scopes: []string{"read", "write"}}
+	tests := []struct {
+		name   string
+		scopes []string
+		want   bool
+	}{
+		{name: "no scopes", want: true},
+		{name: "granted scope", scopes: []string{"read"}, want: true},
+		{name: "all granted scopes", scopes: []string{"write", "read"}, want: true},
+		{name: "missing scope", scopes: []string{"read", "admin"}},
+		{name: "scope prefix", scopes: []string{"rea"}},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := p.HasScopes(tc.scopes...); got != tc.want {
+				t.Errorf("HasScopes(%v) = %v, want %v", tc.scopes, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestPrincipalContext(t *testing.T) {
+	if p, ok := FromContext(context.Background()); ok {
+		t.Errorf("FromContext() = %+v, want no principal", p)
+	}
+	if got := PrincipalsFromContext(context.Background()); got != nil {
+		t.Errorf("PrincipalsFromContext() = %v, want nil", got)
+	}
+
+	first, second := &Principal{Subject: "a"}, &Principal{Subject: "b"}
+	ctx := NewContext(context.Background(), first, second)
+	if p, ok := FromContext(ctx); !ok || p != first {
+		t.Errorf("FromContext() = %+v, %v, want %+v", p, ok, first)
+	}
+	if got := PrincipalsFromContext(ctx); len(got) != 2 || got[0] != first || got[1] != second {
+		t.Errorf("PrincipalsFromContext() = %v, want [%v %v]", got, first, second)
+	}
+}
+
+func TestBasicVerifier(t *testing.T) {
+	verifier := BasicVerifier(func(_ context.Context, username, password string) (*Principal, error) {
+		if password != "se:cret" {
+			return nil, errors.New("wrong password")
+		}
+		return &Principal{Subject: username}, nil
+	})
+	encode := func(s string) string { return base64.StdEncoding.EncodeToString([]byte(s)) }
+	tests := []struct {
+		name       string
+		credential string
+		want       string
+		wantErr    bool
+	}{
+		{name: "valid", credential: encode("alice:se:cret"), want: "alice"},
+		{name: "wrong password", credential: encode("alice:secret"), wantErr: true},
+		{name: "no separator", credential: encode("alice"), wantErr: true},
+		{name: "not base64", credential: "alice:se:cret", wantErr: true},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			p, err := verifier.Verify(context.Background(), tc.credential)
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("Verify() error = %v, want error: %v", err, tc.wantErr)
+			}
+			if err == nil && p.Subject != tc.want {
+				t.Errorf("Verify() subject = %q, want %q", p.Subject, tc.want)
+			}
+		})
+	}
+}
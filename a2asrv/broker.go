@@ -0,0 +1,146 @@
+// Copyright 2025 The A2A Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package a2asrv
+
+import (
+	"context"
+	"errors"
+	"sync"
+)
+
+// Broker transports messages between the EventBus instances of several server
+// replicas, so that a TaskSubscription served by one replica receives the events
+// of a task executed by another one. Topics are task IDs.
+type Broker interface {
+	// Publish sends the payload to all the current subscribers of the topic.
+	Publish(ctx context.Context, topic string, payload []byte) error
+
+	// Subscribe starts receiving the payloads published to the topic after Subscribe
+	// returns. Delivery is at most once: implementations backed by a message server,
+	// like Redis Pub/Sub, lose the payloads published while the subscription is
+	// disconnected, in which case they close the subscription.
+	Subscribe(ctx context.Context, topic string) (BrokerSubscription, error)
+}
+
+// BrokerSubscription receives the payloads published to a topic of a Broker.
+type BrokerSubscription interface {
+	// Messages returns the channel of the payloads. It is closed when the
+	// subscription is closed or broken.
+	Messages() <-chan []byte
+
+	// Close stops the subscription.
+	Close() error
+}
+
+// errBrokerClosed is returned when publishing to a closed subscription of MemoryBroker.
+var errBrokerClosed = errors.New("broker subscription closed")
+
+// MemoryBroker is a Broker which delivers messages within the process. It allows
+// running several handlers with their own event buses in a single process, for
+// example in tests of a multi-replica setup.
+type MemoryBroker struct {
+	mu     sync.Mutex
+	topics map[string]map[*memoryBrokerSubscription]struct{}
+}
+
+var _ Broker = (*MemoryBroker)(nil)
+
+// NewMemoryBroker returns a MemoryBroker without subscribers.
+func NewMemoryBroker() *MemoryBroker {
+	return &MemoryBroker{topics: make(map[string]map[*memoryBrokerSubscription]struct{})}
+}
+
+// Publish implements Broker. It waits until every subscriber receives the payload.
+func (b *MemoryBroker) Publish(ctx context.Context, topic string, payload []byte) error {
+	b.mu.Lock()
+	subs := make([]*memoryBrokerSubscription, 0, len(b.topics[topic]))
+	for sub := range b.topics[topic] {
+		subs = append(subs, sub)
+	}
+	b.mu.Unlock()
+	for _, sub := range subs {
+		if err := sub.send(ctx, payload); err != nil && !errors.Is(err, errBrokerClosed) {
+			return err
+		}
+	}
+	return nil
+}
+
+// Subscribe implements Broker.
+func (b *MemoryBroker) Subscribe(_ context.Context, topic string) (BrokerSubscription, error) {
+	sub := &memoryBrokerSubscription{
+		broker:   b,
+		topic:    topic,
+		messages: make(chan []byte),
+		done:     make(chan struct{}),
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.topics[topic] == nil {
+		b.topics[topic] = make(map[*memoryBrokerSubscription]struct{})
+	}
+	b.topics[topic][sub] = struct{}{}
+	return sub, nil
+}
+
+type memoryBrokerSubscription struct {
+	broker   *MemoryBroker
+	topic    string
+	messages chan []byte
+	done     chan struct{}
+	once     sync.Once
+
+	sendMu sync.Mutex
+	closed bool
+}
+
+func (s *memoryBrokerSubscription) Messages() <-chan []byte {
+	return s.messages
+}
+
+func (s *memoryBrokerSubscription) Close() error {
+	s.once.Do(func() {
+		close(s.done)
+		s.broker.mu.Lock()
+		subs := s.broker.topics[s.topic]
+		delete(subs, s)
+		if len(subs) == 0 {
+			delete(s.broker.topics, s.topic)
+		}
+		s.broker.mu.Unlock()
+
+		s.sendMu.Lock()
+		s.closed = true
+		close(s.messages)
+		s.sendMu.Unlock()
+	})
+	return nil
+}
+
+func (s *memoryBrokerSubscription) send(ctx context.Context, payload []byte) error {
+	s.sendMu.Lock()
+	defer s.sendMu.Unlock()
+	if s.closed {
+		return errBrokerClosed
+	}
+	select {
+	case s.messages <- append([]byte(nil), payload...):
+		return nil
+	case <-s.done:
+		return errBrokerClosed
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
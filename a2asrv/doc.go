@@ -0,0 +1,44 @@
+// Copyright 2025 The A2A Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package a2asrv provides the building blocks for serving A2A agents.
+//
+// NewHandler implements the A2A service on top of an AgentExecutor, which only
+// contains the agent logic. The handler manages the task lifecycle: it creates and
+// stores tasks, applies the events written by the executor, streams them to clients
+// and handles cancellation. Tasks are persisted in a TaskStore, which is an
+// InMemoryTaskStore unless configured with WithTaskStore. Events are delivered to
+// the streams of a task through an EventBus, which buffers them for every subscriber:
+//
+//	srv := a2asrv.NewHandler(executor, a2asrv.WithAgentCard(card))
+//	a2apb.RegisterA2AServiceServer(grpcServer, srv)
+//	http.Handle("/", jsonrpc.NewHandler(srv))
+//
+// Executors report progress by writing events to the EventQueue, typically through
+// a TaskUpdater which fills in the IDs and timestamps of the events.
+//
+// Push notifications are enabled with WithPushNotifier, for example using the
+// Sender of the push package. The push notification configs registered by clients
+// are kept in a PushConfigStore, which is an InMemoryPushConfigStore unless
+// configured with WithPushConfigStore.
+//
+// When several replicas serve the same agent, an EventBus connected to a Broker
+// delivers the events of a task to the subscribers of every replica. The replicas
+// must share the TaskStore too. See the sqlstore and redisbus packages.
+//
+// AgentCardHandler publishes the AgentCard of an agent at the well-known path and
+// serves the authenticated extended card to clients which pass authentication.
+// The auth package enforces the security requirements declared by the card on the
+// gRPC and JSON-RPC transports.
+package a2asrv
@@ -0,0 +1,354 @@
+// Copyright 2025 The A2A Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package a2asrv
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"sync"
+
+	"google.golang.org/protobuf/proto"
+
+	a2apb "github.com/a2aproject/a2a-go/grpc"
+	"github.com/a2aproject/a2a-go/internal/uuid"
+)
+
+// BackpressurePolicy defines what EventBus.Publish does when the buffer of
+// a subscriber is full.
+type BackpressurePolicy int
+
+const (
+	// BackpressureBlock makes Publish wait until the subscriber receives an event,
+	// unsubscribes or the context of Publish is canceled.
+	BackpressureBlock BackpressurePolicy = iota
+	// BackpressureDropOldest makes Publish discard the oldest buffered event of the
+	// subscriber to make room for the new one.
+	BackpressureDropOldest
+)
+
+// DefaultSubscriberBuffer is the number of events buffered for each subscriber
+// by default.
+const DefaultSubscriberBuffer = 16
+
+// EventBusOption configures an EventBus.
+type EventBusOption func(*EventBus)
+
+// WithSubscriberBuffer sets the number of events buffered for each subscriber.
+func WithSubscriberBuffer(size int) EventBusOption {
+	return func(b *EventBus) {
+		b.bufferSize = size
+	}
+}
+
+// WithBackpressure sets the policy applied when a subscriber does not keep up
+// with the published events. The default is BackpressureBlock.
+func WithBackpressure(policy BackpressurePolicy) EventBusOption {
+	return func(b *EventBus) {
+		b.policy = policy
+	}
+}
+
+// WithBroker connects the bus to the buses of other server replicas through the broker.
+// Published events are forwarded to the broker, and every subscription also receives
+// the events published by the other replicas.
+func WithBroker(broker Broker) EventBusOption {
+	return func(b *EventBus) {
+		b.broker = broker
+	}
+}
+
+// EventBus delivers the events of a task to all of its subscribers. Without a Broker
+// the delivery is limited to the process.
+// The subscriptions of a task are closed after a final event is published for it:
+// a TaskStatusUpdateEvent with Final set or a Message.
+type EventBus struct {
+	bufferSize int
+	policy     BackpressurePolicy
+	broker     Broker
+	// id identifies the bus in the messages sent to the broker, so that it can
+	// skip its own events.
+	id string
+
+	mu     sync.Mutex
+	topics map[string]map[*Subscription]struct{}
+}
+
+// NewEventBus returns an EventBus without subscribers.
+func NewEventBus(opts ...EventBusOption) *EventBus {
+	b := &EventBus{
+		bufferSize: DefaultSubscriberBuffer,
+		id:         uuid.New(),
+		topics:     make(map[string]map[*Subscription]struct{}),
+	}
+	for _, opt := range opts {
+		opt(b)
+	}
+	if b.bufferSize < 0 || (b.policy == BackpressureDropOldest && b.bufferSize == 0) {
+		// Dropping the oldest event requires a buffer to drop it from.
+		b.bufferSize = 1
+	}
+	return b
+}
+
+// Distributed reports whether the bus is connected to a Broker.
+func (b *EventBus) Distributed() bool {
+	return b.broker != nil
+}
+
+// Subscribe returns a subscription to the events published for the task from now on.
+func (b *EventBus) Subscribe(ctx context.Context, taskID string) (*Subscription, error) {
+	sub := &Subscription{
+		bus:    b,
+		taskID: taskID,
+		events: make(chan *a2apb.StreamResponse, b.bufferSize),
+		done:   make(chan struct{}),
+	}
+	if b.broker != nil {
+		remote, err := b.broker.Subscribe(ctx, taskID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to subscribe to broker: %w", err)
+		}
+		sub.remote = remote
+		go b.receive(sub)
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	subs, ok := b.topics[taskID]
+	if !ok {
+		subs = make(map[*Subscription]struct{})
+		b.topics[taskID] = subs
+	}
+	subs[sub] = struct{}{}
+	return sub, nil
+}
+
+// Publish delivers the event to the subscribers of the task according to the
+// backpressure policy, and forwards it to the broker. If the event is final, the
+// subscriptions are closed after the event is delivered. The local subscribers
+// receive the event even if forwarding it to the broker fails.
+func (b *EventBus) Publish(ctx context.Context, taskID string, event *a2apb.StreamResponse) error {
+	final := isClosingEvent(event)
+	subs := b.subscribers(taskID, final)
+	var err error
+	for _, sub := range subs {
+		if err == nil {
+			err = sub.deliver(ctx, event, b.policy)
+		}
+		if final {
+			sub.close()
+		}
+	}
+	if err != nil || b.broker == nil {
+		return err
+	}
+	payload, err := b.encode(envelopeEvent, event)
+	if err != nil {
+		return err
+	}
+	if err := b.broker.Publish(ctx, taskID, payload); err != nil {
+		return fmt.Errorf("failed to publish to broker: %w", err)
+	}
+	return nil
+}
+
+// Close closes all the subscriptions of the task, including the ones of other
+// replicas. It is used when the task processing ends without a final event.
+func (b *EventBus) Close(taskID string) {
+	for _, sub := range b.subscribers(taskID, true) {
+		sub.close()
+	}
+	if b.broker == nil {
+		return
+	}
+	if payload, err := b.encode(envelopeClose, nil); err == nil {
+		_ = b.broker.Publish(context.Background(), taskID, payload)
+	}
+}
+
+// receive delivers the events published by other replicas to the subscription.
+func (b *EventBus) receive(sub *Subscription) {
+	defer sub.close()
+	for payload := range sub.remote.Messages() {
+		kind, origin, event, err := b.decode(payload)
+		if err != nil || origin == b.id {
+			continue
+		}
+		if kind == envelopeClose {
+			return
+		}
+		if err := sub.deliver(context.Background(), event, b.policy); err != nil {
+			return
+		}
+		if isClosingEvent(event) {
+			return
+		}
+	}
+}
+
+// Kinds of the messages exchanged through the broker.
+const (
+	envelopeEvent byte = 1
+	envelopeClose byte = 2
+)
+
+var errInvalidEnvelope = errors.New("invalid broker message")
+
+// encode serializes a broker message: the kind, the length-prefixed ID of the bus
+// and the serialized event.
+func (b *EventBus) encode(kind byte, event *a2apb.StreamResponse) ([]byte, error) {
+	payload := []byte{kind}
+	payload = binary.AppendUvarint(payload, uint64(len(b.id)))
+	payload = append(payload, b.id...)
+	if event == nil {
+		return payload, nil
+	}
+	payload, err := proto.MarshalOptions{}.MarshalAppend(payload, event)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode event: %w", err)
+	}
+	return payload, nil
+}
+
+func (b *EventBus) decode(payload []byte) (kind byte, origin string, event *a2apb.StreamResponse, err error) {
+	if len(payload) == 0 {
+		return 0, "", nil, errInvalidEnvelope
+	}
+	kind, payload = payload[0], payload[1:]
+	n, size := binary.Uvarint(payload)
+	if size <= 0 || uint64(len(payload)-size) < n {
+		return 0, "", nil, errInvalidEnvelope
+	}
+	origin, payload = string(payload[size:size+int(n)]), payload[size+int(n):]
+	switch kind {
+	case envelopeClose:
+		return kind, origin, nil, nil
+	case envelopeEvent:
+		event = &a2apb.StreamResponse{}
+		if err := proto.Unmarshal(payload, event); err != nil {
+			return 0, "", nil, fmt.Errorf("%w: %w", errInvalidEnvelope, err)
+		}
+		return kind, origin, event, nil
+	default:
+		return 0, "", nil, errInvalidEnvelope
+	}
+}
+
+// isClosingEvent reports whether the event closes the subscriptions of the task.
+func isClosingEvent(event *a2apb.StreamResponse) bool {
+	return event.GetMsg() != nil || event.GetStatusUpdate().GetFinal()
+}
+
+// subscribers returns the current subscribers of the task. If remove is set,
+// they are unregistered from the bus.
+func (b *EventBus) subscribers(taskID string, remove bool) []*Subscription {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	subs := make([]*Subscription, 0, len(b.topics[taskID]))
+	for sub := range b.topics[taskID] {
+		subs = append(subs, sub)
+	}
+	if remove {
+		delete(b.topics, taskID)
+	}
+	return subs
+}
+
+func (b *EventBus) unsubscribe(sub *Subscription) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	subs := b.topics[sub.taskID]
+	delete(subs, sub)
+	if len(subs) == 0 {
+		delete(b.topics, sub.taskID)
+	}
+}
+
+// Subscription receives the events of a task published on an EventBus.
+type Subscription struct {
+	bus       *EventBus
+	taskID    string
+	events    chan *a2apb.StreamResponse
+	done      chan struct{}
+	closeOnce sync.Once
+	// remote receives the events of other replicas when the bus has a broker.
+	remote BrokerSubscription
+
+	// sendMu serializes the deliveries with the closing of the events channel.
+	sendMu sync.Mutex
+	closed bool
+}
+
+// Events returns the channel of the events. It is closed after a final event
+// or when the task processing ends.
+func (s *Subscription) Events() <-chan *a2apb.StreamResponse {
+	return s.events
+}
+
+// Close stops the delivery of events to the subscription. The subscriber must not
+// expect the events channel to be closed afterwards.
+func (s *Subscription) Close() {
+	s.closeOnce.Do(func() {
+		close(s.done)
+		s.bus.unsubscribe(s)
+		if s.remote != nil {
+			_ = s.remote.Close()
+		}
+	})
+}
+
+func (s *Subscription) deliver(ctx context.Context, event *a2apb.StreamResponse, policy BackpressurePolicy) error {
+	s.sendMu.Lock()
+	defer s.sendMu.Unlock()
+	if s.closed {
+		return nil
+	}
+	if policy == BackpressureDropOldest {
+		for {
+			select {
+			case s.events <- event:
+				return nil
+			case <-s.done:
+				return nil
+			default:
+			}
+			select {
+			case <-s.events:
+			default:
+			}
+		}
+	}
+	select {
+	case s.events <- event:
+		return nil
+	case <-s.done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (s *Subscription) close() {
+	s.sendMu.Lock()
+	defer s.sendMu.Unlock()
+	if !s.closed {
+		s.closed = true
+		close(s.events)
+		if s.remote != nil {
+			_ = s.remote.Close()
+		}
+	}
+}
@@ -0,0 +1,259 @@
+// Copyright 2025 The A2A Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package a2asrv
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"google.golang.org/protobuf/proto"
+
+	a2apb "github.com/a2aproject/a2a-go/grpc"
+)
+
+var testTask = &RequestContext{TaskID: "t1", ContextID: "c1"}
+
+func subscribe(t *testing.T, bus *EventBus, taskID string) *Subscription {
+	t.Helper()
+	sub, err := bus.Subscribe(context.Background(), taskID)
+	if err != nil {
+		t.Fatalf("Subscribe() error = %v", err)
+	}
+	return sub
+}
+
+// receiveAll reads the events of the subscription until its channel is closed.
+func receiveAll(t *testing.T, sub *Subscription) []*a2apb.StreamResponse {
+	t.Helper()
+	var events []*a2apb.StreamResponse
+	timeout := time.After(5 * time.Second)
+	for {
+		select {
+		case event, ok := <-sub.Events():
+			if !ok {
+				return events
+			}
+			events = append(events, event)
+		case <-timeout:
+			t.Fatalf("the subscription was not closed, received %v", events)
+		}
+	}
+}
+
+func assertEvents(t *testing.T, got, want []*a2apb.StreamResponse) {
+	t.Helper()
+	if len(got) != len(want) {
+		t.Fatalf("received %d events %v, want %d", len(got), got, len(want))
+	}
+	for i := range want {
+		if !proto.Equal(got[i], want[i]) {
+			t.Errorf("event %d = %v, want %v", i, got[i], want[i])
+		}
+	}
+}
+
+func TestEventBusFanOut(t *testing.T) {
+	ctx := context.Background()
+	bus := NewEventBus()
+	subs := []*Subscription{subscribe(t, bus, "t1"), subscribe(t, bus, "t1")}
+	other := subscribe(t, bus, "t2")
+
+	events := []*a2apb.StreamResponse{
+		taskEvent(testTask, a2apb.TaskState_TASK_STATE_SUBMITTED),
+		artifactEvent(testTask, "hello"),
+		statusEvent(testTask, a2apb.TaskState_TASK_STATE_COMPLETED, true),
+	}
+	for _, event := range events {
+		if err := bus.Publish(ctx, "t1", event); err != nil {
+			t.Fatalf("Publish() error = %v", err)
+		}
+	}
+	for _, sub := range subs {
+		assertEvents(t, receiveAll(t, sub), events)
+	}
+	select {
+	case event := <-other.Events():
+		t.Errorf("the subscriber of another task received %v", event)
+	default:
+	}
+
+	// The final event unregisters the subscriptions, a new one gets the next events.
+	late := subscribe(t, bus, "t1")
+	next := statusEvent(testTask, a2apb.TaskState_TASK_STATE_FAILED, true)
+	if err := bus.Publish(ctx, "t1", next); err != nil {
+		t.Fatalf("Publish() error = %v", err)
+	}
+	assertEvents(t, receiveAll(t, late), []*a2apb.StreamResponse{next})
+}
+
+func TestEventBusClosingEvents(t *testing.T) {
+	tests := []struct {
+		name    string
+		event   *a2apb.StreamResponse
+		closing bool
+	}{
+		{name: "final status", event: statusEvent(testTask, a2apb.TaskState_TASK_STATE_INPUT_REQUIRED, true), closing: true},
+		{name: "message", event: &a2apb.StreamResponse{Payload: &a2apb.StreamResponse_Msg{Msg: &a2apb.Message{MessageId: "m1"}}}, closing: true},
+		{name: "status", event: statusEvent(testTask, a2apb.TaskState_TASK_STATE_WORKING, false)},
+		{name: "task", event: taskEvent(testTask, a2apb.TaskState_TASK_STATE_COMPLETED)},
+		{name: "artifact", event: artifactEvent(testTask, "hello")},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			bus := NewEventBus()
+			sub := subscribe(t, bus, "t1")
+			if err := bus.Publish(context.Background(), "t1", tc.event); err != nil {
+				t.Fatalf("Publish() error = %v", err)
+			}
+			<-sub.Events()
+			open := true
+			select {
+			case _, open = <-sub.Events():
+			default:
+			}
+			if open == tc.closing {
+				t.Errorf("subscription open = %v after the event, want %v", open, !tc.closing)
+			}
+		})
+	}
+}
+
+func TestEventBusClose(t *testing.T) {
+	bus := NewEventBus()
+	sub := subscribe(t, bus, "t1")
+	event := statusEvent(testTask, a2apb.TaskState_TASK_STATE_WORKING, false)
+	if err := bus.Publish(context.Background(), "t1", event); err != nil {
+		t.Fatalf("Publish() error = %v", err)
+	}
+	bus.Close("t1")
+	assertEvents(t, receiveAll(t, sub), []*a2apb.StreamResponse{event})
+
+	// Publishing after Close does not reach the closed subscription.
+	if err := bus.Publish(context.Background(), "t1", event); err != nil {
+		t.Errorf("Publish() after Close() error = %v", err)
+	}
+}
+
+func TestEventBusBackpressure(t *testing.T) {
+	events := make([]*a2apb.StreamResponse, 5)
+	for i := range events {
+		events[i] = artifactEvent(testTask, string(rune('a'+i)))
+	}
+
+	t.Run("block", func(t *testing.T) {
+		bus := NewEventBus(WithSubscriberBuffer(2))
+		sub := subscribe(t, bus, "t1")
+		for _, event := range events[:2] {
+			if err := bus.Publish(context.Background(), "t1", event); err != nil {
+				t.Fatalf("Publish() error = %v", err)
+			}
+		}
+		ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+		defer cancel()
+		if err := bus.Publish(ctx, "t1", events[2]); !errors.Is(err, context.DeadlineExceeded) {
+			t.Fatalf("Publish() to a full subscriber error = %v, want %v", err, context.DeadlineExceeded)
+		}
+
+		// Receiving an event unblocks the publisher.
+		published := make(chan error, 1)
+		go func() { published <- bus.Publish(context.Background(), "t1", events[3]) }()
+		assertEvents(t, []*a2apb.StreamResponse{<-sub.Events(), <-sub.Events(), <-sub.Events()}, []*a2apb.StreamResponse{events[0], events[1], events[3]})
+		if err := <-published; err != nil {
+			t.Errorf("Publish() error = %v", err)
+		}
+	})
+
+	t.Run("drop oldest", func(t *testing.T) {
+		bus := NewEventBus(WithSubscriberBuffer(2), WithBackpressure(BackpressureDropOldest))
+		sub := subscribe(t, bus, "t1")
+		for _, event := range events {
+			if err := bus.Publish(context.Background(), "t1", event); err != nil {
+				t.Fatalf("Publish() error = %v", err)
+			}
+		}
+		bus.Close("t1")
+		assertEvents(t, receiveAll(t, sub), events[3:])
+	})
+
+	t.Run("drop oldest without buffer", func(t *testing.T) {
+		bus := NewEventBus(WithSubscriberBuffer(0), WithBackpressure(BackpressureDropOldest))
+		sub := subscribe(t, bus, "t1")
+		for _, event := range events {
+			if err := bus.Publish(context.Background(), "t1", event); err != nil {
+				t.Fatalf("Publish() error = %v", err)
+			}
+		}
+		bus.Close("t1")
+		assertEvents(t, receiveAll(t, sub), events[4:])
+	})
+}
+
+func TestSubscriptionCloseUnblocksPublish(t *testing.T) {
+	bus := NewEventBus(WithSubscriberBuffer(0))
+	sub := subscribe(t, bus, "t1")
+	published := make(chan error, 1)
+	go func() {
+		published <- bus.Publish(context.Background(), "t1", artifactEvent(testTask, "hello"))
+	}()
+	time.Sleep(10 * time.Millisecond)
+	sub.Close()
+	select {
+	case err := <-published:
+		if err != nil {
+			t.Errorf("Publish() error = %v", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("Publish() is blocked by a closed subscription")
+	}
+	if subs := bus.subscribers("t1", false); len(subs) != 0 {
+		t.Errorf("the bus has %d subscribers after Close(), want 0", len(subs))
+	}
+}
+
+func TestEventBusConcurrentUse(t *testing.T) {
+	bus := NewEventBus(WithBackpressure(BackpressureDropOldest))
+	var wg sync.WaitGroup
+	for range 4 {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			for range 50 {
+				_ = bus.Publish(context.Background(), "t1", artifactEvent(testTask, "hello"))
+			}
+		}()
+		go func() {
+			defer wg.Done()
+			for range 50 {
+				sub, err := bus.Subscribe(context.Background(), "t1")
+				if err != nil {
+					t.Errorf("Subscribe() error = %v", err)
+					return
+				}
+				sub.Close()
+			}
+		}()
+	}
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for range 50 {
+			bus.Close("t1")
+		}
+	}()
+	wg.Wait()
+}
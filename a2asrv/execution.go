@@ -0,0 +1,311 @@
+// Copyright 2025 The A2A Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package a2asrv
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	"google.golang.org/protobuf/proto"
+
+	"github.com/a2aproject/a2a-go/a2a"
+	a2apb "github.com/a2aproject/a2a-go/grpc"
+)
+
+var errExecutionStopped = errors.New("task execution stopped")
+
+// execution tracks the events produced by AgentExecutor calls for a task.
+// Events written by producers are processed by a single goroutine, which updates
+// the stored task and publishes the events on the EventBus. The execution is done
+// once every producer has returned and all the events are processed.
+type execution struct {
+	taskID    string
+	contextID string
+	bus       *EventBus
+	// request is the message which started the execution, if any.
+	request *a2apb.Message
+
+	// ctx is passed to the producers. It is canceled when the task is canceled
+	// or the events can not be processed anymore.
+	ctx     context.Context
+	cancel  context.CancelFunc
+	events  chan queuedEvent
+	stopped chan struct{}
+	done    chan struct{}
+
+	// notified is closed when the last push notification of the execution is sent.
+	notified chan struct{}
+
+	// publishMu is held while an event is applied and published, so that new
+	// subscribers get a task snapshot consistent with the events they receive.
+	publishMu sync.Mutex
+
+	// The events channel is closed once there are no producers and no writers
+	// sending an event left.
+	mu        sync.Mutex
+	producers int
+	writers   int
+	closed    bool
+	err       error
+	task      *a2apb.Task
+	version   TaskVersion
+	message   *a2apb.Message
+	finished  bool
+	stopOnce  sync.Once
+}
+
+// queuedEvent is an event written by a producer. The result of its processing
+// is reported back to the producer.
+type queuedEvent struct {
+	event     *a2apb.StreamResponse
+	processed chan error
+}
+
+func newExecution(ctx context.Context, bus *EventBus, taskID string) *execution {
+	ctx, cancel := context.WithCancel(context.WithoutCancel(ctx))
+	return &execution{
+		taskID:  taskID,
+		bus:     bus,
+		ctx:     ctx,
+		cancel:  cancel,
+		events:  make(chan queuedEvent),
+		stopped: make(chan struct{}),
+		done:    make(chan struct{}),
+	}
+}
+
+// addProducer registers a new producer of events. It returns false if the
+// execution does not accept events anymore.
+func (e *execution) addProducer() bool {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if e.closed {
+		return false
+	}
+	e.producers++
+	return true
+}
+
+// produce runs fn as a producer registered with addProducer. Every event written
+// by fn is processed by the time produce returns. Once the last producer returns,
+// writes fail with errExecutionStopped, even if the queue is retained by fn.
+func (e *execution) produce(fn func(ctx context.Context, queue EventQueue) error) error {
+	err := fn(e.ctx, executionQueue{exec: e})
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.producers--
+	if e.producers == 0 {
+		e.closed = true
+		if e.writers == 0 {
+			close(e.events)
+		}
+	}
+	return err
+}
+
+// startWrite registers a write of an event. It returns false if the execution
+// does not accept events anymore.
+func (e *execution) startWrite() bool {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if e.closed {
+		return false
+	}
+	e.writers++
+	return true
+}
+
+// endWrite unregisters a write started with startWrite, closing the events channel
+// if the producers returned while the event was being sent.
+func (e *execution) endWrite() {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.writers--
+	if e.closed && e.writers == 0 {
+		close(e.events)
+	}
+}
+
+// setError records the error which caused the execution to fail.
+// Only the first error is kept.
+func (e *execution) setError(err error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if e.err == nil {
+		e.err = err
+	}
+}
+
+// stop makes the pending and future writes fail and cancels the producers.
+func (e *execution) stop(err error) {
+	e.stopOnce.Do(func() {
+		e.setError(err)
+		close(e.stopped)
+		e.cancel()
+	})
+}
+
+// subscribe returns a new subscription to the events of the task and a snapshot
+// of the task taken before any of the events received by the subscription were
+// applied. It returns nil if the execution is finished.
+func (e *execution) subscribe(ctx context.Context) (*Subscription, *a2apb.Task, error) {
+	// finished and the task are only modified with publishMu held.
+	e.publishMu.Lock()
+	defer e.publishMu.Unlock()
+	if e.isFinished() {
+		return nil, nil, nil
+	}
+	sub, err := e.bus.Subscribe(ctx, e.taskID)
+	if err != nil {
+		return nil, nil, err
+	}
+	task, _ := e.currentTask()
+	return sub, task, nil
+}
+
+func (e *execution) isFinished() bool {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return e.finished
+}
+
+// publish delivers the event to the subscribers of the task. The processing is
+// stopped if a subscriber blocks it until the execution context is canceled.
+// Broker failures only affect the subscribers of other replicas, so they do not
+// stop the processing.
+func (e *execution) publish(event *a2apb.StreamResponse) {
+	if err := e.bus.Publish(e.ctx, e.taskID, event); err != nil && e.ctx.Err() != nil {
+		e.stop(err)
+	}
+}
+
+// finish closes the subscriptions and marks the execution as done.
+func (e *execution) finish() {
+	e.publishMu.Lock()
+	e.mu.Lock()
+	e.finished = true
+	e.mu.Unlock()
+	e.publishMu.Unlock()
+	e.bus.Close(e.taskID)
+	e.cancel()
+	close(e.done)
+}
+
+// result returns the latest task snapshot or the message the agent responded with.
+// If the agent produced neither, the error of the execution is returned.
+func (e *execution) result() (*a2apb.Task, *a2apb.Message, error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if e.message != nil {
+		return nil, e.message, nil
+	}
+	if e.task != nil {
+		return cloneTask(e.task), nil, nil
+	}
+	if e.err != nil {
+		return nil, nil, e.err
+	}
+	return nil, nil, fmt.Errorf("%w: agent produced no events", a2a.ErrInvalidAgentResponse)
+}
+
+func (e *execution) currentTask() (*a2apb.Task, TaskVersion) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return cloneTask(e.task), e.version
+}
+
+func (e *execution) setTask(task *a2apb.Task, version TaskVersion) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.task = task
+	e.version = version
+}
+
+func (e *execution) setMessage(msg *a2apb.Message) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.message = msg
+}
+
+// wait blocks until the execution is done or the context is canceled.
+func (e *execution) wait(ctx context.Context) error {
+	select {
+	case <-e.done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// interrupted reports whether the task reached a state which ends the execution.
+func (e *execution) interrupted() bool {
+	task, _ := e.currentTask()
+	state := task.GetStatus().GetState()
+	return IsInterrupted(state) || IsTerminal(state)
+}
+
+func (e *execution) failure() error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return e.err
+}
+
+// executionQueue is the EventQueue passed to the producers of an execution.
+type executionQueue struct {
+	exec *execution
+}
+
+var _ EventQueue = executionQueue{}
+
+func (q executionQueue) Write(ctx context.Context, event *a2apb.StreamResponse) error {
+	if event.GetPayload() == nil {
+		return fmt.Errorf("%w: event payload is required", a2a.ErrInvalidParams)
+	}
+	select {
+	case <-q.exec.stopped:
+		return errExecutionStopped
+	default:
+	}
+	if !q.exec.startWrite() {
+		return errExecutionStopped
+	}
+	defer q.exec.endWrite()
+	queued := queuedEvent{event: event, processed: make(chan error, 1)}
+	select {
+	case q.exec.events <- queued:
+		return <-queued.processed
+	case <-q.exec.stopped:
+		return errExecutionStopped
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func cloneTask(task *a2apb.Task) *a2apb.Task {
+	if task == nil {
+		return nil
+	}
+	return proto.Clone(task).(*a2apb.Task)
+}
+
+func cloneMessage(msg *a2apb.Message) *a2apb.Message {
+	return proto.Clone(msg).(*a2apb.Message)
+}
+
+func cloneConfig(config *a2apb.PushNotificationConfig) *a2apb.PushNotificationConfig {
+	return proto.Clone(config).(*a2apb.PushNotificationConfig)
+}
@@ -0,0 +1,65 @@
+// Copyright 2025 The A2A Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package a2asrv
+
+import (
+	"context"
+
+	"google.golang.org/protobuf/types/known/structpb"
+
+	a2apb "github.com/a2aproject/a2a-go/grpc"
+)
+
+// AgentExecutor implements the logic of an agent. The request handler created by
+// NewHandler takes care of the protocol: task lifecycle, persistence, streaming
+// and cancellation, and only delegates the agent behavior to the executor.
+type AgentExecutor interface {
+	// Execute handles a message sent by a client. The agent reports its progress by
+	// writing events to the queue: either a single Message, or a Task followed by
+	// TaskStatusUpdateEvent and TaskArtifactUpdateEvent for the task identified by
+	// the request context. The context is canceled when the task is canceled.
+	Execute(ctx context.Context, reqCtx *RequestContext, queue EventQueue) error
+
+	// Cancel requests the cancellation of the task identified by the request context.
+	// The executor is expected to write a TaskStatusUpdateEvent with the
+	// TASK_STATE_CANCELLED state to the queue if the task can be canceled.
+	Cancel(ctx context.Context, reqCtx *RequestContext, queue EventQueue) error
+}
+
+// RequestContext holds the information about a request an AgentExecutor handles.
+type RequestContext struct {
+	// TaskID is the identifier of the task the request is for. It is generated by the
+	// handler for messages which do not reference an existing task.
+	TaskID string
+	// ContextID is the identifier of the context the task belongs to.
+	ContextID string
+	// Message is the message sent by the client. It is nil for cancellation requests.
+	Message *a2apb.Message
+	// Task is the stored state of the task, or nil if the message starts a new task.
+	Task *a2apb.Task
+	// Configuration is the configuration of the message send request.
+	Configuration *a2apb.SendMessageConfiguration
+	// Metadata is the metadata of the message send request.
+	Metadata *structpb.Struct
+}
+
+// EventQueue receives the events produced by an AgentExecutor. The accepted events are
+// the ones a StreamResponse can hold: Task, Message, TaskStatusUpdateEvent and
+// TaskArtifactUpdateEvent.
+type EventQueue interface {
+	// Write enqueues the event and waits until it is processed. It returns an error
+	// if the event is rejected, the context is canceled or the task processing is stopped.
+	Write(ctx context.Context, event *a2apb.StreamResponse) error
+}
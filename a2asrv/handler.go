@@ -0,0 +1,768 @@
+// Copyright 2025 The A2A Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package a2asrv
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/a2aproject/a2a-go/a2a"
+	a2apb "github.com/a2aproject/a2a-go/grpc"
+	"github.com/a2aproject/a2a-go/internal/taskupdate"
+	"github.com/a2aproject/a2a-go/internal/uuid"
+)
+
+// HandlerOption configures the handler created by NewHandler.
+type HandlerOption func(*handler)
+
+// WithTaskStore makes the handler persist tasks in the store.
+// By default tasks are kept in an InMemoryTaskStore.
+func WithTaskStore(store TaskStore) HandlerOption {
+	return func(h *handler) {
+		h.tasks = store
+	}
+}
+
+// WithEventBus makes the handler publish the events of tasks on the bus.
+// By default a bus created by NewEventBus without options is used.
+func WithEventBus(bus *EventBus) HandlerOption {
+	return func(h *handler) {
+		h.bus = bus
+	}
+}
+
+// WithPushConfigStore makes the handler persist push notification configs in the store.
+// By default configs are kept in an InMemoryPushConfigStore.
+func WithPushConfigStore(store PushConfigStore) HandlerOption {
+	return func(h *handler) {
+		h.pushConfigs = store
+	}
+}
+
+// WithPushNotifier enables push notifications. The notifier is called in the
+// background whenever the state of a task with push notification configs changes.
+// Without a notifier, the push notification config methods fail with
+// a2a.ErrPushNotificationNotSupported.
+func WithPushNotifier(notifier PushNotifier) HandlerOption {
+	return func(h *handler) {
+		h.notifier = notifier
+	}
+}
+
+// WithPushURLValidator makes the handler reject push notification configs whose URL
+// is not accepted by the validator with a2a.ErrInvalidParams. The notifier should
+// validate the URLs again before delivering notifications, because the addresses
+// they resolve to may change.
+func WithPushURLValidator(validator PushURLValidator) HandlerOption {
+	return func(h *handler) {
+		h.pushURLs = validator
+	}
+}
+
+// WithAgentCard makes the handler return the card from GetAgentCard.
+// Without it GetAgentCard fails with a2a.ErrUnsupportedOperation.
+func WithAgentCard(card *a2apb.AgentCard) HandlerOption {
+	return func(h *handler) {
+		h.card = card
+	}
+}
+
+type handler struct {
+	a2apb.UnimplementedA2AServiceServer
+
+	executor    AgentExecutor
+	card        *a2apb.AgentCard
+	tasks       TaskStore
+	bus         *EventBus
+	pushConfigs PushConfigStore
+	notifier    PushNotifier
+	pushURLs    PushURLValidator
+
+	mu         sync.Mutex
+	executions map[string]*execution
+}
+
+var _ a2apb.A2AServiceServer = (*handler)(nil)
+
+// NewHandler returns an A2AServiceServer which implements the protocol on top of
+// the executor. Tasks are persisted in a TaskStore, push notification configs
+// in a PushConfigStore.
+//
+// Messages which do not reference a task start a new one with a generated ID.
+// The events written by the executor are applied to the stored task and streamed
+// to the clients waiting for the task. If the executor returns an error after
+// creating a task, the task is marked as failed.
+//
+// SendMessage returns after the first event of the execution unless the request
+// configuration sets blocking, in which case it waits for the task to reach a
+// terminal or interrupted state. This matches the protocol default of a
+// non-blocking request.
+//
+// The returned server can be registered with a gRPC server or wrapped by
+// jsonrpc.NewHandler.
+func NewHandler(executor AgentExecutor, opts ...HandlerOption) a2apb.A2AServiceServer {
+	h := &handler{
+		executor:    executor,
+		tasks:       NewInMemoryTaskStore(),
+		bus:         NewEventBus(),
+		pushConfigs: NewInMemoryPushConfigStore(),
+		executions:  make(map[string]*execution),
+	}
+	for _, opt := range opts {
+		opt(h)
+	}
+	return h
+}
+
+func (h *handler) SendMessage(ctx context.Context, req *a2apb.SendMessageRequest) (*a2apb.SendMessageResponse, error) {
+	exec, sub, err := h.startMessage(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	defer sub.Close()
+
+	blocking := req.GetConfiguration().GetBlocking()
+	for done := false; !done; {
+		select {
+		case event, ok := <-sub.Events():
+			done = !ok || !blocking || isFinalEvent(event)
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+	task, msg, err := exec.result()
+	if err != nil {
+		return nil, err
+	}
+	if msg != nil {
+		return &a2apb.SendMessageResponse{Payload: &a2apb.SendMessageResponse_Msg{Msg: msg}}, nil
+	}
+	taskupdate.TrimHistory(task, int(req.GetConfiguration().GetHistoryLength()))
+	return &a2apb.SendMessageResponse{Payload: &a2apb.SendMessageResponse_Task{Task: task}}, nil
+}
+
+func (h *handler) SendStreamingMessage(req *a2apb.SendMessageRequest, stream grpc.ServerStreamingServer[a2apb.StreamResponse]) error {
+	exec, sub, err := h.startMessage(stream.Context(), req)
+	if err != nil {
+		return err
+	}
+	defer sub.Close()
+
+	sent, err := forward(stream, sub)
+	if err != nil || sent {
+		return err
+	}
+	_, _, err = exec.result()
+	return err
+}
+
+func (h *handler) GetTask(ctx context.Context, req *a2apb.GetTaskRequest) (*a2apb.Task, error) {
+	taskID, err := a2a.ParseTaskName(req.GetName())
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", a2a.ErrInvalidParams, err)
+	}
+	task, _, err := h.tasks.Get(ctx, taskID)
+	if err != nil {
+		return nil, err
+	}
+	taskupdate.TrimHistory(task, int(req.GetHistoryLength()))
+	return task, nil
+}
+
+func (h *handler) CancelTask(ctx context.Context, req *a2apb.CancelTaskRequest) (*a2apb.Task, error) {
+	taskID, err := a2a.ParseTaskName(req.GetName())
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", a2a.ErrInvalidParams, err)
+	}
+	task, version, err := h.tasks.Get(ctx, taskID)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkCancelable(task); err != nil {
+		return nil, err
+	}
+
+	// The cancellation events go through the active execution of the task,
+	// so that its subscribers observe them. Without one, a new execution is started.
+	exec := h.activeExecution(taskID)
+	if exec != nil && !exec.addProducer() {
+		// The execution is finishing and does not accept events anymore. It may have
+		// completed the task, which is loaded again once it is done.
+		if err := exec.wait(ctx); err != nil {
+			return nil, err
+		}
+		exec = nil
+		if task, version, err = h.tasks.Get(ctx, taskID); err != nil {
+			return nil, err
+		}
+		if err := checkCancelable(task); err != nil {
+			return nil, err
+		}
+	}
+	if exec == nil {
+		if exec, err = h.newExecution(ctx, taskID); err != nil {
+			return nil, err
+		}
+		exec.contextID = task.GetContextId()
+		exec.setTask(cloneTask(task), version)
+		go h.process(exec)
+	}
+	reqCtx := &RequestContext{TaskID: taskID, ContextID: task.GetContextId(), Task: task}
+	err = exec.produce(func(_ context.Context, queue EventQueue) error {
+		return h.executor.Cancel(ctx, reqCtx, queue)
+	})
+	if err != nil {
+		return nil, err
+	}
+	task, _ = exec.currentTask()
+	if task.GetStatus().GetState() != a2apb.TaskState_TASK_STATE_CANCELLED {
+		return nil, fmt.Errorf("%w: task %s is in state %s", a2a.ErrTaskNotCancelable, taskID, task.GetStatus().GetState())
+	}
+	exec.cancel()
+	return task, nil
+}
+
+// checkCancelable fails with a2a.ErrTaskNotCancelable if the task is in a terminal state.
+func checkCancelable(task *a2apb.Task) error {
+	if state := task.GetStatus().GetState(); IsTerminal(state) {
+		return fmt.Errorf("%w: task %s is in state %s", a2a.ErrTaskNotCancelable, task.GetId(), state)
+	}
+	return nil
+}
+
+func (h *handler) TaskSubscription(req *a2apb.TaskSubscriptionRequest, stream grpc.ServerStreamingServer[a2apb.StreamResponse]) error {
+	taskID, err := a2a.ParseTaskName(req.GetName())
+	if err != nil {
+		return fmt.Errorf("%w: %w", a2a.ErrInvalidParams, err)
+	}
+	task, _, err := h.tasks.Get(stream.Context(), taskID)
+	if err != nil {
+		return err
+	}
+	sub, snapshot, err := h.subscribe(stream.Context(), task)
+	if err != nil {
+		return err
+	}
+	if sub != nil {
+		defer sub.Close()
+	}
+	if snapshot != nil {
+		task = snapshot
+	}
+	if err := stream.Send(&a2apb.StreamResponse{Payload: &a2apb.StreamResponse_Task{Task: task}}); err != nil {
+		return err
+	}
+	if state := task.GetStatus().GetState(); sub == nil || IsTerminal(state) || IsInterrupted(state) {
+		return nil
+	}
+	_, err = forward(stream, sub)
+	return err
+}
+
+func (h *handler) CreateTaskPushNotificationConfig(ctx context.Context, req *a2apb.CreateTaskPushNotificationConfigRequest) (*a2apb.TaskPushNotificationConfig, error) {
+	if h.notifier == nil {
+		return nil, a2a.ErrPushNotificationNotSupported
+	}
+	taskID, err := a2a.ParseTaskName(req.GetParent())
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", a2a.ErrInvalidParams, err)
+	}
+	if _, _, err := h.tasks.Get(ctx, taskID); err != nil {
+		return nil, err
+	}
+	config := req.GetConfig().GetPushNotificationConfig()
+	if config == nil {
+		return nil, fmt.Errorf("%w: push notification config is required", a2a.ErrInvalidParams)
+	}
+	config = cloneConfig(config)
+	if req.GetConfigId() != "" {
+		config.Id = req.GetConfigId()
+	}
+	config, err = h.savePushConfig(ctx, taskID, config)
+	if err != nil {
+		return nil, err
+	}
+	return &a2apb.TaskPushNotificationConfig{
+		Name:                   a2a.PushConfigName(taskID, config.GetId()),
+		PushNotificationConfig: config,
+	}, nil
+}
+
+func (h *handler) GetTaskPushNotificationConfig(ctx context.Context, req *a2apb.GetTaskPushNotificationConfigRequest) (*a2apb.TaskPushNotificationConfig, error) {
+	if h.notifier == nil {
+		return nil, a2a.ErrPushNotificationNotSupported
+	}
+	taskID, configID, err := a2a.ParsePushConfigName(req.GetName())
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", a2a.ErrInvalidParams, err)
+	}
+	if configID == "" {
+		configID = taskID
+	}
+	config, err := h.pushConfigs.Get(ctx, taskID, configID)
+	if err != nil {
+		return nil, err
+	}
+	return &a2apb.TaskPushNotificationConfig{
+		Name:                   a2a.PushConfigName(taskID, configID),
+		PushNotificationConfig: config,
+	}, nil
+}
+
+func (h *handler) ListTaskPushNotificationConfig(ctx context.Context, req *a2apb.ListTaskPushNotificationConfigRequest) (*a2apb.ListTaskPushNotificationConfigResponse, error) {
+	if h.notifier == nil {
+		return nil, a2a.ErrPushNotificationNotSupported
+	}
+	taskID, err := a2a.ParseTaskName(req.GetParent())
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", a2a.ErrInvalidParams, err)
+	}
+	if _, _, err := h.tasks.Get(ctx, taskID); err != nil {
+		return nil, err
+	}
+	// Without a page size all the configs are listed, because JSON-RPC clients cannot
+	// request the next pages.
+	configs, next, err := h.pushConfigs.List(ctx, taskID, int(req.GetPageSize()), req.GetPageToken())
+	if err != nil {
+		return nil, err
+	}
+	resp := &a2apb.ListTaskPushNotificationConfigResponse{NextPageToken: next}
+	for _, config := range configs {
+		resp.Configs = append(resp.Configs, &a2apb.TaskPushNotificationConfig{
+			Name:                   a2a.PushConfigName(taskID, config.GetId()),
+			PushNotificationConfig: config,
+		})
+	}
+	return resp, nil
+}
+
+func (h *handler) DeleteTaskPushNotificationConfig(ctx context.Context, req *a2apb.DeleteTaskPushNotificationConfigRequest) (*emptypb.Empty, error) {
+	if h.notifier == nil {
+		return nil, a2a.ErrPushNotificationNotSupported
+	}
+	taskID, configID, err := a2a.ParsePushConfigName(req.GetName())
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", a2a.ErrInvalidParams, err)
+	}
+	if configID == "" {
+		configID = taskID
+	}
+	if err := h.pushConfigs.Delete(ctx, taskID, configID); err != nil {
+		return nil, err
+	}
+	return &emptypb.Empty{}, nil
+}
+
+func (h *handler) GetAgentCard(context.Context, *a2apb.GetAgentCardRequest) (*a2apb.AgentCard, error) {
+	if h.card == nil {
+		return nil, a2a.ErrUnsupportedOperation
+	}
+	return h.card, nil
+}
+
+// startMessage starts the execution of the message and subscribes to its events.
+func (h *handler) startMessage(ctx context.Context, req *a2apb.SendMessageRequest) (*execution, *Subscription, error) {
+	msg := req.GetRequest()
+	if msg == nil {
+		return nil, nil, fmt.Errorf("%w: message is required", a2a.ErrInvalidParams)
+	}
+	if msg.GetMessageId() == "" {
+		return nil, nil, fmt.Errorf("%w: message ID is required", a2a.ErrInvalidParams)
+	}
+	msg = cloneMessage(msg)
+
+	existing := msg.GetTaskId() != ""
+	if existing {
+		// A client replying to an interrupted task can be faster than the
+		// execution which reported the interruption.
+		if active := h.activeExecution(msg.GetTaskId()); active != nil && active.interrupted() {
+			if err := active.wait(ctx); err != nil {
+				return nil, nil, err
+			}
+		}
+	} else {
+		msg.TaskId = uuid.New()
+	}
+	exec, err := h.newExecution(ctx, msg.GetTaskId())
+	if err != nil {
+		return nil, nil, err
+	}
+	if err := h.prepareMessage(ctx, exec, msg, existing, req.GetConfiguration()); err != nil {
+		h.release(exec)
+		return nil, nil, err
+	}
+
+	task, _ := exec.currentTask()
+	reqCtx := &RequestContext{
+		TaskID:        msg.GetTaskId(),
+		ContextID:     msg.GetContextId(),
+		Message:       cloneMessage(msg),
+		Task:          task,
+		Configuration: req.GetConfiguration(),
+		Metadata:      req.GetMetadata(),
+	}
+	sub, _, err := exec.subscribe(ctx)
+	if err != nil {
+		h.release(exec)
+		return nil, nil, err
+	}
+	go func() {
+		_ = exec.produce(func(ctx context.Context, queue EventQueue) error {
+			err := h.executor.Execute(ctx, reqCtx, queue)
+			if err != nil {
+				exec.setError(err)
+			}
+			return err
+		})
+	}()
+	go h.process(exec)
+	return exec, sub, nil
+}
+
+// prepareMessage sets up the execution for the message. A message for an existing
+// task is added to the task history.
+func (h *handler) prepareMessage(ctx context.Context, exec *execution, msg *a2apb.Message, existing bool, config *a2apb.SendMessageConfiguration) error {
+	if existing {
+		task, version, err := h.tasks.Get(ctx, msg.GetTaskId())
+		if err != nil {
+			return err
+		}
+		if IsTerminal(task.GetStatus().GetState()) {
+			return fmt.Errorf("%w: task %s is in terminal state %s", a2a.ErrInvalidParams, task.GetId(), task.GetStatus().GetState())
+		}
+		if msg.GetContextId() != "" && msg.GetContextId() != task.GetContextId() {
+			return fmt.Errorf("%w: message context %s does not match task context %s", a2a.ErrInvalidParams, msg.GetContextId(), task.GetContextId())
+		}
+		msg.ContextId = task.GetContextId()
+		task.History = append(task.History, msg)
+		if version, err = h.tasks.Save(ctx, task, version); err != nil {
+			return err
+		}
+		exec.setTask(task, version)
+	} else if msg.GetContextId() == "" {
+		msg.ContextId = uuid.New()
+	}
+	exec.contextID = msg.GetContextId()
+	exec.request = msg
+
+	if pushConfig := config.GetPushNotification(); pushConfig != nil {
+		// A config sent with a message is the default config of the task, which is
+		// addressed by the task ID.
+		pushConfig = cloneConfig(pushConfig)
+		if pushConfig.GetId() == "" {
+			pushConfig.Id = msg.GetTaskId()
+		}
+		_, err := h.savePushConfig(ctx, msg.GetTaskId(), pushConfig)
+		return err
+	}
+	return nil
+}
+
+// subscribe subscribes to the events of the task and returns the task snapshot the
+// events apply to. The task is executed by this server or, if the event bus is
+// distributed, possibly by another replica. It returns nil if the task is not
+// being executed.
+func (h *handler) subscribe(ctx context.Context, task *a2apb.Task) (*Subscription, *a2apb.Task, error) {
+	if exec := h.activeExecution(task.GetId()); exec != nil {
+		sub, snapshot, err := exec.subscribe(ctx)
+		if err != nil || sub != nil {
+			return sub, snapshot, err
+		}
+	}
+	state := task.GetStatus().GetState()
+	if !h.bus.Distributed() || IsTerminal(state) || IsInterrupted(state) {
+		return nil, nil, nil
+	}
+	sub, err := h.bus.Subscribe(ctx, task.GetId())
+	if err != nil {
+		return nil, nil, err
+	}
+	// The task is loaded again to include the events published before the subscription.
+	snapshot, _, err := h.tasks.Get(ctx, task.GetId())
+	if err != nil {
+		sub.Close()
+		return nil, nil, err
+	}
+	return sub, snapshot, nil
+}
+
+// newExecution registers a new execution of the task with a single producer.
+func (h *handler) newExecution(ctx context.Context, taskID string) (*execution, error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if _, ok := h.executions[taskID]; ok {
+		return nil, fmt.Errorf("%w: task %s is already being processed", a2a.ErrInvalidParams, taskID)
+	}
+	exec := newExecution(ctx, h.bus, taskID)
+	exec.addProducer()
+	h.executions[taskID] = exec
+	return exec, nil
+}
+
+func (h *handler) activeExecution(taskID string) *execution {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return h.executions[taskID]
+}
+
+// release unregisters an execution which was never started.
+func (h *handler) release(exec *execution) {
+	exec.finish()
+	h.mu.Lock()
+	delete(h.executions, exec.taskID)
+	h.mu.Unlock()
+}
+
+// process handles the events of the execution until all of its producers return.
+func (h *handler) process(exec *execution) {
+	for queued := range exec.events {
+		err := h.handleEvent(exec, queued.event)
+		queued.processed <- err
+		// Invalid events are only rejected, other failures stop the processing.
+		if err != nil && !errors.Is(err, a2a.ErrInvalidAgentResponse) {
+			exec.stop(err)
+			break
+		}
+	}
+	for queued := range exec.events {
+		queued.processed <- errExecutionStopped
+	}
+	h.failTask(exec)
+
+	// The subscriptions are closed before a new execution of the task can be started.
+	exec.finish()
+	h.mu.Lock()
+	delete(h.executions, exec.taskID)
+	h.mu.Unlock()
+}
+
+// handleEvent applies the event to the task and publishes it to the subscribers.
+func (h *handler) handleEvent(exec *execution, event *a2apb.StreamResponse) error {
+	exec.publishMu.Lock()
+	defer exec.publishMu.Unlock()
+
+	switch p := event.GetPayload().(type) {
+	case *a2apb.StreamResponse_Msg:
+		exec.setMessage(cloneMessage(p.Msg))
+
+	case *a2apb.StreamResponse_Task:
+		if err := validateEventIDs(exec, p.Task.GetId(), p.Task.GetContextId()); err != nil {
+			return err
+		}
+		current, version := exec.currentTask()
+		if err := validateTransition(current, p.Task.GetStatus().GetState()); err != nil {
+			return err
+		}
+		task := cloneTask(p.Task)
+		if exec.request != nil && !taskupdate.HasMessage(task, exec.request.GetMessageId()) {
+			task.History = append(task.History, exec.request)
+		}
+		if err := h.saveTask(exec, task, version); err != nil {
+			return err
+		}
+
+	case *a2apb.StreamResponse_StatusUpdate:
+		if err := validateEventIDs(exec, p.StatusUpdate.GetTaskId(), p.StatusUpdate.GetContextId()); err != nil {
+			return err
+		}
+		if p.StatusUpdate.GetStatus() == nil {
+			return fmt.Errorf("%w: status update without status", a2a.ErrInvalidAgentResponse)
+		}
+		task, version := h.taskForUpdate(exec)
+		if err := validateTransition(task, p.StatusUpdate.GetStatus().GetState()); err != nil {
+			return err
+		}
+		taskupdate.ApplyStatusUpdate(task, p.StatusUpdate)
+		if err := h.saveTask(exec, task, version); err != nil {
+			return err
+		}
+
+	case *a2apb.StreamResponse_ArtifactUpdate:
+		if err := validateEventIDs(exec, p.ArtifactUpdate.GetTaskId(), p.ArtifactUpdate.GetContextId()); err != nil {
+			return err
+		}
+		if p.ArtifactUpdate.GetArtifact() == nil {
+			return fmt.Errorf("%w: artifact update without artifact", a2a.ErrInvalidAgentResponse)
+		}
+		task, version := h.taskForUpdate(exec)
+		if state := task.GetStatus().GetState(); IsTerminal(state) {
+			return fmt.Errorf("%w: %w", a2a.ErrInvalidAgentResponse, &InvalidTransitionError{From: state, To: state})
+		}
+		taskupdate.ApplyArtifactUpdate(task, p.ArtifactUpdate)
+		if err := h.saveTask(exec, task, version); err != nil {
+			return err
+		}
+
+	default:
+		return fmt.Errorf("%w: unsupported event %T", a2a.ErrInvalidAgentResponse, p)
+	}
+	exec.publish(event)
+	return nil
+}
+
+// taskForUpdate returns the current task of the execution. If the agent did not
+// create the task yet, a submitted task is created for the request message.
+func (h *handler) taskForUpdate(exec *execution) (*a2apb.Task, TaskVersion) {
+	if task, version := exec.currentTask(); task != nil {
+		return task, version
+	}
+	task := &a2apb.Task{
+		Id:        exec.taskID,
+		ContextId: exec.contextID,
+		Status: &a2apb.TaskStatus{
+			State:     a2apb.TaskState_TASK_STATE_SUBMITTED,
+			Timestamp: timestamppb.Now(),
+		},
+	}
+	if exec.request != nil {
+		task.History = []*a2apb.Message{exec.request}
+	}
+	return task, TaskVersionMissing
+}
+
+func (h *handler) saveTask(exec *execution, task *a2apb.Task, prev TaskVersion) error {
+	previous, _ := exec.currentTask()
+	version, err := h.tasks.Save(exec.ctx, task, prev)
+	if err != nil {
+		return fmt.Errorf("saving task %s: %w", task.GetId(), err)
+	}
+	exec.setTask(task, version)
+	if previous.GetStatus().GetState() != task.GetStatus().GetState() {
+		h.notify(exec, task)
+	}
+	return nil
+}
+
+// notify sends push notifications about the task in the background. The notifications
+// of an execution are sent one after another, so that retries do not reorder them.
+// It is only called by the goroutine processing the events of the execution.
+func (h *handler) notify(exec *execution, task *a2apb.Task) {
+	if h.notifier == nil {
+		return
+	}
+	ctx := context.WithoutCancel(exec.ctx)
+	task = cloneTask(task)
+	prev, done := exec.notified, make(chan struct{})
+	exec.notified = done
+	go func() {
+		defer close(done)
+		if prev != nil {
+			<-prev
+		}
+		configs, _, err := h.pushConfigs.List(ctx, task.GetId(), 0, "")
+		if err != nil || len(configs) == 0 {
+			return
+		}
+		h.notifier.Notify(ctx, task, configs)
+	}()
+}
+
+// failTask marks the task of a failed execution as failed, unless the task
+// already reached a terminal state.
+func (h *handler) failTask(exec *execution) {
+	if exec.failure() == nil {
+		return
+	}
+	task, version := exec.currentTask()
+	if task == nil || IsTerminal(task.GetStatus().GetState()) {
+		return
+	}
+	update := &a2apb.TaskStatusUpdateEvent{
+		TaskId:    exec.taskID,
+		ContextId: exec.contextID,
+		Status: &a2apb.TaskStatus{
+			State:     a2apb.TaskState_TASK_STATE_FAILED,
+			Timestamp: timestamppb.Now(),
+		},
+		Final: true,
+	}
+	taskupdate.ApplyStatusUpdate(task, update)
+	// The task is saved with a context which is not canceled by the stop of the execution.
+	version, err := h.tasks.Save(context.WithoutCancel(exec.ctx), task, version)
+	if err != nil {
+		return
+	}
+	exec.publishMu.Lock()
+	defer exec.publishMu.Unlock()
+	exec.setTask(task, version)
+	h.notify(exec, task)
+	_ = h.bus.Publish(context.WithoutCancel(exec.ctx), exec.taskID, &a2apb.StreamResponse{
+		Payload: &a2apb.StreamResponse_StatusUpdate{StatusUpdate: update},
+	})
+}
+
+func (h *handler) savePushConfig(ctx context.Context, taskID string, config *a2apb.PushNotificationConfig) (*a2apb.PushNotificationConfig, error) {
+	if h.notifier == nil {
+		return nil, a2a.ErrPushNotificationNotSupported
+	}
+	if config.GetUrl() == "" {
+		return nil, fmt.Errorf("%w: push notification config URL is required", a2a.ErrInvalidParams)
+	}
+	if h.pushURLs != nil {
+		if err := h.pushURLs.ValidateURL(ctx, config.GetUrl()); err != nil {
+			return nil, fmt.Errorf("%w: %w", a2a.ErrInvalidParams, err)
+		}
+	}
+	return h.pushConfigs.Save(ctx, taskID, config)
+}
+
+// validateTransition checks that the agent can move the task to the state.
+// A nil task is a task which is not created yet.
+func validateTransition(task *a2apb.Task, to a2apb.TaskState) error {
+	if err := ValidateTransition(task.GetStatus().GetState(), to); err != nil {
+		return fmt.Errorf("%w: %w", a2a.ErrInvalidAgentResponse, err)
+	}
+	return nil
+}
+
+// validateEventIDs checks that an event produced by the agent is for the task of the execution.
+func validateEventIDs(exec *execution, taskID, contextID string) error {
+	if taskID != exec.taskID {
+		return fmt.Errorf("%w: event for task %s, expected %s", a2a.ErrInvalidAgentResponse, taskID, exec.taskID)
+	}
+	if contextID != "" && contextID != exec.contextID {
+		return fmt.Errorf("%w: event for context %s, expected %s", a2a.ErrInvalidAgentResponse, contextID, exec.contextID)
+	}
+	return nil
+}
+
+// forward sends the events of the subscription to the stream until a final event
+// is sent or the execution is done. It reports whether any events were sent.
+func forward(stream grpc.ServerStreamingServer[a2apb.StreamResponse], sub *Subscription) (bool, error) {
+	sent := false
+	for {
+		select {
+		case event, ok := <-sub.Events():
+			if !ok {
+				return sent, nil
+			}
+			if err := stream.Send(event); err != nil {
+				return sent, err
+			}
+			sent = true
+			if isFinalEvent(event) {
+				return sent, nil
+			}
+		case <-stream.Context().Done():
+			return sent, stream.Context().Err()
+		}
+	}
+}
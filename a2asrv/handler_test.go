@@ -0,0 +1,574 @@
+// Copyright 2025 The A2A Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package a2asrv
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+
+	"github.com/a2aproject/a2a-go/a2a"
+	a2apb "github.com/a2aproject/a2a-go/grpc"
+)
+
+// testExecutor is an AgentExecutor delegating to functions. A nil cancel function
+// writes a canceled status update.
+type testExecutor struct {
+	execute func(ctx context.Context, reqCtx *RequestContext, queue EventQueue) error
+	cancel  func(ctx context.Context, reqCtx *RequestContext, queue EventQueue) error
+}
+
+func (e *testExecutor) Execute(ctx context.Context, reqCtx *RequestContext, queue EventQueue) error {
+	return e.execute(ctx, reqCtx, queue)
+}
+
+func (e *testExecutor) Cancel(ctx context.Context, reqCtx *RequestContext, queue EventQueue) error {
+	if e.cancel != nil {
+		return e.cancel(ctx, reqCtx, queue)
+	}
+	return queue.Write(ctx, statusEvent(reqCtx, a2apb.TaskState_TASK_STATE_CANCELLED, true))
+}
+
+func taskEvent(reqCtx *RequestContext, state a2apb.TaskState) *a2apb.StreamResponse {
+	return &a2apb.StreamResponse{Payload: &a2apb.StreamResponse_Task{Task: &a2apb.Task{
+		Id:        reqCtx.TaskID,
+		ContextId: reqCtx.ContextID,
+		Status:    &a2apb.TaskStatus{State: state},
+	}}}
+}
+
+func statusEvent(reqCtx *RequestContext, state a2apb.TaskState, final bool) *a2apb.StreamResponse {
+	return &a2apb.StreamResponse{Payload: &a2apb.StreamResponse_StatusUpdate{StatusUpdate: &a2apb.TaskStatusUpdateEvent{
+		TaskId:    reqCtx.TaskID,
+		ContextId: reqCtx.ContextID,
+		Status:    &a2apb.TaskStatus{State: state},
+		Final:     final,
+	}}}
+}
+
+func artifactEvent(reqCtx *RequestContext, text string) *a2apb.StreamResponse {
+	return &a2apb.StreamResponse{Payload: &a2apb.StreamResponse_ArtifactUpdate{ArtifactUpdate: &a2apb.TaskArtifactUpdateEvent{
+		TaskId:    reqCtx.TaskID,
+		ContextId: reqCtx.ContextID,
+		Artifact:  &a2apb.Artifact{ArtifactId: "a1", Parts: []*a2apb.Part{{Part: &a2apb.Part_Text{Text: text}}}},
+	}}}
+}
+
+// writeAll writes the events to the queue and stops at the first error.
+func writeAll(ctx context.Context, queue EventQueue, events ...*a2apb.StreamResponse) error {
+	for _, event := range events {
+		if err := queue.Write(ctx, event); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func newTestMessage(taskID string) *a2apb.SendMessageRequest {
+	return &a2apb.SendMessageRequest{Request: &a2apb.Message{
+		MessageId: "m-" + time.Now().Format(time.RFC3339Nano),
+		TaskId:    taskID,
+		Role:      a2apb.Role_ROLE_USER,
+		Content:   []*a2apb.Part{{Part: &a2apb.Part_Text{Text: "hello"}}},
+	}, Configuration: &a2apb.SendMessageConfiguration{Blocking: true}}
+}
+
+// testStream collects the events sent to a server stream.
+type testStream struct {
+	grpc.ServerStream
+	ctx context.Context
+
+	mu     sync.Mutex
+	events []*a2apb.StreamResponse
+}
+
+var _ grpc.ServerStreamingServer[a2apb.StreamResponse] = (*testStream)(nil)
+
+func newTestStream(ctx context.Context) *testStream {
+	return &testStream{ctx: ctx}
+}
+
+func (s *testStream) Send(event *a2apb.StreamResponse) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.events = append(s.events, event)
+	return nil
+}
+
+func (s *testStream) Context() context.Context     { return s.ctx }
+func (s *testStream) SetHeader(metadata.MD) error  { return nil }
+func (s *testStream) SendHeader(metadata.MD) error { return nil }
+func (s *testStream) SetTrailer(metadata.MD)       {}
+func (s *testStream) SendMsg(any) error            { return nil }
+func (s *testStream) RecvMsg(any) error            { return nil }
+func (s *testStream) received() []*a2apb.StreamResponse {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]*a2apb.StreamResponse(nil), s.events...)
+}
+
+func TestHandlerSendMessage(t *testing.T) {
+	tests := []struct {
+		name      string
+		execute   func(ctx context.Context, reqCtx *RequestContext, queue EventQueue) error
+		wantState a2apb.TaskState
+		wantMsg   bool
+		wantErr   error
+	}{
+		{
+			name: "task completed",
+			execute: func(ctx context.Context, reqCtx *RequestContext, queue EventQueue) error {
+				return writeAll(ctx, queue,
+					taskEvent(reqCtx, a2apb.TaskState_TASK_STATE_SUBMITTED),
+					statusEvent(reqCtx, a2apb.TaskState_TASK_STATE_WORKING, false),
+					artifactEvent(reqCtx, "result"),
+					statusEvent(reqCtx, a2apb.TaskState_TASK_STATE_COMPLETED, true),
+				)
+			},
+			wantState: a2apb.TaskState_TASK_STATE_COMPLETED,
+		},
+		{
+			name: "status update without task",
+			execute: func(ctx context.Context, reqCtx *RequestContext, queue EventQueue) error {
+				return writeAll(ctx, queue, statusEvent(reqCtx, a2apb.TaskState_TASK_STATE_INPUT_REQUIRED, false))
+			},
+			wantState: a2apb.TaskState_TASK_STATE_INPUT_REQUIRED,
+		},
+		{
+			name: "message",
+			execute: func(ctx context.Context, reqCtx *RequestContext, queue EventQueue) error {
+				return queue.Write(ctx, &a2apb.StreamResponse{Payload: &a2apb.StreamResponse_Msg{Msg: &a2apb.Message{
+					MessageId: "reply",
+					Role:      a2apb.Role_ROLE_AGENT,
+				}}})
+			},
+			wantMsg: true,
+		},
+		{
+			name: "executor fails after creating the task",
+			execute: func(ctx context.Context, reqCtx *RequestContext, queue EventQueue) error {
+				if err := queue.Write(ctx, taskEvent(reqCtx, a2apb.TaskState_TASK_STATE_WORKING)); err != nil {
+					return err
+				}
+				return errors.New("agent crashed")
+			},
+			wantState: a2apb.TaskState_TASK_STATE_FAILED,
+		},
+		{
+			name: "executor fails without events",
+			execute: func(context.Context, *RequestContext, EventQueue) error {
+				return a2a.ErrUnsupportedOperation
+			},
+			wantErr: a2a.ErrUnsupportedOperation,
+		},
+		{
+			name: "executor produces no events",
+			execute: func(context.Context, *RequestContext, EventQueue) error {
+				return nil
+			},
+			wantErr: a2a.ErrInvalidAgentResponse,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			h := NewHandler(&testExecutor{execute: tc.execute})
+			req := newTestMessage("")
+			resp, err := h.SendMessage(context.Background(), req)
+			if tc.wantErr != nil {
+				if !errors.Is(err, tc.wantErr) {
+					t.Fatalf("SendMessage() error = %v, want %v", err, tc.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("SendMessage() error = %v", err)
+			}
+			if tc.wantMsg {
+				if resp.GetMsg().GetMessageId() != "reply" {
+					t.Errorf("SendMessage() = %v, want the agent message", resp)
+				}
+				return
+			}
+			task := resp.GetTask()
+			if task.GetStatus().GetState() != tc.wantState {
+				t.Errorf("SendMessage() task state = %v, want %v", task.GetStatus().GetState(), tc.wantState)
+			}
+			if len(task.GetHistory()) == 0 || task.GetHistory()[0].GetMessageId() != req.GetRequest().GetMessageId() {
+				t.Errorf("SendMessage() task history = %v, want the request message", task.GetHistory())
+			}
+			stored, err := h.GetTask(context.Background(), &a2apb.GetTaskRequest{Name: a2a.TaskName(task.GetId())})
+			if err != nil {
+				t.Fatalf("GetTask() error = %v", err)
+			}
+			if stored.GetStatus().GetState() != tc.wantState {
+				t.Errorf("GetTask() state = %v, want %v", stored.GetStatus().GetState(), tc.wantState)
+			}
+		})
+	}
+}
+
+func TestHandlerSendMessageNonBlocking(t *testing.T) {
+	release := make(chan struct{})
+	done := make(chan struct{})
+	h := NewHandler(&testExecutor{execute: func(ctx context.Context, reqCtx *RequestContext, queue EventQueue) error {
+		defer close(done)
+		if err := queue.Write(ctx, taskEvent(reqCtx, a2apb.TaskState_TASK_STATE_WORKING)); err != nil {
+			return err
+		}
+		<-release
+		return writeAll(ctx, queue, statusEvent(reqCtx, a2apb.TaskState_TASK_STATE_COMPLETED, true))
+	}})
+	req := newTestMessage("")
+	// The configuration does not set blocking, so the request is non-blocking.
+	req.Configuration = &a2apb.SendMessageConfiguration{HistoryLength: 1}
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	resp, err := h.SendMessage(ctx, req)
+	close(release)
+	if err != nil {
+		t.Fatalf("SendMessage() error = %v", err)
+	}
+	if got := resp.GetTask().GetStatus().GetState(); got != a2apb.TaskState_TASK_STATE_WORKING {
+		t.Errorf("SendMessage() state = %v, want %v", got, a2apb.TaskState_TASK_STATE_WORKING)
+	}
+	<-done
+}
+
+func TestHandlerRejectsInvalidEvents(t *testing.T) {
+	tests := []struct {
+		name  string
+		event func(reqCtx *RequestContext) *a2apb.StreamResponse
+	}{
+		{
+			name: "other task",
+			event: func(reqCtx *RequestContext) *a2apb.StreamResponse {
+				return taskEvent(&RequestContext{TaskID: "other", ContextID: reqCtx.ContextID}, a2apb.TaskState_TASK_STATE_WORKING)
+			},
+		},
+		{
+			name: "update of a terminal task",
+			event: func(reqCtx *RequestContext) *a2apb.StreamResponse {
+				return statusEvent(reqCtx, a2apb.TaskState_TASK_STATE_WORKING, false)
+			},
+		},
+		{
+			name: "artifact of a terminal task",
+			event: func(reqCtx *RequestContext) *a2apb.StreamResponse {
+				return artifactEvent(reqCtx, "late")
+			},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			writeErr := make(chan error, 1)
+			h := NewHandler(&testExecutor{execute: func(ctx context.Context, reqCtx *RequestContext, queue EventQueue) error {
+				if err := queue.Write(ctx, taskEvent(reqCtx, a2apb.TaskState_TASK_STATE_COMPLETED)); err != nil {
+					return err
+				}
+				writeErr <- queue.Write(ctx, tc.event(reqCtx))
+				return nil
+			}})
+			resp, err := h.SendMessage(context.Background(), newTestMessage(""))
+			if err != nil {
+				t.Fatalf("SendMessage() error = %v", err)
+			}
+			if got := resp.GetTask().GetStatus().GetState(); got != a2apb.TaskState_TASK_STATE_COMPLETED {
+				t.Errorf("SendMessage() state = %v, want %v", got, a2apb.TaskState_TASK_STATE_COMPLETED)
+			}
+			// The response is returned once the task is completed, possibly before
+			// the second event is written.
+			if _, err := h.SendMessage(context.Background(), newTestMessage(resp.GetTask().GetId())); !errors.Is(err, a2a.ErrInvalidParams) {
+				t.Errorf("SendMessage() to a terminal task error = %v, want %v", err, a2a.ErrInvalidParams)
+			}
+			if err := <-writeErr; !errors.Is(err, a2a.ErrInvalidAgentResponse) {
+				t.Errorf("Write() error = %v, want %v", err, a2a.ErrInvalidAgentResponse)
+			}
+		})
+	}
+}
+
+func TestHandlerWriteAfterExecuteReturns(t *testing.T) {
+	retained := make(chan EventQueue, 1)
+	h := NewHandler(&testExecutor{execute: func(ctx context.Context, reqCtx *RequestContext, queue EventQueue) error {
+		retained <- queue
+		return queue.Write(ctx, taskEvent(reqCtx, a2apb.TaskState_TASK_STATE_WORKING))
+	}})
+	resp, err := h.SendMessage(context.Background(), newTestMessage(""))
+	if err != nil {
+		t.Fatalf("SendMessage() error = %v", err)
+	}
+	queue := <-retained
+	reqCtx := &RequestContext{TaskID: resp.GetTask().GetId(), ContextID: resp.GetTask().GetContextId()}
+	err = queue.Write(context.Background(), statusEvent(reqCtx, a2apb.TaskState_TASK_STATE_COMPLETED, true))
+	if !errors.Is(err, errExecutionStopped) {
+		t.Errorf("Write() after Execute returned error = %v, want %v", err, errExecutionStopped)
+	}
+}
+
+func TestHandlerConcurrentWritesWhileExecuteReturns(t *testing.T) {
+	// Writes racing with the return of Execute are either processed or rejected.
+	for range 50 {
+		var wg sync.WaitGroup
+		h := NewHandler(&testExecutor{execute: func(ctx context.Context, reqCtx *RequestContext, queue EventQueue) error {
+			if err := queue.Write(ctx, taskEvent(reqCtx, a2apb.TaskState_TASK_STATE_WORKING)); err != nil {
+				return err
+			}
+			for range 5 {
+				wg.Add(1)
+				go func() {
+					defer wg.Done()
+					err := queue.Write(context.Background(), artifactEvent(reqCtx, "chunk"))
+					if err != nil && !errors.Is(err, errExecutionStopped) {
+						t.Errorf("Write() error = %v", err)
+					}
+				}()
+			}
+			return nil
+		}})
+		if _, err := h.SendMessage(context.Background(), newTestMessage("")); err != nil {
+			t.Fatalf("SendMessage() error = %v", err)
+		}
+		wg.Wait()
+	}
+}
+
+func TestHandlerStreaming(t *testing.T) {
+	h := NewHandler(&testExecutor{execute: func(ctx context.Context, reqCtx *RequestContext, queue EventQueue) error {
+		return writeAll(ctx, queue,
+			taskEvent(reqCtx, a2apb.TaskState_TASK_STATE_SUBMITTED),
+			statusEvent(reqCtx, a2apb.TaskState_TASK_STATE_WORKING, false),
+			artifactEvent(reqCtx, "result"),
+			statusEvent(reqCtx, a2apb.TaskState_TASK_STATE_COMPLETED, true),
+		)
+	}})
+	stream := newTestStream(context.Background())
+	if err := h.SendStreamingMessage(newTestMessage(""), stream); err != nil {
+		t.Fatalf("SendStreamingMessage() error = %v", err)
+	}
+	events := stream.received()
+	if len(events) != 4 {
+		t.Fatalf("SendStreamingMessage() sent %d events, want 4: %v", len(events), events)
+	}
+	if events[0].GetTask() == nil || events[2].GetArtifactUpdate() == nil || !events[3].GetStatusUpdate().GetFinal() {
+		t.Errorf("SendStreamingMessage() events = %v, want task, status, artifact and final status", events)
+	}
+}
+
+func TestHandlerCancelTask(t *testing.T) {
+	started := make(chan *RequestContext, 1)
+	canceled := make(chan struct{})
+	h := NewHandler(&testExecutor{execute: func(ctx context.Context, reqCtx *RequestContext, queue EventQueue) error {
+		if err := queue.Write(ctx, taskEvent(reqCtx, a2apb.TaskState_TASK_STATE_WORKING)); err != nil {
+			return err
+		}
+		started <- reqCtx
+		<-ctx.Done()
+		close(canceled)
+		return nil
+	}})
+
+	stream := newTestStream(context.Background())
+	streamErr := make(chan error, 1)
+	go func() { streamErr <- h.SendStreamingMessage(newTestMessage(""), stream) }()
+	reqCtx := <-started
+
+	task, err := h.CancelTask(context.Background(), &a2apb.CancelTaskRequest{Name: a2a.TaskName(reqCtx.TaskID)})
+	if err != nil {
+		t.Fatalf("CancelTask() error = %v", err)
+	}
+	if task.GetStatus().GetState() != a2apb.TaskState_TASK_STATE_CANCELLED {
+		t.Errorf("CancelTask() state = %v, want %v", task.GetStatus().GetState(), a2apb.TaskState_TASK_STATE_CANCELLED)
+	}
+	select {
+	case <-canceled:
+	case <-time.After(time.Second):
+		t.Fatal("the execution context was not canceled")
+	}
+	if err := <-streamErr; err != nil {
+		t.Fatalf("SendStreamingMessage() error = %v", err)
+	}
+	events := stream.received()
+	if last := events[len(events)-1]; last.GetStatusUpdate().GetStatus().GetState() != a2apb.TaskState_TASK_STATE_CANCELLED {
+		t.Errorf("last streamed event = %v, want the cancellation", last)
+	}
+
+	if _, err := h.CancelTask(context.Background(), &a2apb.CancelTaskRequest{Name: a2a.TaskName(reqCtx.TaskID)}); !errors.Is(err, a2a.ErrTaskNotCancelable) {
+		t.Errorf("CancelTask() of a canceled task error = %v, want %v", err, a2a.ErrTaskNotCancelable)
+	}
+	if _, err := h.CancelTask(context.Background(), &a2apb.CancelTaskRequest{Name: a2a.TaskName("missing")}); !errors.Is(err, a2a.ErrTaskNotFound) {
+		t.Errorf("CancelTask() of a missing task error = %v, want %v", err, a2a.ErrTaskNotFound)
+	}
+}
+
+// blockingTaskStore blocks saving a task in the blocked state until release is closed.
+type blockingTaskStore struct {
+	*InMemoryTaskStore
+	state   a2apb.TaskState
+	once    sync.Once
+	blocked chan struct{}
+	release chan struct{}
+}
+
+func (s *blockingTaskStore) Save(ctx context.Context, task *a2apb.Task, prev TaskVersion) (TaskVersion, error) {
+	if task.GetStatus().GetState() == s.state {
+		s.once.Do(func() { close(s.blocked) })
+		<-s.release
+	}
+	return s.InMemoryTaskStore.Save(ctx, task, prev)
+}
+
+func TestHandlerCancelTaskOfFinishingExecution(t *testing.T) {
+	store := &blockingTaskStore{
+		InMemoryTaskStore: NewInMemoryTaskStore(),
+		state:             a2apb.TaskState_TASK_STATE_FAILED,
+		blocked:           make(chan struct{}),
+		release:           make(chan struct{}),
+	}
+	started := make(chan *RequestContext, 1)
+	h := NewHandler(&testExecutor{execute: func(ctx context.Context, reqCtx *RequestContext, queue EventQueue) error {
+		if err := queue.Write(ctx, taskEvent(reqCtx, a2apb.TaskState_TASK_STATE_WORKING)); err != nil {
+			return err
+		}
+		started <- reqCtx
+		return errors.New("agent crashed")
+	}}, WithTaskStore(store))
+
+	stream := newTestStream(context.Background())
+	streamErr := make(chan error, 1)
+	go func() { streamErr <- h.SendStreamingMessage(newTestMessage(""), stream) }()
+	reqCtx := <-started
+	// The execution has no producers left and is failing the task.
+	<-store.blocked
+
+	cancelErr := make(chan error, 1)
+	go func() {
+		_, err := h.CancelTask(context.Background(), &a2apb.CancelTaskRequest{Name: a2a.TaskName(reqCtx.TaskID)})
+		cancelErr <- err
+	}()
+	time.Sleep(50 * time.Millisecond)
+	close(store.release)
+
+	if err := <-cancelErr; !errors.Is(err, a2a.ErrTaskNotCancelable) {
+		t.Errorf("CancelTask() error = %v, want %v", err, a2a.ErrTaskNotCancelable)
+	}
+	if err := <-streamErr; err != nil {
+		t.Fatalf("SendStreamingMessage() error = %v", err)
+	}
+	task, err := h.GetTask(context.Background(), &a2apb.GetTaskRequest{Name: a2a.TaskName(reqCtx.TaskID)})
+	if err != nil {
+		t.Fatalf("GetTask() error = %v", err)
+	}
+	if task.GetStatus().GetState() != a2apb.TaskState_TASK_STATE_FAILED {
+		t.Errorf("GetTask() state = %v, want %v", task.GetStatus().GetState(), a2apb.TaskState_TASK_STATE_FAILED)
+	}
+}
+
+func TestHandlerMultiTurn(t *testing.T) {
+	h := NewHandler(&testExecutor{execute: func(ctx context.Context, reqCtx *RequestContext, queue EventQueue) error {
+		if reqCtx.Task == nil {
+			return writeAll(ctx, queue, statusEvent(reqCtx, a2apb.TaskState_TASK_STATE_INPUT_REQUIRED, true))
+		}
+		return writeAll(ctx, queue, statusEvent(reqCtx, a2apb.TaskState_TASK_STATE_COMPLETED, true))
+	}})
+	first, err := h.SendMessage(context.Background(), newTestMessage(""))
+	if err != nil {
+		t.Fatalf("SendMessage() error = %v", err)
+	}
+	if got := first.GetTask().GetStatus().GetState(); got != a2apb.TaskState_TASK_STATE_INPUT_REQUIRED {
+		t.Fatalf("SendMessage() state = %v, want %v", got, a2apb.TaskState_TASK_STATE_INPUT_REQUIRED)
+	}
+	second, err := h.SendMessage(context.Background(), newTestMessage(first.GetTask().GetId()))
+	if err != nil {
+		t.Fatalf("SendMessage() to the interrupted task error = %v", err)
+	}
+	task := second.GetTask()
+	if task.GetStatus().GetState() != a2apb.TaskState_TASK_STATE_COMPLETED || len(task.GetHistory()) != 2 {
+		t.Errorf("SendMessage() = %v, want a completed task with both messages in history", task)
+	}
+}
+
+func TestHandlerInvalidRequests(t *testing.T) {
+	h := NewHandler(&testExecutor{execute: func(context.Context, *RequestContext, EventQueue) error { return nil }})
+	ctx := context.Background()
+	tests := []struct {
+		name    string
+		call    func() error
+		wantErr error
+	}{
+		{
+			name: "missing message",
+			call: func() error {
+				_, err := h.SendMessage(ctx, &a2apb.SendMessageRequest{})
+				return err
+			},
+			wantErr: a2a.ErrInvalidParams,
+		},
+		{
+			name: "missing message id",
+			call: func() error {
+				_, err := h.SendMessage(ctx, &a2apb.SendMessageRequest{Request: &a2apb.Message{Role: a2apb.Role_ROLE_USER}})
+				return err
+			},
+			wantErr: a2a.ErrInvalidParams,
+		},
+		{
+			name: "unknown task",
+			call: func() error {
+				_, err := h.SendMessage(ctx, newTestMessage("missing"))
+				return err
+			},
+			wantErr: a2a.ErrTaskNotFound,
+		},
+		{
+			name: "invalid task name",
+			call: func() error {
+				_, err := h.GetTask(ctx, &a2apb.GetTaskRequest{Name: "t1"})
+				return err
+			},
+			wantErr: a2a.ErrInvalidParams,
+		},
+		{
+			name: "push notifications not supported",
+			call: func() error {
+				_, err := h.CreateTaskPushNotificationConfig(ctx, &a2apb.CreateTaskPushNotificationConfigRequest{
+					Parent: a2a.TaskName("t1"),
+					Config: &a2apb.TaskPushNotificationConfig{PushNotificationConfig: &a2apb.PushNotificationConfig{Url: "https://example.com"}},
+				})
+				return err
+			},
+			wantErr: a2a.ErrPushNotificationNotSupported,
+		},
+		{
+			name: "agent card not configured",
+			call: func() error {
+				_, err := h.GetAgentCard(ctx, &a2apb.GetAgentCardRequest{})
+				return err
+			},
+			wantErr: a2a.ErrUnsupportedOperation,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if err := tc.call(); !errors.Is(err, tc.wantErr) {
+				t.Errorf("error = %v, want %v", err, tc.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,36 @@
+// Copyright 2025 The A2A Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package a2asrv
+
+import (
+	"context"
+
+	a2apb "github.com/a2aproject/a2a-go/grpc"
+)
+
+// PushNotifier delivers push notifications about task changes to the webhooks
+// registered by clients. The push package provides an HTTP implementation.
+type PushNotifier interface {
+	// Notify delivers the task to the webhooks of the configs.
+	Notify(ctx context.Context, task *a2apb.Task, configs []*a2apb.PushNotificationConfig)
+}
+
+// PushURLValidator checks that push notifications may be delivered to a URL. It is
+// used to reject configs pointing at addresses clients must not reach through the
+// agent, such as internal services. The push package provides an implementation.
+type PushURLValidator interface {
+	// ValidateURL returns an error if notifications must not be sent to the URL.
+	ValidateURL(ctx context.Context, rawURL string) error
+}
@@ -0,0 +1,98 @@
+// Copyright 2025 The A2A Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package a2asrv
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/a2aproject/a2a-go/a2a"
+	a2apb "github.com/a2aproject/a2a-go/grpc"
+)
+
+// recordingNotifier is a PushNotifier keeping the states of the notified tasks.
+type recordingNotifier struct {
+	mu       sync.Mutex
+	states   []a2apb.TaskState
+	notified chan struct{}
+}
+
+func newRecordingNotifier() *recordingNotifier {
+	return &recordingNotifier{notified: make(chan struct{}, 16)}
+}
+
+func (n *recordingNotifier) Notify(_ context.Context, task *a2apb.Task, configs []*a2apb.PushNotificationConfig) {
+	n.mu.Lock()
+	n.states = append(n.states, task.GetStatus().GetState())
+	n.mu.Unlock()
+	n.notified <- struct{}{}
+}
+
+func TestHandlerPushNotifications(t *testing.T) {
+	ctx := context.Background()
+	notifier := newRecordingNotifier()
+	executor := &testExecutor{execute: func(ctx context.Context, reqCtx *RequestContext, queue EventQueue) error {
+		return writeAll(ctx, queue,
+			statusEvent(reqCtx, a2apb.TaskState_TASK_STATE_WORKING, false),
+			statusEvent(reqCtx, a2apb.TaskState_TASK_STATE_COMPLETED, true),
+		)
+	}}
+	h := NewHandler(executor, WithPushNotifier(notifier))
+	req := newTestMessage("")
+	req.Configuration = &a2apb.SendMessageConfiguration{
+		Blocking:         true,
+		PushNotification: &a2apb.PushNotificationConfig{Url: "https://client.example.com/webhook"},
+	}
+	if _, err := h.SendMessage(ctx, req); err != nil {
+		t.Fatalf("SendMessage() error = %v", err)
+	}
+
+	for {
+		select {
+		case <-notifier.notified:
+		case <-time.After(5 * time.Second):
+			t.Fatalf("notified states = %v, want the completed state", notifier.states)
+		}
+		notifier.mu.Lock()
+		states := append([]a2apb.TaskState(nil), notifier.states...)
+		notifier.mu.Unlock()
+		if states[len(states)-1] != a2apb.TaskState_TASK_STATE_COMPLETED {
+			continue
+		}
+		// The notifications of a task are delivered in order.
+		for i := 1; i < len(states); i++ {
+			if states[i] < states[i-1] {
+				t.Errorf("notified states = %v, want them in order", states)
+			}
+		}
+		return
+	}
+}
+
+func TestHandlerPushNotificationsNotSupported(t *testing.T) {
+	h := NewHandler(&testExecutor{})
+	_, err := h.CreateTaskPushNotificationConfig(context.Background(), &a2apb.CreateTaskPushNotificationConfigRequest{
+		Parent: "tasks/t1",
+		Config: &a2apb.TaskPushNotificationConfig{
+			PushNotificationConfig: &a2apb.PushNotificationConfig{Url: "https://client.example.com/webhook"},
+		},
+	})
+	if !errors.Is(err, a2a.ErrPushNotificationNotSupported) {
+		t.Errorf("CreateTaskPushNotificationConfig() error = %v, want %v", err, a2a.ErrPushNotificationNotSupported)
+	}
+}
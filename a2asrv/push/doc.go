@@ -0,0 +1,45 @@
+// Copyright 2025 The A2A Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package push delivers A2A push notifications to the webhooks registered by clients.
+//
+// Sender POSTs the JSON representation of a task to the URL of every push
+// notification config of the task. It sets the notification token header,
+// authenticates the request with one of the schemes requested by the config,
+// retries failed deliveries with exponential backoff and reports the outcome
+// of every delivery:
+//
+//	sender := push.NewSender(push.WithRecorder(recorder.Record))
+//	handler := a2asrv.NewHandler(executor, a2asrv.WithPushNotifier(sender))
+//
+// With WithSigner, every notification carries a JWS in a2a.NotificationSignatureHeader
+// proving it was sent by the agent for the exact body received. The public keys are
+// published by a JWKSHandler, which receivers use to verify the signatures, for example
+// with the webhook package of the client:
+//
+//	signer, err := push.NewSigner("key-1", privateKey)
+//	jwks, err := push.NewJWKSHandler(signer)
+//	http.Handle(a2a.WellKnownJWKSPath, jwks)
+//	sender := push.NewSender(push.WithSigner(signer))
+//
+// URLValidator protects the agent from clients registering webhooks at internal
+// addresses. The same validator should reject such configs when they are created
+// and be used by the Sender, which checks the addresses again when connecting:
+//
+//	validator := push.NewURLValidator(push.WithAllowedHosts("*.example.com"))
+//	sender := push.NewSender(push.WithURLValidator(validator))
+//	handler := a2asrv.NewHandler(executor,
+//		a2asrv.WithPushNotifier(sender),
+//		a2asrv.WithPushURLValidator(validator))
+package push
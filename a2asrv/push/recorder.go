@@ -0,0 +1,58 @@
+// Copyright 2025 The A2A Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package push
+
+import "sync"
+
+// MemoryRecorder keeps the most recent delivery outcomes in memory.
+// Its Record method can be passed to WithRecorder.
+type MemoryRecorder struct {
+	limit int
+
+	mu       sync.Mutex
+	outcomes []Outcome
+}
+
+// NewMemoryRecorder returns a recorder keeping at most limit outcomes.
+func NewMemoryRecorder(limit int) *MemoryRecorder {
+	return &MemoryRecorder{limit: limit}
+}
+
+// Record stores the outcome, discarding the oldest one when the limit is reached.
+func (r *MemoryRecorder) Record(outcome Outcome) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.limit <= 0 {
+		return
+	}
+	if len(r.outcomes) == r.limit {
+		r.outcomes = append(r.outcomes[:0], r.outcomes[1:]...)
+	}
+	r.outcomes = append(r.outcomes, outcome)
+}
+
+// Outcomes returns the recorded outcomes of the task, oldest first.
+// An empty task ID returns the outcomes of all tasks.
+func (r *MemoryRecorder) Outcomes(taskID string) []Outcome {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var result []Outcome
+	for _, o := range r.outcomes {
+		if taskID == "" || o.TaskID == taskID {
+			result = append(result, o)
+		}
+	}
+	return result
+}
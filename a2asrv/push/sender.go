@@ -0,0 +1,301 @@
+// Copyright 2025 The A2A Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package push
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math/rand/v2"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/a2aproject/a2a-go/a2a"
+	"github.com/a2aproject/a2a-go/a2asrv"
+	a2apb "github.com/a2aproject/a2a-go/grpc"
+)
+
+// TokenHeader is the header carrying the token of the push notification config.
+const TokenHeader = a2a.NotificationTokenHeader
+
+// Default retry settings of a Sender.
+const (
+	DefaultMaxAttempts    = 5
+	DefaultInitialBackoff = 500 * time.Millisecond
+	DefaultMaxBackoff     = 30 * time.Second
+)
+
+// ErrUnsupportedScheme is reported when none of the authentication schemes
+// requested by a push notification config is supported by the Sender.
+var ErrUnsupportedScheme = errors.New("unsupported authentication scheme")
+
+// Authenticator adds the credentials of a push notification config to a request.
+type Authenticator func(req *http.Request, auth *a2apb.AuthenticationInfo) error
+
+// Outcome describes the result of the delivery of a notification to a webhook.
+type Outcome struct {
+	// TaskID is the identifier of the task the notification is about.
+	TaskID string
+	// ConfigID is the identifier of the push notification config.
+	ConfigID string
+	// URL is the address of the webhook.
+	URL string
+	// State is the state of the task which was notified.
+	State a2apb.TaskState
+	// Attempts is the number of requests sent.
+	Attempts int
+	// StatusCode is the HTTP status of the last response, or 0 if none was received.
+	StatusCode int
+	// Err is the reason of the failure, or nil if the notification was delivered.
+	Err error
+	// Time is when the delivery finished.
+	Time time.Time
+}
+
+// Delivered reports whether the webhook accepted the notification.
+func (o Outcome) Delivered() bool {
+	return o.Err == nil
+}
+
+// Option configures a Sender.
+type Option func(*Sender)
+
+// WithHTTPClient sets the client used to deliver notifications. http.DefaultClient
+// is used by default, or the Client of the URLValidator if one is configured.
+func WithHTTPClient(client *http.Client) Option {
+	return func(s *Sender) {
+		s.client = client
+	}
+}
+
+// WithRetry sets the maximum number of delivery attempts and the bounds of the
+// exponential backoff between them.
+func WithRetry(maxAttempts int, initialBackoff, maxBackoff time.Duration) Option {
+	return func(s *Sender) {
+		s.maxAttempts = maxAttempts
+		s.initialBackoff = initialBackoff
+		s.maxBackoff = maxBackoff
+	}
+}
+
+// WithAuthenticator registers the authenticator for the scheme, replacing the default
+// one if any. Schemes are matched case-insensitively. Bearer and Basic schemes are
+// supported by default, both using the credentials of the config as they are.
+func WithAuthenticator(scheme string, authenticate Authenticator) Option {
+	return func(s *Sender) {
+		s.authenticators[strings.ToLower(scheme)] = authenticate
+	}
+}
+
+// WithURLValidator makes the Sender validate the URL of every config before delivering
+// notifications to it, and connect to webhooks through the validator unless a client
+// is set with WithHTTPClient. Deliveries to forbidden URLs fail with ErrForbiddenURL
+// and are not retried.
+func WithURLValidator(validator *URLValidator) Option {
+	return func(s *Sender) {
+		s.validator = validator
+	}
+}
+
+// WithSigner signs every notification with the signer. Receivers verify the signature
+// with the keys published by a JWKSHandler.
+func WithSigner(signer *Signer) Option {
+	return func(s *Sender) {
+		s.signer = signer
+	}
+}
+
+// WithRecorder sets a function called with the outcome of every delivery.
+func WithRecorder(record func(Outcome)) Option {
+	return func(s *Sender) {
+		s.record = record
+	}
+}
+
+// Sender delivers push notifications over HTTP. It implements a2asrv.PushNotifier.
+type Sender struct {
+	client         *http.Client
+	maxAttempts    int
+	initialBackoff time.Duration
+	maxBackoff     time.Duration
+	authenticators map[string]Authenticator
+	signer         *Signer
+	validator      *URLValidator
+	record         func(Outcome)
+}
+
+var _ a2asrv.PushNotifier = (*Sender)(nil)
+
+// NewSender returns a Sender configured with the options.
+func NewSender(opts ...Option) *Sender {
+	s := &Sender{
+		maxAttempts:    DefaultMaxAttempts,
+		initialBackoff: DefaultInitialBackoff,
+		maxBackoff:     DefaultMaxBackoff,
+		authenticators: map[string]Authenticator{
+			"bearer": schemeAuthenticator("Bearer"),
+			"basic":  schemeAuthenticator("Basic"),
+		},
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	if s.client == nil {
+		s.client = http.DefaultClient
+		if s.validator != nil {
+			s.client = s.validator.Client()
+		}
+	}
+	if s.maxAttempts < 1 {
+		s.maxAttempts = 1
+	}
+	return s
+}
+
+// Notify implements a2asrv.PushNotifier. It delivers the task to all the configs
+// concurrently and waits for the deliveries to finish.
+func (s *Sender) Notify(ctx context.Context, task *a2apb.Task, configs []*a2apb.PushNotificationConfig) {
+	var wg sync.WaitGroup
+	for _, config := range configs {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			s.Send(ctx, task, config)
+		}()
+	}
+	wg.Wait()
+}
+
+// Send delivers the task to the webhook of the config, retrying failed attempts.
+// Network errors, 429 and 5xx responses are retried, other failures are not.
+func (s *Sender) Send(ctx context.Context, task *a2apb.Task, config *a2apb.PushNotificationConfig) Outcome {
+	outcome := Outcome{
+		TaskID:   task.GetId(),
+		ConfigID: config.GetId(),
+		URL:      config.GetUrl(),
+		State:    task.GetStatus().GetState(),
+	}
+	outcome.StatusCode, outcome.Err = s.deliver(ctx, task, config, &outcome.Attempts)
+	outcome.Time = time.Now()
+	if s.record != nil {
+		s.record(outcome)
+	}
+	return outcome
+}
+
+func (s *Sender) deliver(ctx context.Context, task *a2apb.Task, config *a2apb.PushNotificationConfig, attempts *int) (int, error) {
+	t, err := a2a.TaskFromProto(task)
+	if err != nil {
+		return 0, fmt.Errorf("failed to convert task: %w", err)
+	}
+	body, err := json.Marshal(t)
+	if err != nil {
+		return 0, fmt.Errorf("failed to encode task: %w", err)
+	}
+	authenticate, err := s.authenticator(config.GetAuthentication())
+	if err != nil {
+		return 0, err
+	}
+	if s.validator != nil {
+		// Resolution failures are left to the attempts, which retry them.
+		if err := s.validator.ValidateURL(ctx, config.GetUrl()); errors.Is(err, ErrForbiddenURL) {
+			return 0, err
+		}
+	}
+
+	backoff := s.initialBackoff
+	for {
+		*attempts++
+		status, retry, err := s.post(ctx, config, body, authenticate)
+		if err == nil || !retry || *attempts >= s.maxAttempts {
+			return status, err
+		}
+		// Full jitter spreads the retries of notifications failing at the same time.
+		delay := time.Duration(rand.Int64N(int64(backoff) + 1))
+		select {
+		case <-time.After(delay):
+		case <-ctx.Done():
+			return status, errors.Join(err, ctx.Err())
+		}
+		backoff = min(backoff*2, s.maxBackoff)
+	}
+}
+
+// post sends a single request. It reports whether a failed request can be retried.
+func (s *Sender) post(ctx context.Context, config *a2apb.PushNotificationConfig, body []byte, authenticate Authenticator) (int, bool, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, config.GetUrl(), bytes.NewReader(body))
+	if err != nil {
+		return 0, false, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if config.GetToken() != "" {
+		req.Header.Set(TokenHeader, config.GetToken())
+	}
+	if s.signer != nil {
+		// Every attempt is signed again so that iat reflects the time it was sent.
+		signature, err := s.signer.Sign(body)
+		if err != nil {
+			return 0, false, fmt.Errorf("failed to sign notification: %w", err)
+		}
+		req.Header.Set(a2a.NotificationSignatureHeader, signature)
+	}
+	if authenticate != nil {
+		if err := authenticate(req, config.GetAuthentication()); err != nil {
+			return 0, false, fmt.Errorf("failed to authenticate: %w", err)
+		}
+	}
+	resp, err := s.client.Do(req)
+	if err != nil {
+		retry := ctx.Err() == nil && !errors.Is(err, ErrForbiddenURL)
+		return 0, retry, fmt.Errorf("failed to send notification: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 1<<16))
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		return resp.StatusCode, false, nil
+	}
+	retry := resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= 500
+	return resp.StatusCode, retry, fmt.Errorf("webhook responded with HTTP status %q", resp.Status)
+}
+
+// authenticator returns the authenticator of the first supported scheme of the config,
+// or nil if the config does not require authentication.
+func (s *Sender) authenticator(auth *a2apb.AuthenticationInfo) (Authenticator, error) {
+	if len(auth.GetSchemes()) == 0 {
+		return nil, nil
+	}
+	for _, scheme := range auth.GetSchemes() {
+		if authenticate, ok := s.authenticators[strings.ToLower(scheme)]; ok {
+			return authenticate, nil
+		}
+	}
+	return nil, fmt.Errorf("%w: %s", ErrUnsupportedScheme, strings.Join(auth.GetSchemes(), ", "))
+}
+
+// schemeAuthenticator sets the Authorization header with the credentials of the config.
+func schemeAuthenticator(scheme string) Authenticator {
+	return func(req *http.Request, auth *a2apb.AuthenticationInfo) error {
+		if auth.GetCredentials() == "" {
+			return fmt.Errorf("%s scheme requires credentials", scheme)
+		}
+		req.Header.Set("Authorization", scheme+" "+auth.GetCredentials())
+		return nil
+	}
+}
@@ -0,0 +1,246 @@
+// Copyright 2025 The A2A Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package push
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/a2aproject/a2a-go/a2a"
+	a2apb "github.com/a2aproject/a2a-go/grpc"
+)
+
+// webhook is a test server responding with the statuses in order, then 200.
+type webhook struct {
+	*httptest.Server
+
+	mu       sync.Mutex
+	statuses []int
+	requests []*http.Request
+	bodies   [][]byte
+}
+
+func newWebhook(t *testing.T, statuses ...int) *webhook {
+	t.Helper()
+	w := &webhook{statuses: statuses}
+	w.Server = httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		w.mu.Lock()
+		w.requests = append(w.requests, r)
+		w.bodies = append(w.bodies, body)
+		status := http.StatusOK
+		if len(w.statuses) > 0 {
+			status, w.statuses = w.statuses[0], w.statuses[1:]
+		}
+		w.mu.Unlock()
+		rw.WriteHeader(status)
+	}))
+	t.Cleanup(w.Close)
+	return w
+}
+
+func (w *webhook) received() ([]*http.Request, [][]byte) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return append([]*http.Request(nil), w.requests...), append([][]byte(nil), w.bodies...)
+}
+
+func newTestTask() *a2apb.Task {
+	return &a2apb.Task{
+		Id:        "t1",
+		ContextId: "c1",
+		Status:    &a2apb.TaskStatus{State: a2apb.TaskState_TASK_STATE_COMPLETED},
+	}
+}
+
+func fastRetry(maxAttempts int) Option {
+	return WithRetry(maxAttempts, time.Millisecond, 2*time.Millisecond)
+}
+
+func TestSenderSend(t *testing.T) {
+	tests := []struct {
+		name         string
+		statuses     []int
+		wantAttempts int
+		wantStatus   int
+		wantErr      bool
+	}{
+		{name: "delivered", wantAttempts: 1, wantStatus: http.StatusOK},
+		{name: "accepted", statuses: []int{http.StatusAccepted}, wantAttempts: 1, wantStatus: http.StatusAccepted},
+		{name: "retried server error", statuses: []int{http.StatusServiceUnavailable, http.StatusInternalServerError}, wantAttempts: 3, wantStatus: http.StatusOK},
+		{name: "retried rate limit", statuses: []int{http.StatusTooManyRequests}, wantAttempts: 2, wantStatus: http.StatusOK},
+		{name: "client error", statuses: []int{http.StatusBadRequest}, wantAttempts: 1, wantStatus: http.StatusBadRequest, wantErr: true},
+		{name: "redirect", statuses: []int{http.StatusNotModified}, wantAttempts: 1, wantStatus: http.StatusNotModified, wantErr: true},
+		{
+			name:         "attempts exhausted",
+			statuses:     []int{http.StatusBadGateway, http.StatusBadGateway, http.StatusBadGateway, http.StatusBadGateway},
+			wantAttempts: 3,
+			wantStatus:   http.StatusBadGateway,
+			wantErr:      true,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			hook := newWebhook(t, tc.statuses...)
+			recorder := NewMemoryRecorder(10)
+			s := NewSender(fastRetry(3), WithRecorder(recorder.Record))
+			config := &a2apb.PushNotificationConfig{Id: "p1", Url: hook.URL, Token: "secret"}
+			outcome := s.Send(context.Background(), newTestTask(), config)
+			if (outcome.Err != nil) != tc.wantErr || outcome.Delivered() == tc.wantErr {
+				t.Fatalf("Send() error = %v, want error: %v", outcome.Err, tc.wantErr)
+			}
+			if outcome.Attempts != tc.wantAttempts || outcome.StatusCode != tc.wantStatus {
+				t.Errorf("Send() = %d attempts with status %d, want %d with %d", outcome.Attempts, outcome.StatusCode, tc.wantAttempts, tc.wantStatus)
+			}
+			if outcome.TaskID != "t1" || outcome.ConfigID != "p1" || outcome.URL != hook.URL ||
+				outcome.State != a2apb.TaskState_TASK_STATE_COMPLETED || outcome.Time.IsZero() {
+				t.Errorf("Send() = %+v, want the outcome of the delivery of t1 to p1", outcome)
+			}
+			if got := recorder.Outcomes("t1"); len(got) != 1 || got[0].Attempts != outcome.Attempts {
+				t.Errorf("recorded outcomes = %+v, want the outcome of Send()", got)
+			}
+
+			requests, bodies := hook.received()
+			if len(requests) != tc.wantAttempts {
+				t.Fatalf("the webhook received %d requests, want %d", len(requests), tc.wantAttempts)
+			}
+			req := requests[0]
+			if req.Method != http.MethodPost || req.Header.Get("Content-Type") != "application/json" || req.Header.Get(TokenHeader) != "secret" {
+				t.Errorf("request = %s with headers %v, want a JSON POST with the token", req.Method, req.Header)
+			}
+			var task a2a.Task
+			if err := json.Unmarshal(bodies[0], &task); err != nil || task.ID != "t1" || task.ContextID != "c1" {
+				t.Errorf("request body = %s, want the task as JSON", bodies[0])
+			}
+		})
+	}
+}
+
+func TestSenderNetworkError(t *testing.T) {
+	hook := newWebhook(t)
+	hook.Close()
+	outcome := NewSender(fastRetry(2)).Send(context.Background(), newTestTask(), &a2apb.PushNotificationConfig{Url: hook.URL})
+	if outcome.Delivered() || outcome.Attempts != 2 || outcome.StatusCode != 0 {
+		t.Errorf("Send() = %+v, want 2 failed attempts without status", outcome)
+	}
+}
+
+func TestSenderCanceledDuringBackoff(t *testing.T) {
+	hook := newWebhook(t, http.StatusServiceUnavailable)
+	ctx, cancel := context.WithCancel(context.Background())
+	s := NewSender(WithRetry(5, time.Hour, time.Hour), WithRecorder(func(Outcome) {}))
+	time.AfterFunc(50*time.Millisecond, cancel)
+	done := make(chan Outcome, 1)
+	go func() { done <- s.Send(ctx, newTestTask(), &a2apb.PushNotificationConfig{Url: hook.URL}) }()
+	select {
+	case outcome := <-done:
+		if !errors.Is(outcome.Err, context.Canceled) || outcome.Attempts != 1 {
+			t.Errorf("Send() = %+v, want a canceled delivery after 1 attempt", outcome)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("Send() did not return after the context was canceled")
+	}
+}
+
+func TestSenderAuthentication(t *testing.T) {
+	custom := func(req *http.Request, auth *a2apb.AuthenticationInfo) error {
+		req.Header.Set("X-Api-Key", auth.GetCredentials())
+		return nil
+	}
+	tests := []struct {
+		name       string
+		auth       *a2apb.AuthenticationInfo
+		wantHeader string
+		wantValue  string
+		wantErr    error
+	}{
+		{name: "bearer", auth: &a2apb.AuthenticationInfo{Schemes: []string{"Bearer"}, Credentials: "abc"}, wantHeader: "Authorization", wantValue: "Bearer abc"},
+		{name: "basic", auth: &a2apb.AuthenticationInfo{Schemes: []string{"basic"}, Credentials: "dXNlcjpwdw=="}, wantHeader: "Authorization", wantValue: "Basic dXNlcjpwdw=="},
+		{name: "first supported scheme", auth: &a2apb.AuthenticationInfo{Schemes: []string{"Digest", "ApiKey"}, Credentials: "k1"}, wantHeader: "X-Api-Key", wantValue: "k1"},
+		{name: "no authentication", auth: &a2apb.AuthenticationInfo{}, wantHeader: "Authorization"},
+		{name: "unsupported scheme", auth: &a2apb.AuthenticationInfo{Schemes: []string{"Digest"}}, wantErr: ErrUnsupportedScheme},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			hook := newWebhook(t)
+			s := NewSender(WithAuthenticator("apikey", custom))
+			outcome := s.Send(context.Background(), newTestTask(), &a2apb.PushNotificationConfig{Url: hook.URL, Authentication: tc.auth})
+			if !errors.Is(outcome.Err, tc.wantErr) {
+				t.Fatalf("Send() error = %v, want %v", outcome.Err, tc.wantErr)
+			}
+			requests, _ := hook.received()
+			if tc.wantErr != nil {
+				if len(requests) != 0 {
+					t.Errorf("the webhook received %d requests, want none", len(requests))
+				}
+				return
+			}
+			if got := requests[0].Header.Get(tc.wantHeader); got != tc.wantValue {
+				t.Errorf("%s header = %q, want %q", tc.wantHeader, got, tc.wantValue)
+			}
+		})
+	}
+}
+
+func TestSenderNotify(t *testing.T) {
+	hooks := []*webhook{newWebhook(t), newWebhook(t), newWebhook(t, http.StatusBadRequest)}
+	recorder := NewMemoryRecorder(10)
+	var configs []*a2apb.PushNotificationConfig
+	for _, hook := range hooks {
+		configs = append(configs, &a2apb.PushNotificationConfig{Url: hook.URL})
+	}
+	NewSender(WithRecorder(recorder.Record)).Notify(context.Background(), newTestTask(), configs)
+	for i, hook := range hooks {
+		if requests, _ := hook.received(); len(requests) != 1 {
+			t.Errorf("webhook %d received %d requests, want 1", i, len(requests))
+		}
+	}
+	delivered := 0
+	for _, outcome := range recorder.Outcomes("") {
+		if outcome.Delivered() {
+			delivered++
+		}
+	}
+	if delivered != 2 {
+		t.Errorf("%d notifications delivered, want 2", delivered)
+	}
+}
+
+func TestMemoryRecorder(t *testing.T) {
+	recorder := NewMemoryRecorder(3)
+	for i, taskID := range []string{"t1", "t2", "t1", "t2", "t1"} {
+		recorder.Record(Outcome{TaskID: taskID, Attempts: i})
+	}
+	all := recorder.Outcomes("")
+	if len(all) != 3 || all[0].Attempts != 2 || all[2].Attempts != 4 {
+		t.Errorf("Outcomes() = %+v, want the 3 most recent outcomes, oldest first", all)
+	}
+	if got := recorder.Outcomes("t2"); len(got) != 1 || got[0].Attempts != 3 {
+		t.Errorf("Outcomes(t2) = %+v, want the recent outcome of t2", got)
+	}
+
+	disabled := NewMemoryRecorder(0)
+	disabled.Record(Outcome{TaskID: "t1"})
+	if got := disabled.Outcomes(""); len(got) != 0 {
+		t.Errorf("Outcomes() of a recorder without capacity = %+v, want none", got)
+	}
+}
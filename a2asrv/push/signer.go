@@ -0,0 +1,100 @@
+// Copyright 2025 The A2A Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package push
+
+import (
+	"crypto"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/a2aproject/a2a-go/a2a"
+	"github.com/a2aproject/a2a-go/internal/jwt"
+)
+
+// Signer signs push notifications with a private key. ECDSA P-256 and P-384, RSA
+// and Ed25519 keys are supported.
+type Signer struct {
+	kid string
+	key crypto.Signer
+	alg string
+}
+
+// NewSigner returns a Signer using the key identified by kid in the published JWKS.
+func NewSigner(kid string, key crypto.Signer) (*Signer, error) {
+	if kid == "" {
+		return nil, errors.New("key ID is required")
+	}
+	alg, err := jwt.Algorithm(key.Public())
+	if err != nil {
+		return nil, err
+	}
+	return &Signer{kid: kid, key: key, alg: alg}, nil
+}
+
+// KeyID returns the identifier of the signing key.
+func (s *Signer) KeyID() string {
+	return s.kid
+}
+
+// Sign returns the compact JWS of a notification with the body.
+func (s *Signer) Sign(body []byte) (string, error) {
+	sum := sha256.Sum256(body)
+	claims := map[string]any{
+		"iat":                         time.Now().Unix(),
+		a2a.NotificationBodyHashClaim: hex.EncodeToString(sum[:]),
+	}
+	return jwt.Sign(jwt.Header{Alg: s.alg, Kid: s.kid, Typ: "JWT"}, claims, s.key)
+}
+
+// JWKSHandler is an http.Handler serving the public keys of signers as a JSON Web
+// Key Set. It is expected to be mounted at a2a.WellKnownJWKSPath of the agent server.
+type JWKSHandler struct {
+	data []byte
+}
+
+// NewJWKSHandler returns a JWKSHandler publishing the keys of the signers. Keys which
+// are being rotated out should stay published until the notifications they signed
+// are no longer verified.
+func NewJWKSHandler(signers ...*Signer) (*JWKSHandler, error) {
+	set := jwt.JWKS{Keys: []jwt.JWK{}}
+	for _, s := range signers {
+		key, err := jwt.NewJWK(s.kid, s.key.Public())
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode key %q: %w", s.kid, err)
+		}
+		set.Keys = append(set.Keys, key)
+	}
+	data, err := json.Marshal(set)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode key set: %w", err)
+	}
+	return &JWKSHandler{data: data}, nil
+}
+
+// ServeHTTP implements http.Handler.
+func (h *JWKSHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		w.Header().Set("Allow", "GET, HEAD")
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	_, _ = w.Write(h.data)
+}
@@ -0,0 +1,121 @@
+// Copyright 2025 The A2A Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package push
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/a2aproject/a2a-go/internal/jwt"
+)
+
+func TestNewSigner(t *testing.T) {
+	p256, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	p224, err := ecdsa.GenerateKey(elliptic.P224(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := NewSigner("", p256); err == nil {
+		t.Error("NewSigner() without key ID error = nil, want error")
+	}
+	if _, err := NewSigner("k1", p224); err == nil {
+		t.Error("NewSigner() with a P-224 key error = nil, want error")
+	}
+	signer, err := NewSigner("k1", p256)
+	if err != nil {
+		t.Fatalf("NewSigner() error = %v", err)
+	}
+	if signer.KeyID() != "k1" {
+		t.Errorf("KeyID() = %q, want k1", signer.KeyID())
+	}
+}
+
+func TestJWKSHandler(t *testing.T) {
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	current, _ := NewSigner("current", ecKey)
+	previous, _ := NewSigner("previous", rsaKey)
+	handler, err := NewJWKSHandler(current, previous)
+	if err != nil {
+		t.Fatalf("NewJWKSHandler() error = %v", err)
+	}
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/.well-known/jwks.json", nil))
+	if rec.Code != http.StatusOK || rec.Header().Get("Content-Type") != "application/json" {
+		t.Fatalf("GET status = %d with content type %q, want 200 with JSON", rec.Code, rec.Header().Get("Content-Type"))
+	}
+	var set jwt.JWKS
+	if err := json.Unmarshal(rec.Body.Bytes(), &set); err != nil {
+		t.Fatalf("failed to decode key set: %v", err)
+	}
+	var raw struct {
+		Keys []map[string]any `json:"keys"`
+	}
+	if err := json.Unmarshal(rec.Body.Bytes(), &raw); err != nil {
+		t.Fatalf("failed to decode key set: %v", err)
+	}
+	for _, key := range raw.Keys {
+		// d, p and q are the private parts of EC and RSA keys.
+		for _, private := range []string{"d", "p", "q"} {
+			if _, ok := key[private]; ok {
+				t.Errorf("key %v exposes the private parameter %q", key["kid"], private)
+			}
+		}
+	}
+	for _, signer := range []*Signer{current, previous} {
+		jwk, ok := set.Key(signer.KeyID())
+		if !ok {
+			t.Errorf("key set does not contain %s", signer.KeyID())
+			continue
+		}
+		pub, err := jwk.PublicKey()
+		if err != nil {
+			t.Fatalf("PublicKey() error = %v", err)
+		}
+		token, err := signer.Sign([]byte("{}"))
+		if err != nil {
+			t.Fatalf("Sign() error = %v", err)
+		}
+		parsed, err := jwt.Parse(token)
+		if err != nil {
+			t.Fatalf("jwt.Parse() error = %v", err)
+		}
+		if err := parsed.Verify(pub); err != nil {
+			t.Errorf("signature of %s does not verify with the published key: %v", signer.KeyID(), err)
+		}
+	}
+
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/.well-known/jwks.json", nil))
+	if rec.Code != http.StatusMethodNotAllowed {
+		t.Errorf("POST status = %d, want %d", rec.Code, http.StatusMethodNotAllowed)
+	}
+}
@@ -0,0 +1,241 @@
+// Copyright 2025 The A2A Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package push
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/netip"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/a2aproject/a2a-go/a2asrv"
+)
+
+// ErrForbiddenURL is returned when notifications must not be sent to a URL.
+var ErrForbiddenURL = errors.New("push notification URL is not allowed")
+
+// blockedPrefixes are the special-purpose ranges which are not checked by the
+// methods of netip.Addr. Addresses of private, loopback, link-local, multicast and
+// unspecified ranges are blocked as well.
+var blockedPrefixes = []netip.Prefix{
+	netip.MustParsePrefix("0.0.0.0/8"),       // "this" network
+	netip.MustParsePrefix("100.64.0.0/10"),   // carrier-grade NAT
+	netip.MustParsePrefix("192.0.0.0/24"),    // IETF protocol assignments
+	netip.MustParsePrefix("192.0.2.0/24"),    // documentation
+	netip.MustParsePrefix("198.18.0.0/15"),   // benchmarking
+	netip.MustParsePrefix("198.51.100.0/24"), // documentation
+	netip.MustParsePrefix("203.0.113.0/24"),  // documentation
+	netip.MustParsePrefix("240.0.0.0/4"),     // reserved, including broadcast
+	netip.MustParsePrefix("64:ff9b::/96"),    // NAT64, which can map to private IPv4 addresses
+	netip.MustParsePrefix("2001:db8::/32"),   // documentation
+}
+
+// URLValidatorOption configures a URLValidator.
+type URLValidatorOption func(*URLValidator)
+
+// WithAllowedSchemes sets the URL schemes notifications may be sent with. Only https
+// is allowed by default.
+func WithAllowedSchemes(schemes ...string) URLValidatorOption {
+	return func(v *URLValidator) {
+		v.schemes = make(map[string]bool, len(schemes))
+		for _, scheme := range schemes {
+			v.schemes[strings.ToLower(scheme)] = true
+		}
+	}
+}
+
+// WithAllowedHosts restricts notifications to the hosts. A pattern is either a host
+// name, matched exactly, or a "*." prefixed domain, matching its subdomains. The
+// addresses of allowed hosts are still checked.
+func WithAllowedHosts(patterns ...string) URLValidatorOption {
+	return func(v *URLValidator) {
+		v.hosts = patterns
+	}
+}
+
+// WithAllowedNetworks exempts the networks from the blocking of private and
+// special-purpose addresses, for example to deliver notifications to an internal
+// gateway.
+func WithAllowedNetworks(prefixes ...netip.Prefix) URLValidatorOption {
+	return func(v *URLValidator) {
+		v.networks = prefixes
+	}
+}
+
+// WithResolver sets the resolver used to look up the addresses of hosts.
+// net.DefaultResolver is used by default.
+func WithResolver(resolver *net.Resolver) URLValidatorOption {
+	return func(v *URLValidator) {
+		v.resolver = resolver
+	}
+}
+
+// URLValidator prevents server-side request forgery through push notification URLs.
+// It accepts URLs with an allowed scheme and host, whose host resolves only to public
+// addresses. It implements a2asrv.PushURLValidator for checking the configs when they
+// are created, and its DialContext checks the addresses again when notifications are
+// sent, so that a host cannot resolve to a public address when validated and to an
+// internal one when dialed.
+type URLValidator struct {
+	schemes  map[string]bool
+	hosts    []string
+	networks []netip.Prefix
+	resolver *net.Resolver
+	dialer   net.Dialer
+}
+
+var _ a2asrv.PushURLValidator = (*URLValidator)(nil)
+
+// NewURLValidator returns a URLValidator configured with the options.
+func NewURLValidator(opts ...URLValidatorOption) *URLValidator {
+	v := &URLValidator{
+		schemes:  map[string]bool{"https": true},
+		resolver: net.DefaultResolver,
+		dialer:   net.Dialer{Timeout: 30 * time.Second, KeepAlive: 30 * time.Second},
+	}
+	for _, opt := range opts {
+		opt(v)
+	}
+	return v
+}
+
+// ValidateURL implements a2asrv.PushURLValidator.
+func (v *URLValidator) ValidateURL(ctx context.Context, rawURL string) error {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrForbiddenURL, err)
+	}
+	if !v.schemes[strings.ToLower(u.Scheme)] {
+		return fmt.Errorf("%w: scheme %q is not allowed", ErrForbiddenURL, u.Scheme)
+	}
+	if u.User != nil {
+		return fmt.Errorf("%w: URL must not contain credentials", ErrForbiddenURL)
+	}
+	host := u.Hostname()
+	if err := v.checkHost(host); err != nil {
+		return err
+	}
+	_, err = v.resolve(ctx, host)
+	return err
+}
+
+// DialContext connects to the address like net.Dialer.DialContext, after checking
+// that the host is allowed and resolves only to allowed addresses. The connection
+// is made to the checked addresses, so the host is not resolved again.
+func (v *URLValidator) DialContext(ctx context.Context, network, address string) (net.Conn, error) {
+	host, port, err := net.SplitHostPort(address)
+	if err != nil {
+		return nil, err
+	}
+	if err := v.checkHost(host); err != nil {
+		return nil, err
+	}
+	addrs, err := v.resolve(ctx, host)
+	if err != nil {
+		return nil, err
+	}
+	var errs []error
+	for _, addr := range addrs {
+		conn, err := v.dialer.DialContext(ctx, network, net.JoinHostPort(addr.String(), port))
+		if err == nil {
+			return conn, nil
+		}
+		errs = append(errs, err)
+	}
+	return nil, errors.Join(errs...)
+}
+
+// Client returns an HTTP client which connects through DialContext. It does not use
+// proxies, which would connect to the webhooks on its behalf, and does not follow
+// redirects, which could lead to a forbidden URL.
+func (v *URLValidator) Client() *http.Client {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.Proxy = nil
+	transport.DialContext = v.DialContext
+	return &http.Client{
+		Transport: transport,
+		CheckRedirect: func(*http.Request, []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+}
+
+func (v *URLValidator) checkHost(host string) error {
+	if host == "" {
+		return fmt.Errorf("%w: host is required", ErrForbiddenURL)
+	}
+	if len(v.hosts) == 0 {
+		return nil
+	}
+	host = strings.ToLower(strings.TrimSuffix(host, "."))
+	for _, pattern := range v.hosts {
+		pattern = strings.ToLower(pattern)
+		if suffix, ok := strings.CutPrefix(pattern, "*"); ok && strings.HasSuffix(host, suffix) && len(host) > len(suffix) {
+			return nil
+		}
+		if host == pattern {
+			return nil
+		}
+	}
+	return fmt.Errorf("%w: host %q is not allowed", ErrForbiddenURL, host)
+}
+
+// resolve returns the addresses of the host, failing if any of them is blocked.
+func (v *URLValidator) resolve(ctx context.Context, host string) ([]netip.Addr, error) {
+	var addrs []netip.Addr
+	if addr, err := netip.ParseAddr(host); err == nil {
+		addrs = []netip.Addr{addr}
+	} else {
+		addrs, err = v.resolver.LookupNetIP(ctx, "ip", host)
+		if err != nil {
+			return nil, fmt.Errorf("failed to resolve %s: %w", host, err)
+		}
+	}
+	if len(addrs) == 0 {
+		return nil, fmt.Errorf("failed to resolve %s: no addresses", host)
+	}
+	for i, addr := range addrs {
+		addr = addr.Unmap()
+		if !v.allowed(addr) {
+			return nil, fmt.Errorf("%w: %s resolves to blocked address %s", ErrForbiddenURL, host, addr)
+		}
+		addrs[i] = addr
+	}
+	return addrs, nil
+}
+
+func (v *URLValidator) allowed(addr netip.Addr) bool {
+	for _, prefix := range v.networks {
+		if prefix.Contains(addr) {
+			return true
+		}
+	}
+	if addr.IsLoopback() || addr.IsPrivate() || addr.IsUnspecified() ||
+		addr.IsLinkLocalUnicast() || addr.IsLinkLocalMulticast() ||
+		addr.IsInterfaceLocalMulticast() || addr.IsMulticast() {
+		return false
+	}
+	for _, prefix := range blockedPrefixes {
+		if prefix.Contains(addr) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,296 @@
+// Copyright 2025 The A2A Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package push
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/netip"
+	"strings"
+	"sync"
+	"testing"
+
+	"golang.org/x/net/dns/dnsmessage"
+
+	"github.com/a2aproject/a2a-go/a2a"
+	"github.com/a2aproject/a2a-go/a2asrv"
+	a2apb "github.com/a2aproject/a2a-go/grpc"
+)
+
+// fakeDNS is a DNS server answering A queries with the configured IPv4 addresses.
+type fakeDNS struct {
+	conn net.PacketConn
+
+	mu      sync.Mutex
+	records map[string][4]byte
+}
+
+func newFakeDNS(t *testing.T) *fakeDNS {
+	t.Helper()
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("net.ListenPacket() error = %v", err)
+	}
+	d := &fakeDNS{conn: conn, records: make(map[string][4]byte)}
+	t.Cleanup(func() { _ = conn.Close() })
+	go d.serve()
+	return d
+}
+
+// set makes the name resolve to the address.
+func (d *fakeDNS) set(name string, addr netip.Addr) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.records[name+"."] = addr.As4()
+}
+
+func (d *fakeDNS) resolver() *net.Resolver {
+	return &net.Resolver{PreferGo: true, Dial: func(ctx context.Context, _, _ string) (net.Conn, error) {
+		var dialer net.Dialer
+		return dialer.DialContext(ctx, "udp", d.conn.LocalAddr().String())
+	}}
+}
+
+func (d *fakeDNS) serve() {
+	buf := make([]byte, 512)
+	for {
+		n, addr, err := d.conn.ReadFrom(buf)
+		if err != nil {
+			return
+		}
+		var p dnsmessage.Parser
+		header, err := p.Start(buf[:n])
+		if err != nil {
+			continue
+		}
+		q, err := p.Question()
+		if err != nil {
+			continue
+		}
+		d.mu.Lock()
+		ip, ok := d.records[strings.ToLower(q.Name.String())]
+		d.mu.Unlock()
+		rcode := dnsmessage.RCodeSuccess
+		if !ok {
+			rcode = dnsmessage.RCodeNameError
+		}
+		b := dnsmessage.NewBuilder(nil, dnsmessage.Header{ID: header.ID, Response: true, Authoritative: true, RCode: rcode})
+		_ = b.StartQuestions()
+		_ = b.Question(q)
+		_ = b.StartAnswers()
+		if ok && q.Type == dnsmessage.TypeA {
+			_ = b.AResource(dnsmessage.ResourceHeader{Name: q.Name, Class: dnsmessage.ClassINET}, dnsmessage.AResource{A: ip})
+		}
+		msg, err := b.Finish()
+		if err != nil {
+			continue
+		}
+		_, _ = d.conn.WriteTo(msg, addr)
+	}
+}
+
+func TestURLValidatorValidateURL(t *testing.T) {
+	dns := newFakeDNS(t)
+	dns.set("public.test", netip.MustParseAddr("93.184.216.34"))
+	dns.set("internal.test", netip.MustParseAddr("10.0.0.5"))
+	dns.set("hooks.example.com", netip.MustParseAddr("93.184.216.34"))
+	dns.set("example.com", netip.MustParseAddr("93.184.216.34"))
+
+	tests := []struct {
+		name          string
+		opts          []URLValidatorOption
+		url           string
+		wantErr       bool
+		wantForbidden bool
+	}{
+		{name: "public address", url: "https://8.8.8.8/webhook"},
+		{name: "public host", url: "https://public.test/webhook"},
+		{name: "http", url: "http://8.8.8.8/webhook", wantErr: true, wantForbidden: true},
+		{name: "allowed http", opts: []URLValidatorOption{WithAllowedSchemes("HTTP")}, url: "http://8.8.8.8/webhook"},
+		{name: "credentials", url: "https://user:pw@8.8.8.8/", wantErr: true, wantForbidden: true},
+		{name: "missing host", url: "https:///webhook", wantErr: true, wantForbidden: true},
+		{name: "invalid URL", url: "https://[::1", wantErr: true, wantForbidden: true},
+		{name: "loopback", url: "https://127.0.0.1/", wantErr: true, wantForbidden: true},
+		{name: "IPv6 loopback", url: "https://[::1]/", wantErr: true, wantForbidden: true},
+		{name: "IPv4-mapped loopback", url: "https://[::ffff:127.0.0.1]/", wantErr: true, wantForbidden: true},
+		{name: "private", url: "https://192.168.1.1/", wantErr: true, wantForbidden: true},
+		{name: "unique local", url: "https://[fd00::1]/", wantErr: true, wantForbidden: true},
+		{name: "metadata service", url: "https://169.254.169.254/latest/meta-data", wantErr: true, wantForbidden: true},
+		{name: "link-local IPv6", url: "https://[fe80::1%25eth0]/", wantErr: true, wantForbidden: true},
+		{name: "unspecified", url: "https://0.0.0.0/", wantErr: true, wantForbidden: true},
+		{name: "carrier-grade NAT", url: "https://100.64.1.1/", wantErr: true, wantForbidden: true},
+		{name: "NAT64", url: "https://[64:ff9b::a00:1]/", wantErr: true, wantForbidden: true},
+		{name: "multicast", url: "https://224.0.0.1/", wantErr: true, wantForbidden: true},
+		{name: "broadcast", url: "https://255.255.255.255/", wantErr: true, wantForbidden: true},
+		{name: "host resolving to private address", url: "https://internal.test/", wantErr: true, wantForbidden: true},
+		{name: "unknown host", url: "https://unknown.test/", wantErr: true},
+		{
+			name: "allowed network",
+			opts: []URLValidatorOption{WithAllowedNetworks(netip.MustParsePrefix("10.0.0.0/24"))},
+			url:  "https://internal.test/",
+		},
+		{
+			name: "allowed subdomain",
+			opts: []URLValidatorOption{WithAllowedHosts("*.example.com")},
+			url:  "https://hooks.example.com/",
+		},
+		{
+			name:          "parent of allowed subdomains",
+			opts:          []URLValidatorOption{WithAllowedHosts("*.example.com")},
+			url:           "https://example.com/",
+			wantErr:       true,
+			wantForbidden: true,
+		},
+		{
+			name: "allowed host in other case with trailing dot",
+			opts: []URLValidatorOption{WithAllowedHosts("public.test")},
+			url:  "https://PUBLIC.test./",
+		},
+		{
+			name:          "host not allowed",
+			opts:          []URLValidatorOption{WithAllowedHosts("public.test")},
+			url:           "https://8.8.8.8/",
+			wantErr:       true,
+			wantForbidden: true,
+		},
+		{
+			name:          "allowed host resolving to private address",
+			opts:          []URLValidatorOption{WithAllowedHosts("internal.test")},
+			url:           "https://internal.test/",
+			wantErr:       true,
+			wantForbidden: true,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			v := NewURLValidator(append([]URLValidatorOption{WithResolver(dns.resolver())}, tc.opts...)...)
+			err := v.ValidateURL(context.Background(), tc.url)
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("ValidateURL() error = %v, want error: %v", err, tc.wantErr)
+			}
+			if got := errors.Is(err, ErrForbiddenURL); got != tc.wantForbidden {
+				t.Errorf("ValidateURL() error = %v, want ErrForbiddenURL: %v", err, tc.wantForbidden)
+			}
+		})
+	}
+}
+
+// TestURLValidatorDNSRebinding checks that the addresses are checked again when
+// connecting, after the host was validated with a public address.
+func TestURLValidatorDNSRebinding(t *testing.T) {
+	ctx := context.Background()
+	hook := newWebhook(t)
+	_, port, err := net.SplitHostPort(hook.Listener.Addr().String())
+	if err != nil {
+		t.Fatal(err)
+	}
+	dns := newFakeDNS(t)
+	dns.set("rebind.test", netip.MustParseAddr("93.184.216.34"))
+	v := NewURLValidator(WithAllowedSchemes("http"), WithResolver(dns.resolver()))
+	url := "http://rebind.test:" + port + "/webhook"
+	if err := v.ValidateURL(ctx, url); err != nil {
+		t.Fatalf("ValidateURL() error = %v", err)
+	}
+
+	dns.set("rebind.test", netip.MustParseAddr("127.0.0.1"))
+	if _, err := v.DialContext(ctx, "tcp", "rebind.test:"+port); !errors.Is(err, ErrForbiddenURL) {
+		t.Errorf("DialContext() error = %v, want %v", err, ErrForbiddenURL)
+	}
+	if _, err := v.Client().Post(url, "application/json", nil); !errors.Is(err, ErrForbiddenURL) {
+		t.Errorf("Client().Post() error = %v, want %v", err, ErrForbiddenURL)
+	}
+	if requests, _ := hook.received(); len(requests) != 0 {
+		t.Errorf("the webhook received %d requests, want none", len(requests))
+	}
+}
+
+func TestURLValidatorClientDoesNotFollowRedirects(t *testing.T) {
+	target := newWebhook(t)
+	redirect := httptest.NewServer(http.RedirectHandler(target.URL, http.StatusFound))
+	defer redirect.Close()
+	v := NewURLValidator(WithAllowedSchemes("http"), WithAllowedNetworks(netip.MustParsePrefix("127.0.0.0/8")))
+	resp, err := v.Client().Get(redirect.URL)
+	if err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+	_ = resp.Body.Close()
+	if resp.StatusCode != http.StatusFound {
+		t.Errorf("status = %d, want the redirect response %d", resp.StatusCode, http.StatusFound)
+	}
+	if requests, _ := target.received(); len(requests) != 0 {
+		t.Errorf("the redirect target received %d requests, want none", len(requests))
+	}
+}
+
+func TestSenderWithURLValidator(t *testing.T) {
+	hook := newWebhook(t)
+	task := newTestTask()
+	config := &a2apb.PushNotificationConfig{Url: hook.URL}
+
+	blocked := NewSender(WithURLValidator(NewURLValidator(WithAllowedSchemes("http"))), fastRetry(3))
+	outcome := blocked.Send(context.Background(), task, config)
+	if !errors.Is(outcome.Err, ErrForbiddenURL) || outcome.Attempts != 0 {
+		t.Errorf("Send() to a loopback webhook = %+v, want a forbidden URL without attempts", outcome)
+	}
+
+	allowed := NewSender(WithURLValidator(NewURLValidator(
+		WithAllowedSchemes("http"),
+		WithAllowedNetworks(netip.MustParsePrefix("127.0.0.0/8"), netip.MustParsePrefix("::1/128")),
+	)))
+	if outcome := allowed.Send(context.Background(), task, config); !outcome.Delivered() {
+		t.Errorf("Send() to an allowed network = %+v, want delivered", outcome)
+	}
+}
+
+func TestHandlerRejectsForbiddenPushURLs(t *testing.T) {
+	executor := &completingExecutor{}
+	h := a2asrv.NewHandler(executor,
+		a2asrv.WithPushNotifier(NewSender()),
+		a2asrv.WithPushURLValidator(NewURLValidator()),
+	)
+	_, err := h.SendMessage(context.Background(), &a2apb.SendMessageRequest{
+		Request: &a2apb.Message{
+			MessageId: "m1",
+			Role:      a2apb.Role_ROLE_USER,
+			Content:   []*a2apb.Part{{Part: &a2apb.Part_Text{Text: "hello"}}},
+		},
+		Configuration: &a2apb.SendMessageConfiguration{
+			PushNotification: &a2apb.PushNotificationConfig{Url: "https://169.254.169.254/latest"},
+		},
+	})
+	if !errors.Is(err, a2a.ErrInvalidParams) {
+		t.Errorf("SendMessage() error = %v, want %v", err, a2a.ErrInvalidParams)
+	}
+	if executor.executed {
+		t.Error("the executor ran for a request with a forbidden push URL")
+	}
+}
+
+// completingExecutor completes every task.
+type completingExecutor struct {
+	executed bool
+}
+
+func (e *completingExecutor) Execute(ctx context.Context, reqCtx *a2asrv.RequestContext, queue a2asrv.EventQueue) error {
+	e.executed = true
+	return a2asrv.NewTaskUpdater(reqCtx, queue).Complete(ctx, nil)
+}
+
+func (e *completingExecutor) Cancel(ctx context.Context, reqCtx *a2asrv.RequestContext, queue a2asrv.EventQueue) error {
+	return a2asrv.NewTaskUpdater(reqCtx, queue).Cancel(ctx, nil)
+}
@@ -0,0 +1,163 @@
+// Copyright 2025 The A2A Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package a2asrv
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+
+	"google.golang.org/protobuf/proto"
+
+	"github.com/a2aproject/a2a-go/a2a"
+	a2apb "github.com/a2aproject/a2a-go/grpc"
+	"github.com/a2aproject/a2a-go/internal/uuid"
+)
+
+// ErrPushConfigNotFound is returned by a PushConfigStore when a config does not exist.
+// It is a2a.ErrPushConfigNotFound and can be matched using errors.Is.
+var ErrPushConfigNotFound = a2a.ErrPushConfigNotFound
+
+// PushConfigStore persists the push notification configs of tasks. Implementations
+// must be safe for concurrent use. Modifications of the configs passed to or returned
+// from a store must not affect the stored state.
+type PushConfigStore interface {
+	// Save stores the config of the task, replacing the config with the same ID, and
+	// returns the stored config. A unique ID is generated if the ID of the config is empty.
+	Save(ctx context.Context, taskID string, config *a2apb.PushNotificationConfig) (*a2apb.PushNotificationConfig, error)
+
+	// Get returns the config of the task with the ID.
+	// It fails with ErrPushConfigNotFound if the config does not exist.
+	Get(ctx context.Context, taskID, configID string) (*a2apb.PushNotificationConfig, error)
+
+	// List returns a page of at most pageSize configs of the task, ordered by ID, and
+	// the token of the next page, which is empty on the last page. A non-positive page
+	// size returns all the remaining configs. Page tokens are opaque and fail with
+	// a2a.ErrInvalidParams if they were not returned by the store.
+	List(ctx context.Context, taskID string, pageSize int, pageToken string) ([]*a2apb.PushNotificationConfig, string, error)
+
+	// Delete removes the config of the task with the ID.
+	// It fails with ErrPushConfigNotFound if the config does not exist.
+	Delete(ctx context.Context, taskID, configID string) error
+}
+
+// InMemoryPushConfigStore is a PushConfigStore which keeps configs in memory.
+// Configs are cloned on every access.
+type InMemoryPushConfigStore struct {
+	mu      sync.RWMutex
+	configs map[string]map[string]*a2apb.PushNotificationConfig
+}
+
+var _ PushConfigStore = (*InMemoryPushConfigStore)(nil)
+
+// NewInMemoryPushConfigStore creates an empty InMemoryPushConfigStore.
+func NewInMemoryPushConfigStore() *InMemoryPushConfigStore {
+	return &InMemoryPushConfigStore{configs: make(map[string]map[string]*a2apb.PushNotificationConfig)}
+}
+
+// Save implements PushConfigStore.
+func (s *InMemoryPushConfigStore) Save(ctx context.Context, taskID string, config *a2apb.PushNotificationConfig) (*a2apb.PushNotificationConfig, error) {
+	config = proto.Clone(config).(*a2apb.PushNotificationConfig)
+	if config.GetId() == "" {
+		config.Id = uuid.New()
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	configs, ok := s.configs[taskID]
+	if !ok {
+		configs = make(map[string]*a2apb.PushNotificationConfig)
+		s.configs[taskID] = configs
+	}
+	configs[config.GetId()] = config
+	return proto.Clone(config).(*a2apb.PushNotificationConfig), nil
+}
+
+// Get implements PushConfigStore.
+func (s *InMemoryPushConfigStore) Get(ctx context.Context, taskID, configID string) (*a2apb.PushNotificationConfig, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	config, ok := s.configs[taskID][configID]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrPushConfigNotFound, a2a.PushConfigName(taskID, configID))
+	}
+	return proto.Clone(config).(*a2apb.PushNotificationConfig), nil
+}
+
+// List implements PushConfigStore.
+func (s *InMemoryPushConfigStore) List(ctx context.Context, taskID string, pageSize int, pageToken string) ([]*a2apb.PushNotificationConfig, string, error) {
+	after, err := DecodePageToken(pageToken)
+	if err != nil {
+		return nil, "", err
+	}
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	var ids []string
+	for id := range s.configs[taskID] {
+		if id > after {
+			ids = append(ids, id)
+		}
+	}
+	slices.Sort(ids)
+	next := ""
+	if pageSize > 0 && len(ids) > pageSize {
+		ids = ids[:pageSize]
+		next = EncodePageToken(ids[pageSize-1])
+	}
+	result := make([]*a2apb.PushNotificationConfig, len(ids))
+	for i, id := range ids {
+		result[i] = proto.Clone(s.configs[taskID][id]).(*a2apb.PushNotificationConfig)
+	}
+	return result, next, nil
+}
+
+// Delete implements PushConfigStore.
+func (s *InMemoryPushConfigStore) Delete(ctx context.Context, taskID, configID string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	configs := s.configs[taskID]
+	if _, ok := configs[configID]; !ok {
+		return fmt.Errorf("%w: %s", ErrPushConfigNotFound, a2a.PushConfigName(taskID, configID))
+	}
+	delete(configs, configID)
+	if len(configs) == 0 {
+		delete(s.configs, taskID)
+	}
+	return nil
+}
+
+// pageTokenPrefix versions the page token format.
+const pageTokenPrefix = "v1:"
+
+// EncodePageToken returns an opaque page token continuing a listing ordered by ID
+// after the ID. It is intended for PushConfigStore implementations.
+func EncodePageToken(lastID string) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(pageTokenPrefix + lastID))
+}
+
+// DecodePageToken returns the ID encoded in a page token by EncodePageToken.
+// An empty token, which requests the first page, decodes to an empty ID.
+func DecodePageToken(token string) (string, error) {
+	if token == "" {
+		return "", nil
+	}
+	data, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil || !strings.HasPrefix(string(data), pageTokenPrefix) {
+		return "", fmt.Errorf("%w: invalid page token", a2a.ErrInvalidParams)
+	}
+	return strings.TrimPrefix(string(data), pageTokenPrefix), nil
+}
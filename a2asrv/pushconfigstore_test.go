@@ -0,0 +1,184 @@
+// Copyright 2025 The A2A Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package a2asrv
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/a2aproject/a2a-go/a2a"
+	a2apb "github.com/a2aproject/a2a-go/grpc"
+)
+
+func TestInMemoryPushConfigStore(t *testing.T) {
+	ctx := context.Background()
+	store := NewInMemoryPushConfigStore()
+
+	saved, err := store.Save(ctx, "t1", &a2apb.PushNotificationConfig{Url: "https://example.com/a"})
+	if err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+	if saved.GetId() == "" {
+		t.Fatal("Save() did not generate an ID")
+	}
+	saved.Url = "https://example.com/modified"
+	got, err := store.Get(ctx, "t1", saved.GetId())
+	if err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+	if got.GetUrl() != "https://example.com/a" {
+		t.Errorf("Get() URL = %q, want the stored config unaffected by modifications", got.GetUrl())
+	}
+
+	if _, err := store.Save(ctx, "t1", &a2apb.PushNotificationConfig{Id: saved.GetId(), Url: "https://example.com/b"}); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+	if got, _ := store.Get(ctx, "t1", saved.GetId()); got.GetUrl() != "https://example.com/b" {
+		t.Errorf("Get() URL = %q after replacing the config, want %q", got.GetUrl(), "https://example.com/b")
+	}
+	if _, err := store.Get(ctx, "t2", saved.GetId()); !errors.Is(err, ErrPushConfigNotFound) {
+		t.Errorf("Get() for another task error = %v, want %v", err, ErrPushConfigNotFound)
+	}
+
+	if err := store.Delete(ctx, "t1", saved.GetId()); err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+	if err := store.Delete(ctx, "t1", saved.GetId()); !errors.Is(err, ErrPushConfigNotFound) {
+		t.Errorf("Delete() of a deleted config error = %v, want %v", err, ErrPushConfigNotFound)
+	}
+	if configs, _, err := store.List(ctx, "t1", 0, ""); err != nil || len(configs) != 0 {
+		t.Errorf("List() = %v, %v, want no configs", configs, err)
+	}
+}
+
+func TestInMemoryPushConfigStoreList(t *testing.T) {
+	ctx := context.Background()
+	store := NewInMemoryPushConfigStore()
+	for _, id := range []string{"c", "a", "e", "b", "d"} {
+		if _, err := store.Save(ctx, "t1", &a2apb.PushNotificationConfig{Id: id}); err != nil {
+			t.Fatalf("Save() error = %v", err)
+		}
+	}
+	if _, err := store.Save(ctx, "t2", &a2apb.PushNotificationConfig{Id: "x"}); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+
+	tests := []struct {
+		pageSize  int
+		wantPages []string
+	}{
+		{pageSize: 0, wantPages: []string{"abcde"}},
+		{pageSize: 1, wantPages: []string{"a", "b", "c", "d", "e"}},
+		{pageSize: 2, wantPages: []string{"ab", "cd", "e"}},
+		{pageSize: 5, wantPages: []string{"abcde"}},
+		{pageSize: 10, wantPages: []string{"abcde"}},
+	}
+	for _, tc := range tests {
+		t.Run(fmt.Sprintf("page size %d", tc.pageSize), func(t *testing.T) {
+			var pages []string
+			token := ""
+			for {
+				configs, next, err := store.List(ctx, "t1", tc.pageSize, token)
+				if err != nil {
+					t.Fatalf("List() error = %v", err)
+				}
+				page := ""
+				for _, config := range configs {
+					page += config.GetId()
+				}
+				pages = append(pages, page)
+				if next == "" {
+					break
+				}
+				token = next
+			}
+			if fmt.Sprint(pages) != fmt.Sprint(tc.wantPages) {
+				t.Errorf("pages = %v, want %v", pages, tc.wantPages)
+			}
+		})
+	}
+
+	for _, token := range []string{"not base64!", EncodePageToken("a")[1:], "djI6YQ"} {
+		if _, _, err := store.List(ctx, "t1", 1, token); !errors.Is(err, a2a.ErrInvalidParams) {
+			t.Errorf("List() with page token %q error = %v, want %v", token, err, a2a.ErrInvalidParams)
+		}
+	}
+}
+
+func TestPageToken(t *testing.T) {
+	for _, id := range []string{"", "a", "config/with:special chars"} {
+		got, err := DecodePageToken(EncodePageToken(id))
+		if err != nil || got != id {
+			t.Errorf("DecodePageToken(EncodePageToken(%q)) = %q, %v", id, got, err)
+		}
+	}
+	if got, err := DecodePageToken(""); err != nil || got != "" {
+		t.Errorf("DecodePageToken(\"\") = %q, %v, want the first page", got, err)
+	}
+}
+
+func TestHandlerListPushConfigs(t *testing.T) {
+	ctx := context.Background()
+	executor := &testExecutor{execute: func(ctx context.Context, reqCtx *RequestContext, queue EventQueue) error {
+		return writeAll(ctx, queue, statusEvent(reqCtx, a2apb.TaskState_TASK_STATE_INPUT_REQUIRED, true))
+	}}
+	h := NewHandler(executor, WithPushNotifier(newRecordingNotifier()))
+	resp, err := h.SendMessage(ctx, newTestMessage(""))
+	if err != nil {
+		t.Fatalf("SendMessage() error = %v", err)
+	}
+	parent := a2a.TaskName(resp.GetTask().GetId())
+	for _, id := range []string{"p1", "p2", "p3"} {
+		_, err := h.CreateTaskPushNotificationConfig(ctx, &a2apb.CreateTaskPushNotificationConfigRequest{
+			Parent:   parent,
+			ConfigId: id,
+			Config: &a2apb.TaskPushNotificationConfig{
+				PushNotificationConfig: &a2apb.PushNotificationConfig{Url: "https://client.example.com/" + id},
+			},
+		})
+		if err != nil {
+			t.Fatalf("CreateTaskPushNotificationConfig() error = %v", err)
+		}
+	}
+
+	first, err := h.ListTaskPushNotificationConfig(ctx, &a2apb.ListTaskPushNotificationConfigRequest{Parent: parent, PageSize: 2})
+	if err != nil {
+		t.Fatalf("ListTaskPushNotificationConfig() error = %v", err)
+	}
+	if len(first.GetConfigs()) != 2 || first.GetNextPageToken() == "" {
+		t.Fatalf("first page = %v, want 2 configs and a next page", first)
+	}
+	if want := a2a.PushConfigName(resp.GetTask().GetId(), "p1"); first.GetConfigs()[0].GetName() != want {
+		t.Errorf("config name = %q, want %q", first.GetConfigs()[0].GetName(), want)
+	}
+	second, err := h.ListTaskPushNotificationConfig(ctx, &a2apb.ListTaskPushNotificationConfigRequest{
+		Parent:    parent,
+		PageSize:  2,
+		PageToken: first.GetNextPageToken(),
+	})
+	if err != nil {
+		t.Fatalf("ListTaskPushNotificationConfig() error = %v", err)
+	}
+	if len(second.GetConfigs()) != 1 || second.GetNextPageToken() != "" {
+		t.Errorf("second page = %v, want the last config", second)
+	}
+
+	_, err = h.ListTaskPushNotificationConfig(ctx, &a2apb.ListTaskPushNotificationConfigRequest{Parent: a2a.TaskName("missing")})
+	if !errors.Is(err, a2a.ErrTaskNotFound) {
+		t.Errorf("ListTaskPushNotificationConfig() of a missing task error = %v, want %v", err, a2a.ErrTaskNotFound)
+	}
+}
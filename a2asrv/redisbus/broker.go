@@ -0,0 +1,308 @@
+// Copyright 2025 The A2A Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package redisbus
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"net"
+	"sync"
+	"time"
+
+	"github.com/a2aproject/a2a-go/a2asrv"
+)
+
+// DefaultChannelPrefix is prepended to task IDs to form the names of Pub/Sub channels.
+const DefaultChannelPrefix = "a2a:task:"
+
+// DefaultMaxMessageSize is the default limit of the size of the replies read from
+// the server, in particular of the published payloads.
+const DefaultMaxMessageSize = 16 << 20
+
+// Dialer opens connections to the Redis server.
+type Dialer func(ctx context.Context, network, address string) (net.Conn, error)
+
+// Option configures a Broker.
+type Option func(*Broker)
+
+// WithPassword authenticates the connections with the password.
+func WithPassword(password string) Option {
+	return func(b *Broker) {
+		b.password = password
+	}
+}
+
+// WithUsername authenticates the connections as the ACL user. It is used
+// together with WithPassword.
+func WithUsername(username string) Option {
+	return func(b *Broker) {
+		b.username = username
+	}
+}
+
+// WithChannelPrefix sets the prefix of the Pub/Sub channel names.
+// The default is DefaultChannelPrefix.
+func WithChannelPrefix(prefix string) Option {
+	return func(b *Broker) {
+		b.prefix = prefix
+	}
+}
+
+// WithMaxMessageSize limits the size of the bulk strings and the number of array
+// elements read from the server. A subscription receiving a larger message is closed.
+// The default is DefaultMaxMessageSize.
+func WithMaxMessageSize(size int) Option {
+	return func(b *Broker) {
+		b.maxSize = size
+	}
+}
+
+// WithDialer sets the function used to open connections, for example to use TLS.
+func WithDialer(dial Dialer) Option {
+	return func(b *Broker) {
+		b.dial = dial
+	}
+}
+
+// Broker is an a2asrv.Broker using Redis Pub/Sub. Messages are published over
+// a shared connection, and every subscription uses a dedicated connection.
+// Like Redis Pub/Sub, the broker delivers messages at most once: the messages
+// published while a subscription connection is broken are lost, and the
+// subscription is closed instead of being reconnected.
+type Broker struct {
+	address  string
+	username string
+	password string
+	prefix   string
+	maxSize  int
+	dial     Dialer
+
+	// mu guards the publishing connection.
+	mu   sync.Mutex
+	conn *conn
+}
+
+var _ a2asrv.Broker = (*Broker)(nil)
+
+// NewBroker returns a Broker for the Redis server at the address. Connections
+// are opened lazily.
+func NewBroker(address string, opts ...Option) *Broker {
+	d := &net.Dialer{}
+	b := &Broker{
+		address: address,
+		prefix:  DefaultChannelPrefix,
+		maxSize: DefaultMaxMessageSize,
+		dial:    d.DialContext,
+	}
+	for _, opt := range opts {
+		opt(b)
+	}
+	return b
+}
+
+// Publish implements a2asrv.Broker.
+func (b *Broker) Publish(ctx context.Context, topic string, payload []byte) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.conn == nil {
+		c, err := b.connect(ctx)
+		if err != nil {
+			return err
+		}
+		b.conn = c
+	}
+	reply, err := b.conn.do(ctx, []byte("PUBLISH"), []byte(b.prefix+topic), payload)
+	if err != nil {
+		// The connection state is unknown, a new one is opened for the next call.
+		_ = b.conn.Close()
+		b.conn = nil
+		return err
+	}
+	if _, ok := reply.(int64); !ok {
+		return fmt.Errorf("%w: unexpected PUBLISH reply %v", errProtocol, reply)
+	}
+	return nil
+}
+
+// Subscribe implements a2asrv.Broker.
+func (b *Broker) Subscribe(ctx context.Context, topic string) (a2asrv.BrokerSubscription, error) {
+	c, err := b.connect(ctx)
+	if err != nil {
+		return nil, err
+	}
+	channel := b.prefix + topic
+	reply, err := c.do(ctx, []byte("SUBSCRIBE"), []byte(channel))
+	if err == nil {
+		err = checkPushMessage(reply, "subscribe", channel)
+	}
+	if err != nil {
+		_ = c.Close()
+		return nil, fmt.Errorf("failed to subscribe to %s: %w", channel, err)
+	}
+	sub := &subscription{
+		conn:     c,
+		channel:  channel,
+		messages: make(chan []byte),
+		done:     make(chan struct{}),
+	}
+	go sub.receive()
+	return sub, nil
+}
+
+// Close closes the publishing connection. Subscriptions are closed separately.
+func (b *Broker) Close() error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.conn == nil {
+		return nil
+	}
+	err := b.conn.Close()
+	b.conn = nil
+	return err
+}
+
+// connect opens a new authenticated connection.
+func (b *Broker) connect(ctx context.Context) (*conn, error) {
+	nc, err := b.dial(ctx, "tcp", b.address)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to redis: %w", err)
+	}
+	c := &conn{Conn: nc, r: bufio.NewReader(nc), w: bufio.NewWriter(nc), maxSize: b.maxSize}
+	if b.password == "" {
+		return c, nil
+	}
+	args := [][]byte{[]byte("AUTH"), []byte(b.password)}
+	if b.username != "" {
+		args = [][]byte{[]byte("AUTH"), []byte(b.username), []byte(b.password)}
+	}
+	if _, err := c.do(ctx, args...); err != nil {
+		_ = c.Close()
+		return nil, fmt.Errorf("failed to authenticate: %w", err)
+	}
+	return c, nil
+}
+
+type conn struct {
+	net.Conn
+	r       *bufio.Reader
+	w       *bufio.Writer
+	maxSize int
+}
+
+// do sends the command and reads its reply. Error replies are returned as errors.
+// If the context is canceled or its deadline is exceeded, the pending I/O is
+// interrupted and the context error is returned; the connection must be closed then.
+func (c *conn) do(ctx context.Context, args ...[]byte) (any, error) {
+	// The pending I/O is interrupted when the context is done, which covers its
+	// deadline as well, so that the context error is set by then.
+	interrupted := make(chan struct{})
+	stop := context.AfterFunc(ctx, func() {
+		defer close(interrupted)
+		_ = c.SetDeadline(time.Now())
+	})
+	defer func() {
+		if !stop() {
+			// Wait for the deadline to be set before clearing it.
+			<-interrupted
+		}
+		_ = c.SetDeadline(time.Time{})
+	}()
+	reply, err := c.roundTrip(args)
+	if err != nil {
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+		return nil, err
+	}
+	if rerr, ok := reply.(redisError); ok {
+		return nil, rerr
+	}
+	return reply, nil
+}
+
+func (c *conn) roundTrip(args [][]byte) (any, error) {
+	if err := writeCommand(c.w, args...); err != nil {
+		return nil, err
+	}
+	return c.readReply()
+}
+
+func (c *conn) readReply() (any, error) {
+	return readReply(c.r, c.maxSize)
+}
+
+type subscription struct {
+	conn     *conn
+	channel  string
+	messages chan []byte
+	done     chan struct{}
+	once     sync.Once
+}
+
+func (s *subscription) Messages() <-chan []byte {
+	return s.messages
+}
+
+func (s *subscription) Close() error {
+	var err error
+	s.once.Do(func() {
+		close(s.done)
+		err = s.conn.Close()
+	})
+	return err
+}
+
+// receive reads the messages of the channel until the connection is closed.
+func (s *subscription) receive() {
+	defer close(s.messages)
+	for {
+		reply, err := s.conn.readReply()
+		if err != nil {
+			_ = s.Close()
+			return
+		}
+		items, ok := reply.([]any)
+		if !ok || len(items) != 3 || !isKind(items[0], "message") {
+			continue
+		}
+		payload, ok := items[2].([]byte)
+		if !ok {
+			continue
+		}
+		select {
+		case s.messages <- payload:
+		case <-s.done:
+			return
+		}
+	}
+}
+
+// checkPushMessage checks that the reply is a Pub/Sub message of the kind for the channel.
+func checkPushMessage(reply any, kind, channel string) error {
+	items, ok := reply.([]any)
+	if !ok || len(items) != 3 || !isKind(items[0], kind) {
+		return fmt.Errorf("%w: unexpected %s reply %v", errProtocol, kind, reply)
+	}
+	if name, ok := items[1].([]byte); !ok || string(name) != channel {
+		return fmt.Errorf("%w: %s reply for another channel", errProtocol, kind)
+	}
+	return nil
+}
+
+func isKind(item any, kind string) bool {
+	b, ok := item.([]byte)
+	return ok && string(b) == kind
+}
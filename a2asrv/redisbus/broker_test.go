@@ -0,0 +1,329 @@
+// Copyright 2025 The A2A Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package redisbus
+
+import (
+	"context"
+	"errors"
+	"net"
+	"strings"
+	"testing"
+	"time"
+
+	"google.golang.org/protobuf/proto"
+
+	"github.com/a2aproject/a2a-go/a2asrv"
+	a2apb "github.com/a2aproject/a2a-go/grpc"
+)
+
+func newTestBroker(t *testing.T, address string, opts ...Option) *Broker {
+	t.Helper()
+	b := NewBroker(address, opts...)
+	t.Cleanup(func() { _ = b.Close() })
+	return b
+}
+
+func brokerSubscribe(t *testing.T, b *Broker, topic string) a2asrv.BrokerSubscription {
+	t.Helper()
+	sub, err := b.Subscribe(context.Background(), topic)
+	if err != nil {
+		t.Fatalf("Subscribe() error = %v", err)
+	}
+	t.Cleanup(func() { _ = sub.Close() })
+	return sub
+}
+
+func receive(t *testing.T, sub a2asrv.BrokerSubscription) ([]byte, bool) {
+	t.Helper()
+	select {
+	case payload, ok := <-sub.Messages():
+		return payload, ok
+	case <-time.After(5 * time.Second):
+		t.Fatal("no message received")
+		return nil, false
+	}
+}
+
+func TestBrokerPublishSubscribe(t *testing.T) {
+	ctx := context.Background()
+	server := newFakeRedis(t, "", "")
+	publisher := newTestBroker(t, server.addr())
+	subscriber := newTestBroker(t, server.addr())
+
+	sub := brokerSubscribe(t, subscriber, "t1")
+	other := brokerSubscribe(t, subscriber, "t2")
+	if got := server.subscribers(DefaultChannelPrefix + "t1"); got != 1 {
+		t.Fatalf("the server has %d subscribers of the channel, want 1", got)
+	}
+
+	payloads := [][]byte{[]byte("first"), {}, []byte("binary\r\n\x00payload")}
+	for _, payload := range payloads {
+		if err := publisher.Publish(ctx, "t1", payload); err != nil {
+			t.Fatalf("Publish() error = %v", err)
+		}
+	}
+	for _, want := range payloads {
+		if got, _ := receive(t, sub); string(got) != string(want) {
+			t.Errorf("received %q, want %q", got, want)
+		}
+	}
+	select {
+	case payload := <-other.Messages():
+		t.Errorf("the subscriber of another topic received %q", payload)
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	if err := sub.Close(); err != nil {
+		t.Errorf("Close() error = %v", err)
+	}
+	if _, ok := receive(t, sub); ok {
+		t.Error("Messages() is open after Close()")
+	}
+}
+
+func TestBrokerChannelPrefix(t *testing.T) {
+	server := newFakeRedis(t, "", "")
+	b := newTestBroker(t, server.addr(), WithChannelPrefix("test:"))
+	brokerSubscribe(t, b, "t1")
+	if got := server.subscribers("test:t1"); got != 1 {
+		t.Errorf("the server has %d subscribers of the prefixed channel, want 1", got)
+	}
+}
+
+func TestBrokerAuthentication(t *testing.T) {
+	passwordServer := newFakeRedis(t, "", "secret")
+	aclServer := newFakeRedis(t, "agent", "secret")
+	tests := []struct {
+		name    string
+		server  *fakeRedis
+		opts    []Option
+		wantErr bool
+	}{
+		{name: "password", server: passwordServer, opts: []Option{WithPassword("secret")}},
+		{name: "wrong password", server: passwordServer, opts: []Option{WithPassword("wrong")}, wantErr: true},
+		{name: "no password", server: passwordServer, wantErr: true},
+		{name: "username and password", server: aclServer, opts: []Option{WithUsername("agent"), WithPassword("secret")}},
+		{name: "wrong username", server: aclServer, opts: []Option{WithUsername("other"), WithPassword("secret")}, wantErr: true},
+		{name: "default user", server: aclServer, opts: []Option{WithPassword("secret")}, wantErr: true},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			b := newTestBroker(t, tc.server.addr(), tc.opts...)
+			sub, err := b.Subscribe(context.Background(), "t1")
+			if err == nil {
+				_ = sub.Close()
+			}
+			if (err != nil) != tc.wantErr {
+				t.Errorf("Subscribe() error = %v, want error: %v", err, tc.wantErr)
+			}
+			if err := b.Publish(context.Background(), "t1", []byte("hello")); (err != nil) != tc.wantErr {
+				t.Errorf("Publish() error = %v, want error: %v", err, tc.wantErr)
+			}
+		})
+	}
+}
+
+func TestBrokerReconnectsPublisher(t *testing.T) {
+	ctx := context.Background()
+	server := newFakeRedis(t, "", "")
+	b := newTestBroker(t, server.addr())
+	if err := b.Publish(ctx, "t1", []byte("hello")); err != nil {
+		t.Fatalf("Publish() error = %v", err)
+	}
+	server.dropConnections()
+	// The broken connection may only be noticed by the first call.
+	var err error
+	for range 2 {
+		if err = b.Publish(ctx, "t1", []byte("hello")); err == nil {
+			break
+		}
+	}
+	if err != nil {
+		t.Errorf("Publish() after the connection broke error = %v", err)
+	}
+}
+
+func TestBrokerClosesBrokenSubscription(t *testing.T) {
+	server := newFakeRedis(t, "", "")
+	sub := brokerSubscribe(t, newTestBroker(t, server.addr()), "t1")
+	server.dropConnections()
+	if _, ok := receive(t, sub); ok {
+		t.Error("Messages() is open after the connection broke")
+	}
+}
+
+func TestBrokerMaxMessageSize(t *testing.T) {
+	ctx := context.Background()
+	server := newFakeRedis(t, "", "")
+	publisher := newTestBroker(t, server.addr())
+	sub := brokerSubscribe(t, newTestBroker(t, server.addr(), WithMaxMessageSize(16)), "t1")
+
+	if err := publisher.Publish(ctx, "t1", []byte("small")); err != nil {
+		t.Fatalf("Publish() error = %v", err)
+	}
+	if got, _ := receive(t, sub); string(got) != "small" {
+		t.Errorf("received %q, want %q", got, "small")
+	}
+	if err := publisher.Publish(ctx, "t1", []byte(strings.Repeat("x", 17))); err != nil {
+		t.Fatalf("Publish() error = %v", err)
+	}
+	if payload, ok := receive(t, sub); ok {
+		t.Errorf("received %q larger than the limit, want the subscription closed", payload)
+	}
+}
+
+// TestBrokerContextCancellation checks that the calls do not wait for a server
+// that never replies once the context is done, with or without a deadline.
+func TestBrokerContextCancellation(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("net.Listen() error = %v", err)
+	}
+	t.Cleanup(func() { _ = listener.Close() })
+	go func() {
+		for {
+			c, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			t.Cleanup(func() { _ = c.Close() })
+		}
+	}()
+
+	tests := []struct {
+		name    string
+		ctx     func() (context.Context, context.CancelFunc)
+		wantErr error
+	}{
+		{
+			name: "canceled",
+			ctx: func() (context.Context, context.CancelFunc) {
+				ctx, cancel := context.WithCancel(context.Background())
+				time.AfterFunc(50*time.Millisecond, cancel)
+				return ctx, cancel
+			},
+			wantErr: context.Canceled,
+		},
+		{
+			name: "deadline",
+			ctx: func() (context.Context, context.CancelFunc) {
+				return context.WithTimeout(context.Background(), 50*time.Millisecond)
+			},
+			wantErr: context.DeadlineExceeded,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			b := newTestBroker(t, listener.Addr().String())
+			calls := map[string]func(ctx context.Context) error{
+				"Publish": func(ctx context.Context) error { return b.Publish(ctx, "t1", []byte("hello")) },
+				"Subscribe": func(ctx context.Context) error {
+					_, err := b.Subscribe(ctx, "t1")
+					return err
+				},
+			}
+			for name, call := range calls {
+				ctx, cancel := tc.ctx()
+				done := make(chan error, 1)
+				go func() { done <- call(ctx) }()
+				select {
+				case err := <-done:
+					if !errors.Is(err, tc.wantErr) {
+						t.Errorf("%s() error = %v, want %v", name, err, tc.wantErr)
+					}
+				case <-time.After(5 * time.Second):
+					t.Errorf("%s() did not return after the context was done", name)
+				}
+				cancel()
+			}
+		})
+	}
+}
+
+// TestBrokerContextCanceledAfterCall checks that a context canceled after a
+// successful call does not break the connection.
+func TestBrokerContextCanceledAfterCall(t *testing.T) {
+	server := newFakeRedis(t, "", "")
+	publisher := newTestBroker(t, server.addr())
+	subscriber := newTestBroker(t, server.addr())
+	ctx, cancel := context.WithCancel(context.Background())
+	sub, err := subscriber.Subscribe(ctx, "t1")
+	if err != nil {
+		t.Fatalf("Subscribe() error = %v", err)
+	}
+	defer func() { _ = sub.Close() }()
+	cancel()
+	time.Sleep(10 * time.Millisecond)
+	if err := publisher.Publish(context.Background(), "t1", []byte("hello")); err != nil {
+		t.Fatalf("Publish() error = %v", err)
+	}
+	if got, ok := receive(t, sub); !ok || string(got) != "hello" {
+		t.Errorf("received %q, %v, want %q", got, ok, "hello")
+	}
+}
+
+// TestEventBusAcrossReplicas connects the event buses of two replicas through
+// two brokers of the same server.
+func TestEventBusAcrossReplicas(t *testing.T) {
+	ctx := context.Background()
+	server := newFakeRedis(t, "", "")
+	executing := a2asrv.NewEventBus(a2asrv.WithBroker(newTestBroker(t, server.addr())))
+	serving := a2asrv.NewEventBus(a2asrv.WithBroker(newTestBroker(t, server.addr())))
+
+	sub, err := serving.Subscribe(ctx, "t1")
+	if err != nil {
+		t.Fatalf("Subscribe() error = %v", err)
+	}
+	defer sub.Close()
+	events := []*a2apb.StreamResponse{
+		{Payload: &a2apb.StreamResponse_StatusUpdate{StatusUpdate: &a2apb.TaskStatusUpdateEvent{
+			TaskId: "t1",
+			Status: &a2apb.TaskStatus{State: a2apb.TaskState_TASK_STATE_WORKING},
+		}}},
+		{Payload: &a2apb.StreamResponse_StatusUpdate{StatusUpdate: &a2apb.TaskStatusUpdateEvent{
+			TaskId: "t1",
+			Status: &a2apb.TaskStatus{State: a2apb.TaskState_TASK_STATE_COMPLETED},
+			Final:  true,
+		}}},
+	}
+	for _, event := range events {
+		if err := executing.Publish(ctx, "t1", event); err != nil {
+			t.Fatalf("Publish() error = %v", err)
+		}
+	}
+
+	var got []*a2apb.StreamResponse
+	timeout := time.After(5 * time.Second)
+	for done := false; !done; {
+		select {
+		case event, ok := <-sub.Events():
+			if !ok {
+				done = true
+				break
+			}
+			got = append(got, event)
+		case <-timeout:
+			t.Fatalf("the subscription was not closed after the final event, received %v", got)
+		}
+	}
+	if len(got) != len(events) {
+		t.Fatalf("received %d events %v, want %d", len(got), got, len(events))
+	}
+	for i := range events {
+		if !proto.Equal(got[i], events[i]) {
+			t.Errorf("event %d = %v, want %v", i, got[i], events[i])
+		}
+	}
+}
@@ -0,0 +1,34 @@
+// Copyright 2025 The A2A Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package redisbus implements an a2asrv.Broker on top of Redis Pub/Sub, so that
+// the event buses of several server replicas can share the events of tasks.
+//
+// The broker speaks the Redis protocol (RESP) directly and works with any server
+// implementing PUBLISH and SUBSCRIBE, such as Redis or Valkey:
+//
+//	broker := redisbus.NewBroker("localhost:6379", redisbus.WithPassword(password))
+//	bus := a2asrv.NewEventBus(a2asrv.WithBroker(broker))
+//	handler := a2asrv.NewHandler(executor,
+//		a2asrv.WithEventBus(bus),
+//		a2asrv.WithTaskStore(sharedStore),
+//	)
+//
+// Replicas must share the task store as well, since subscribers load the task
+// state from it.
+//
+// Redis Pub/Sub delivers messages at most once. A subscription whose connection
+// breaks is closed, which ends the TaskSubscription served from it; clients
+// resubscribe and receive the current task state from the task store.
+package redisbus
@@ -0,0 +1,197 @@
+// Copyright 2025 The A2A Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package redisbus
+
+import (
+	"bufio"
+	"fmt"
+	"net"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// fakeRedis is an in-process server implementing the Redis commands used by the
+// Broker: AUTH, PUBLISH and SUBSCRIBE.
+type fakeRedis struct {
+	listener net.Listener
+	username string
+	password string
+
+	mu       sync.Mutex
+	conns    map[*fakeConn]struct{}
+	channels map[string]map[*fakeConn]struct{}
+	commands []string
+}
+
+type fakeConn struct {
+	net.Conn
+	mu     sync.Mutex
+	w      *bufio.Writer
+	authed bool
+}
+
+func (c *fakeConn) write(format string, args ...any) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	_, _ = fmt.Fprintf(c.w, format, args...)
+	_ = c.w.Flush()
+}
+
+func (c *fakeConn) writeBulks(items ...string) {
+	var b strings.Builder
+	fmt.Fprintf(&b, "*%d\r\n", len(items))
+	for _, item := range items {
+		fmt.Fprintf(&b, "$%d\r\n%s\r\n", len(item), item)
+	}
+	c.write("%s", b.String())
+}
+
+// newFakeRedis starts a server requiring the password if it is not empty.
+func newFakeRedis(t *testing.T, username, password string) *fakeRedis {
+	t.Helper()
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("net.Listen() error = %v", err)
+	}
+	s := &fakeRedis{
+		listener: listener,
+		username: username,
+		password: password,
+		conns:    make(map[*fakeConn]struct{}),
+		channels: make(map[string]map[*fakeConn]struct{}),
+	}
+	go s.serve()
+	t.Cleanup(s.close)
+	return s
+}
+
+func (s *fakeRedis) addr() string {
+	return s.listener.Addr().String()
+}
+
+func (s *fakeRedis) serve() {
+	for {
+		nc, err := s.listener.Accept()
+		if err != nil {
+			return
+		}
+		c := &fakeConn{Conn: nc, w: bufio.NewWriter(nc), authed: s.password == ""}
+		s.mu.Lock()
+		s.conns[c] = struct{}{}
+		s.mu.Unlock()
+		go s.handle(c)
+	}
+}
+
+// close stops the server and closes all the connections.
+func (s *fakeRedis) close() {
+	_ = s.listener.Close()
+	s.dropConnections()
+}
+
+// dropConnections closes the current connections, as a server restart would.
+func (s *fakeRedis) dropConnections() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for c := range s.conns {
+		_ = c.Close()
+	}
+}
+
+// subscribers returns the number of subscribers of the channel.
+func (s *fakeRedis) subscribers(channel string) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return len(s.channels[channel])
+}
+
+func (s *fakeRedis) receivedCommands() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]string(nil), s.commands...)
+}
+
+func (s *fakeRedis) handle(c *fakeConn) {
+	defer func() {
+		_ = c.Close()
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		delete(s.conns, c)
+		for _, subs := range s.channels {
+			delete(subs, c)
+		}
+	}()
+	r := bufio.NewReader(c)
+	for {
+		reply, err := readReply(r, DefaultMaxMessageSize)
+		if err != nil {
+			return
+		}
+		items, ok := reply.([]any)
+		if !ok || len(items) == 0 {
+			c.write("-ERR invalid command\r\n")
+			continue
+		}
+		args := make([]string, len(items))
+		for i, item := range items {
+			b, _ := item.([]byte)
+			args[i] = string(b)
+		}
+		s.mu.Lock()
+		s.commands = append(s.commands, strings.ToUpper(args[0]))
+		s.mu.Unlock()
+		s.execute(c, args)
+	}
+}
+
+func (s *fakeRedis) execute(c *fakeConn, args []string) {
+	switch cmd := strings.ToUpper(args[0]); {
+	case cmd == "AUTH":
+		user, password := "default", args[len(args)-1]
+		if len(args) == 3 {
+			user = args[1]
+		}
+		if password != s.password || (s.username != "" && user != s.username) {
+			c.write("-WRONGPASS invalid username-password pair\r\n")
+			return
+		}
+		c.authed = true
+		c.write("+OK\r\n")
+	case !c.authed:
+		c.write("-NOAUTH Authentication required.\r\n")
+	case cmd == "PUBLISH" && len(args) == 3:
+		s.mu.Lock()
+		subs := make([]*fakeConn, 0, len(s.channels[args[1]]))
+		for sub := range s.channels[args[1]] {
+			subs = append(subs, sub)
+		}
+		s.mu.Unlock()
+		for _, sub := range subs {
+			sub.writeBulks("message", args[1], args[2])
+		}
+		c.write(":%d\r\n", len(subs))
+	case cmd == "SUBSCRIBE" && len(args) == 2:
+		s.mu.Lock()
+		if s.channels[args[1]] == nil {
+			s.channels[args[1]] = make(map[*fakeConn]struct{})
+		}
+		s.channels[args[1]][c] = struct{}{}
+		s.mu.Unlock()
+		c.write("*3\r\n$9\r\nsubscribe\r\n$%d\r\n%s\r\n:1\r\n", len(args[1]), args[1])
+	default:
+		c.write("-ERR unknown command '%s'\r\n", args[0])
+	}
+}
@@ -0,0 +1,121 @@
+// Copyright 2025 The A2A Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package redisbus
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+)
+
+// redisError is an error reply of the server.
+type redisError string
+
+func (e redisError) Error() string {
+	return "redis: " + string(e)
+}
+
+var errProtocol = errors.New("redis: protocol error")
+
+// writeCommand writes the command as a RESP array of bulk strings.
+func writeCommand(w *bufio.Writer, args ...[]byte) error {
+	if _, err := fmt.Fprintf(w, "*%d\r\n", len(args)); err != nil {
+		return err
+	}
+	for _, arg := range args {
+		if _, err := fmt.Fprintf(w, "$%d\r\n", len(arg)); err != nil {
+			return err
+		}
+		if _, err := w.Write(arg); err != nil {
+			return err
+		}
+		if _, err := w.WriteString("\r\n"); err != nil {
+			return err
+		}
+	}
+	return w.Flush()
+}
+
+// readReply reads a reply. Simple strings and bulk strings are returned as []byte,
+// integers as int64, arrays as []any, nulls as nil and error replies as redisError.
+// Bulk strings longer than maxSize bytes and arrays of more than maxSize elements
+// are rejected before anything is allocated for them.
+func readReply(r *bufio.Reader, maxSize int) (any, error) {
+	line, err := readLine(r)
+	if err != nil {
+		return nil, err
+	}
+	if len(line) == 0 {
+		return nil, errProtocol
+	}
+	switch line[0] {
+	case '+':
+		return line[1:], nil
+	case '-':
+		return redisError(line[1:]), nil
+	case ':':
+		return strconv.ParseInt(string(line[1:]), 10, 64)
+	case '$':
+		n, err := readLength(line, maxSize)
+		if err != nil || n < 0 {
+			return nil, err
+		}
+		buf := make([]byte, n+2)
+		if _, err := io.ReadFull(r, buf); err != nil {
+			return nil, err
+		}
+		return buf[:n], nil
+	case '*':
+		n, err := readLength(line, maxSize)
+		if err != nil || n < 0 {
+			return nil, err
+		}
+		items := make([]any, n)
+		for i := range items {
+			if items[i], err = readReply(r, maxSize); err != nil {
+				return nil, err
+			}
+		}
+		return items, nil
+	default:
+		return nil, fmt.Errorf("%w: unexpected reply type %q", errProtocol, line[0])
+	}
+}
+
+// readLength parses the length of a bulk string or an array. The length -1 denotes
+// a null value.
+func readLength(line []byte, maxSize int) (int, error) {
+	n, err := strconv.Atoi(string(line[1:]))
+	if err != nil || n < -1 {
+		return 0, fmt.Errorf("%w: invalid length %q", errProtocol, line[1:])
+	}
+	if n > maxSize {
+		return 0, fmt.Errorf("%w: length %d exceeds the limit of %d", errProtocol, n, maxSize)
+	}
+	return n, nil
+}
+
+func readLine(r *bufio.Reader) ([]byte, error) {
+	line, err := r.ReadSlice('\n')
+	if err != nil {
+		return nil, err
+	}
+	if len(line) < 2 || line[len(line)-2] != '\r' {
+		return nil, errProtocol
+	}
+	return append([]byte(nil), line[:len(line)-2]...), nil
+}
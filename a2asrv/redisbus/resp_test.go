@@ -0,0 +1,83 @@
+// Copyright 2025 The A2A Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package redisbus
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestReadReply(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		want    any
+		wantErr bool
+	}{
+		{name: "simple string", input: "+OK\r\n", want: []byte("OK")},
+		{name: "error", input: "-ERR failed\r\n", want: redisError("ERR failed")},
+		{name: "integer", input: ":42\r\n", want: int64(42)},
+		{name: "bulk string", input: "$5\r\nhe\r\no\r\n", want: []byte("he\r\no")},
+		{name: "empty bulk string", input: "$0\r\n\r\n", want: []byte{}},
+		{name: "null bulk string", input: "$-1\r\n", want: nil},
+		{name: "array", input: "*2\r\n$1\r\na\r\n:1\r\n", want: []any{[]byte("a"), int64(1)}},
+		{name: "null array", input: "*-1\r\n", want: nil},
+		{name: "bulk string at the limit", input: "$16\r\n" + strings.Repeat("x", 16) + "\r\n", want: []byte(strings.Repeat("x", 16))},
+		{name: "bulk string over the limit", input: "$17\r\n" + strings.Repeat("x", 17) + "\r\n", wantErr: true},
+		{name: "huge bulk string", input: "$9223372036854775807\r\n", wantErr: true},
+		{name: "negative bulk length", input: "$-2\r\n", wantErr: true},
+		{name: "array over the limit", input: "*17\r\n", wantErr: true},
+		{name: "negative array length", input: "*-5\r\n", wantErr: true},
+		{name: "invalid length", input: "$x\r\n", wantErr: true},
+		{name: "truncated bulk string", input: "$5\r\nab", wantErr: true},
+		{name: "missing carriage return", input: "+OK\n", wantErr: true},
+		{name: "empty line", input: "\r\n", wantErr: true},
+		{name: "unknown type", input: "%1\r\n", wantErr: true},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := readReply(bufio.NewReader(strings.NewReader(tc.input)), 16)
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("readReply() error = %v, want error: %v", err, tc.wantErr)
+			}
+			if !tc.wantErr && !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("readReply() = %#v, want %#v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestReadReplyLengthErrors(t *testing.T) {
+	for _, input := range []string{"$17\r\n", "$-2\r\n", "*17\r\n"} {
+		if _, err := readReply(bufio.NewReader(strings.NewReader(input)), 16); !errors.Is(err, errProtocol) {
+			t.Errorf("readReply(%q) error = %v, want %v", input, err, errProtocol)
+		}
+	}
+}
+
+func TestWriteCommand(t *testing.T) {
+	var buf bytes.Buffer
+	if err := writeCommand(bufio.NewWriter(&buf), []byte("PUBLISH"), []byte("ch"), []byte("a\r\nb")); err != nil {
+		t.Fatalf("writeCommand() error = %v", err)
+	}
+	want := "*3\r\n$7\r\nPUBLISH\r\n$2\r\nch\r\n$4\r\na\r\nb\r\n"
+	if got := buf.String(); got != want {
+		t.Errorf("writeCommand() wrote %q, want %q", got, want)
+	}
+}
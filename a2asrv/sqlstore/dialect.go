@@ -0,0 +1,81 @@
+// Copyright 2025 The A2A Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sqlstore
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Dialect is the SQL flavor of a database.
+type Dialect int
+
+const (
+	// SQLite is the dialect of SQLite 3.24 or later.
+	SQLite Dialect = iota + 1
+	// Postgres is the dialect of PostgreSQL 9.5 or later.
+	Postgres
+)
+
+func (d Dialect) String() string {
+	switch d {
+	case SQLite:
+		return "sqlite"
+	case Postgres:
+		return "postgres"
+	default:
+		return "Dialect(" + strconv.Itoa(int(d)) + ")"
+	}
+}
+
+func (d Dialect) validate() error {
+	if d != SQLite && d != Postgres {
+		return fmt.Errorf("unsupported dialect %s", d)
+	}
+	return nil
+}
+
+// rebind replaces the ? placeholders of the query with the ones of the dialect.
+func (d Dialect) rebind(query string) string {
+	if d != Postgres {
+		return query
+	}
+	var sb strings.Builder
+	n := 0
+	for _, r := range query {
+		if r != '?' {
+			sb.WriteRune(r)
+			continue
+		}
+		n++
+		sb.WriteString("$" + strconv.Itoa(n))
+	}
+	return sb.String()
+}
+
+func (d Dialect) blobType() string {
+	if d == Postgres {
+		return "BYTEA"
+	}
+	return "BLOB"
+}
+
+func (d Dialect) timestampType() string {
+	if d == Postgres {
+		return "TIMESTAMPTZ"
+	}
+	return "TIMESTAMP"
+}
@@ -0,0 +1,38 @@
+// Copyright 2025 The A2A Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package sqlstore persists A2A server state in a SQL database using database/sql.
+//
+// TaskStore implements a2asrv.TaskStore. Tasks are stored as serialized protocol
+// buffers, together with indexed id, context_id, state and updated_at columns which
+// can be used to query the tasks directly. PushConfigStore implements
+// a2asrv.PushConfigStore in a separate table, keyed by task and config IDs.
+//
+// The package does not depend on a specific driver. The database is opened by the
+// application, and the Dialect tells the store which SQL flavor to use:
+//
+//	db, err := sql.Open("sqlite", "file:tasks.db") // modernc.org/sqlite
+//	...
+//	store := sqlstore.NewTaskStore(db, sqlstore.SQLite)
+//	if err := store.Migrate(ctx); err != nil {
+//		...
+//	}
+//	handler := a2asrv.NewHandler(executor, a2asrv.WithTaskStore(store))
+//
+// Migrate creates or upgrades the schema. It records the applied migrations in a
+// separate table, so it is safe to call on every start. Concurrent calls from
+// several replicas are serialized: on Postgres with a table lock, on SQLite with an
+// immediate transaction. SQLite reports a busy database instead of waiting for the
+// lock unless a busy timeout is set, e.g. "file:tasks.db?_pragma=busy_timeout(5000)".
+package sqlstore
@@ -0,0 +1,161 @@
+// Copyright 2025 The A2A Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sqlstore
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+)
+
+// migration is a step of the schema evolution. Migrations are applied in order
+// and never modified once released.
+type migration struct {
+	version    int
+	statements func(d Dialect, table string) []string
+}
+
+var taskMigrations = []migration{
+	{
+		version: 1,
+		statements: func(d Dialect, table string) []string {
+			return []string{
+				`CREATE TABLE ` + table + ` (
+					id TEXT PRIMARY KEY,
+					context_id TEXT NOT NULL,
+					state TEXT NOT NULL,
+					version BIGINT NOT NULL,
+					updated_at ` + d.timestampType() + ` NOT NULL,
+					data ` + d.blobType() + ` NOT NULL
+				)`,
+				`CREATE INDEX ` + table + `_context_id_idx ON ` + table + ` (context_id)`,
+				`CREATE INDEX ` + table + `_state_idx ON ` + table + ` (state)`,
+				`CREATE INDEX ` + table + `_updated_at_idx ON ` + table + ` (updated_at)`,
+			}
+		},
+	},
+}
+
+var pushConfigMigrations = []migration{
+	{
+		version: 1,
+		statements: func(d Dialect, table string) []string {
+			return []string{
+				`CREATE TABLE ` + table + ` (
+					task_id TEXT NOT NULL,
+					id TEXT NOT NULL,
+					updated_at ` + d.timestampType() + ` NOT NULL,
+					data ` + d.blobType() + ` NOT NULL,
+					PRIMARY KEY (task_id, id)
+				)`,
+			}
+		},
+	},
+}
+
+// migrate applies the migrations which are not recorded in the migrations table
+// of the given table yet. All the pending migrations are applied in a single transaction.
+func migrate(ctx context.Context, db *sql.DB, d Dialect, table string, migrations []migration) error {
+	if err := d.validate(); err != nil {
+		return err
+	}
+	migrationsTable := table + "_migrations"
+	_, err := db.ExecContext(ctx, `CREATE TABLE IF NOT EXISTS `+migrationsTable+` (
+		version INTEGER PRIMARY KEY,
+		applied_at `+d.timestampType()+` NOT NULL
+	)`)
+	if err != nil {
+		return fmt.Errorf("failed to create migrations table: %w", err)
+	}
+
+	return withMigrationLock(ctx, db, d, migrationsTable, func(q querier) error {
+		var current int
+		row := q.QueryRowContext(ctx, `SELECT COALESCE(MAX(version), 0) FROM `+migrationsTable)
+		if err := row.Scan(&current); err != nil {
+			return fmt.Errorf("failed to read schema version: %w", err)
+		}
+		for _, m := range migrations {
+			if m.version <= current {
+				continue
+			}
+			for _, stmt := range m.statements(d, table) {
+				if _, err := q.ExecContext(ctx, stmt); err != nil {
+					return fmt.Errorf("migration %d failed: %w", m.version, err)
+				}
+			}
+			insert := d.rebind(`INSERT INTO ` + migrationsTable + ` (version, applied_at) VALUES (?, ?)`)
+			if _, err := q.ExecContext(ctx, insert, m.version, time.Now().UTC()); err != nil {
+				return fmt.Errorf("failed to record migration %d: %w", m.version, err)
+			}
+		}
+		return nil
+	})
+}
+
+// querier is implemented by *sql.Tx and *sql.Conn.
+type querier interface {
+	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
+	QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row
+}
+
+// withMigrationLock runs fn in a transaction which excludes concurrent migrations
+// of the table, so that every migration is applied once.
+func withMigrationLock(ctx context.Context, db *sql.DB, d Dialect, migrationsTable string, fn func(q querier) error) error {
+	if d == SQLite {
+		return withImmediateTx(ctx, db, fn)
+	}
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to start migration: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+	if _, err := tx.ExecContext(ctx, `LOCK TABLE `+migrationsTable+` IN EXCLUSIVE MODE`); err != nil {
+		return fmt.Errorf("failed to lock migrations table: %w", err)
+	}
+	if err := fn(tx); err != nil {
+		return err
+	}
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit migration: %w", err)
+	}
+	return nil
+}
+
+// withImmediateTx runs fn in a SQLite transaction started with BEGIN IMMEDIATE, which
+// takes the write lock upfront. Transactions started by database/sql are deferred and
+// only take the lock on the first write, so concurrent migrations could both read the
+// same schema version. The statements run on a dedicated connection, because the
+// transaction is not managed by database/sql.
+func withImmediateTx(ctx context.Context, db *sql.DB, fn func(q querier) error) error {
+	conn, err := db.Conn(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to start migration: %w", err)
+	}
+	defer func() { _ = conn.Close() }()
+	if _, err := conn.ExecContext(ctx, `BEGIN IMMEDIATE`); err != nil {
+		return fmt.Errorf("failed to start migration: %w", err)
+	}
+	rollback := func() { _, _ = conn.ExecContext(context.WithoutCancel(ctx), `ROLLBACK`) }
+	if err := fn(conn); err != nil {
+		rollback()
+		return err
+	}
+	if _, err := conn.ExecContext(ctx, `COMMIT`); err != nil {
+		rollback()
+		return fmt.Errorf("failed to commit migration: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,144 @@
+// Copyright 2025 The A2A Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sqlstore
+
+import (
+	"context"
+	"database/sql"
+	"path/filepath"
+	"sync"
+	"testing"
+)
+
+func appliedVersions(t *testing.T, db *sql.DB, table string) []int {
+	t.Helper()
+	rows, err := db.Query(`SELECT version FROM ` + table + `_migrations ORDER BY version`)
+	if err != nil {
+		t.Fatalf("failed to query migrations: %v", err)
+	}
+	defer func() { _ = rows.Close() }()
+	var versions []int
+	for rows.Next() {
+		var v int
+		if err := rows.Scan(&v); err != nil {
+			t.Fatalf("failed to scan migration: %v", err)
+		}
+		versions = append(versions, v)
+	}
+	if err := rows.Err(); err != nil {
+		t.Fatalf("failed to read migrations: %v", err)
+	}
+	return versions
+}
+
+func TestMigrate(t *testing.T) {
+	ctx := context.Background()
+	db := openTestDB(t)
+	for range 2 {
+		if err := migrate(ctx, db, SQLite, "tasks", taskMigrations); err != nil {
+			t.Fatalf("migrate() error = %v", err)
+		}
+	}
+	if got := appliedVersions(t, db, "tasks"); len(got) != 1 || got[0] != 1 {
+		t.Fatalf("applied migrations = %v, want [1]", got)
+	}
+
+	upgraded := append(taskMigrations[:len(taskMigrations):len(taskMigrations)], migration{
+		version: 2,
+		statements: func(_ Dialect, table string) []string {
+			return []string{`ALTER TABLE ` + table + ` ADD COLUMN owner TEXT`}
+		},
+	})
+	if err := migrate(ctx, db, SQLite, "tasks", upgraded); err != nil {
+		t.Fatalf("migrate() with a new migration error = %v", err)
+	}
+	if got := appliedVersions(t, db, "tasks"); len(got) != 2 || got[1] != 2 {
+		t.Errorf("applied migrations = %v, want [1 2]", got)
+	}
+	if _, err := db.Exec(`UPDATE tasks SET owner = 'x'`); err != nil {
+		t.Errorf("the new column is missing: %v", err)
+	}
+}
+
+func TestMigrateRollsBackFailedMigration(t *testing.T) {
+	ctx := context.Background()
+	db := openTestDB(t)
+	broken := []migration{{
+		version: 1,
+		statements: func(_ Dialect, table string) []string {
+			return []string{`CREATE TABLE ` + table + ` (id TEXT)`, `NOT VALID SQL`}
+		},
+	}}
+	if err := migrate(ctx, db, SQLite, "tasks", broken); err == nil {
+		t.Fatal("migrate() error = nil, want error")
+	}
+	if got := appliedVersions(t, db, "tasks"); len(got) != 0 {
+		t.Errorf("applied migrations = %v, want none", got)
+	}
+	// The statements applied before the failure are rolled back, so the store can be migrated.
+	if err := migrate(ctx, db, SQLite, "tasks", taskMigrations); err != nil {
+		t.Errorf("migrate() after a failed migration error = %v", err)
+	}
+}
+
+func TestMigrateConcurrently(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "test.db")
+	// Every migration creates tables, so applying one twice fails.
+	const replicas = 8
+	var wg sync.WaitGroup
+	errs := make(chan error, replicas)
+	for range replicas {
+		db := openTestDBFile(t, path)
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			errs <- NewTaskStore(db, SQLite).Migrate(ctx)
+		}()
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		if err != nil {
+			t.Errorf("Migrate() error = %v", err)
+		}
+	}
+	if got := appliedVersions(t, openTestDBFile(t, path), DefaultTaskTable); len(got) != len(taskMigrations) {
+		t.Errorf("applied migrations = %v, want %d", got, len(taskMigrations))
+	}
+}
+
+func TestMigrateUnsupportedDialect(t *testing.T) {
+	if err := NewTaskStore(openTestDB(t), Dialect(0)).Migrate(context.Background()); err == nil {
+		t.Error("Migrate() error = nil, want error")
+	}
+}
+
+func TestRebind(t *testing.T) {
+	tests := []struct {
+		dialect Dialect
+		query   string
+		want    string
+	}{
+		{dialect: SQLite, query: `SELECT * FROM t WHERE a = ? AND b = ?`, want: `SELECT * FROM t WHERE a = ? AND b = ?`},
+		{dialect: Postgres, query: `SELECT * FROM t WHERE a = ? AND b = ?`, want: `SELECT * FROM t WHERE a = $1 AND b = $2`},
+		{dialect: Postgres, query: `DELETE FROM t`, want: `DELETE FROM t`},
+	}
+	for _, tc := range tests {
+		if got := tc.dialect.rebind(tc.query); got != tc.want {
+			t.Errorf("%s.rebind(%q) = %q, want %q", tc.dialect, tc.query, got, tc.want)
+		}
+	}
+}
@@ -0,0 +1,166 @@
+// Copyright 2025 The A2A Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sqlstore
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"google.golang.org/protobuf/proto"
+
+	"github.com/a2aproject/a2a-go/a2a"
+	"github.com/a2aproject/a2a-go/a2asrv"
+	a2apb "github.com/a2aproject/a2a-go/grpc"
+	"github.com/a2aproject/a2a-go/internal/uuid"
+)
+
+// DefaultPushConfigTable is the name of the table PushConfigStore uses by default.
+const DefaultPushConfigTable = "a2a_push_configs"
+
+// PushConfigStoreOption configures a PushConfigStore.
+type PushConfigStoreOption func(*PushConfigStore)
+
+// WithPushConfigTable makes the store use the table instead of DefaultPushConfigTable.
+// The name is used in SQL statements as is and must not come from untrusted input.
+func WithPushConfigTable(name string) PushConfigStoreOption {
+	return func(s *PushConfigStore) {
+		s.table = name
+	}
+}
+
+// PushConfigStore is an a2asrv.PushConfigStore which keeps push notification configs
+// in a SQL database.
+type PushConfigStore struct {
+	db      *sql.DB
+	dialect Dialect
+	table   string
+}
+
+var _ a2asrv.PushConfigStore = (*PushConfigStore)(nil)
+
+// NewPushConfigStore returns a PushConfigStore using the database. Migrate must be
+// called before the store is used for the first time.
+func NewPushConfigStore(db *sql.DB, dialect Dialect, opts ...PushConfigStoreOption) *PushConfigStore {
+	s := &PushConfigStore{db: db, dialect: dialect, table: DefaultPushConfigTable}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
+}
+
+// Migrate creates the tables of the store or upgrades them to the latest schema.
+func (s *PushConfigStore) Migrate(ctx context.Context) error {
+	return migrate(ctx, s.db, s.dialect, s.table, pushConfigMigrations)
+}
+
+// Save implements a2asrv.PushConfigStore.
+func (s *PushConfigStore) Save(ctx context.Context, taskID string, config *a2apb.PushNotificationConfig) (*a2apb.PushNotificationConfig, error) {
+	config = proto.Clone(config).(*a2apb.PushNotificationConfig)
+	if config.GetId() == "" {
+		config.Id = uuid.New()
+	}
+	name := a2a.PushConfigName(taskID, config.GetId())
+	data, err := proto.Marshal(config)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode push notification config %s: %w", name, err)
+	}
+	query := s.dialect.rebind(`INSERT INTO ` + s.table + ` (task_id, id, updated_at, data) VALUES (?, ?, ?, ?)
+		ON CONFLICT (task_id, id) DO UPDATE SET updated_at = excluded.updated_at, data = excluded.data`)
+	if _, err := s.db.ExecContext(ctx, query, taskID, config.GetId(), time.Now().UTC(), data); err != nil {
+		return nil, fmt.Errorf("failed to save push notification config %s: %w", name, err)
+	}
+	return config, nil
+}
+
+// Get implements a2asrv.PushConfigStore.
+func (s *PushConfigStore) Get(ctx context.Context, taskID, configID string) (*a2apb.PushNotificationConfig, error) {
+	name := a2a.PushConfigName(taskID, configID)
+	var data []byte
+	query := s.dialect.rebind(`SELECT data FROM ` + s.table + ` WHERE task_id = ? AND id = ?`)
+	err := s.db.QueryRowContext(ctx, query, taskID, configID).Scan(&data)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("%w: %s", a2asrv.ErrPushConfigNotFound, name)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to load push notification config %s: %w", name, err)
+	}
+	config := &a2apb.PushNotificationConfig{}
+	if err := proto.Unmarshal(data, config); err != nil {
+		return nil, fmt.Errorf("failed to decode push notification config %s: %w", name, err)
+	}
+	return config, nil
+}
+
+// List implements a2asrv.PushConfigStore.
+func (s *PushConfigStore) List(ctx context.Context, taskID string, pageSize int, pageToken string) ([]*a2apb.PushNotificationConfig, string, error) {
+	after, err := a2asrv.DecodePageToken(pageToken)
+	if err != nil {
+		return nil, "", err
+	}
+	query := `SELECT data FROM ` + s.table + ` WHERE task_id = ? AND id > ? ORDER BY id`
+	args := []any{taskID, after}
+	if pageSize > 0 {
+		// One more row than requested tells whether there is a next page.
+		query += ` LIMIT ?`
+		args = append(args, pageSize+1)
+	}
+	rows, err := s.db.QueryContext(ctx, s.dialect.rebind(query), args...)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to list push notification configs of task %s: %w", taskID, err)
+	}
+	defer func() { _ = rows.Close() }()
+	var configs []*a2apb.PushNotificationConfig
+	for rows.Next() {
+		var data []byte
+		if err := rows.Scan(&data); err != nil {
+			return nil, "", fmt.Errorf("failed to list push notification configs of task %s: %w", taskID, err)
+		}
+		config := &a2apb.PushNotificationConfig{}
+		if err := proto.Unmarshal(data, config); err != nil {
+			return nil, "", fmt.Errorf("failed to decode push notification config of task %s: %w", taskID, err)
+		}
+		configs = append(configs, config)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, "", fmt.Errorf("failed to list push notification configs of task %s: %w", taskID, err)
+	}
+	next := ""
+	if pageSize > 0 && len(configs) > pageSize {
+		configs = configs[:pageSize]
+		next = a2asrv.EncodePageToken(configs[pageSize-1].GetId())
+	}
+	return configs, next, nil
+}
+
+// Delete implements a2asrv.PushConfigStore.
+func (s *PushConfigStore) Delete(ctx context.Context, taskID, configID string) error {
+	name := a2a.PushConfigName(taskID, configID)
+	query := s.dialect.rebind(`DELETE FROM ` + s.table + ` WHERE task_id = ? AND id = ?`)
+	result, err := s.db.ExecContext(ctx, query, taskID, configID)
+	if err != nil {
+		return fmt.Errorf("failed to delete push notification config %s: %w", name, err)
+	}
+	n, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to delete push notification config %s: %w", name, err)
+	}
+	if n == 0 {
+		return fmt.Errorf("%w: %s", a2asrv.ErrPushConfigNotFound, name)
+	}
+	return nil
+}
@@ -0,0 +1,158 @@
+// Copyright 2025 The A2A Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sqlstore
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"google.golang.org/protobuf/proto"
+
+	"github.com/a2aproject/a2a-go/a2a"
+	"github.com/a2aproject/a2a-go/a2asrv"
+	a2apb "github.com/a2aproject/a2a-go/grpc"
+)
+
+func newTestPushConfigStore(t *testing.T) *PushConfigStore {
+	t.Helper()
+	store := NewPushConfigStore(openTestDB(t), SQLite)
+	if err := store.Migrate(context.Background()); err != nil {
+		t.Fatalf("Migrate() error = %v", err)
+	}
+	return store
+}
+
+func TestPushConfigStore(t *testing.T) {
+	ctx := context.Background()
+	store := newTestPushConfigStore(t)
+	config := &a2apb.PushNotificationConfig{
+		Url:            "https://client.example.com/webhook",
+		Token:          "secret",
+		Authentication: &a2apb.AuthenticationInfo{Schemes: []string{"Bearer"}, Credentials: "abc"},
+	}
+
+	saved, err := store.Save(ctx, "t1", config)
+	if err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+	if saved.GetId() == "" || config.GetId() != "" {
+		t.Fatalf("Save() = %v, want a generated ID without modifying the argument", saved)
+	}
+	got, err := store.Get(ctx, "t1", saved.GetId())
+	if err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+	if !proto.Equal(got, saved) {
+		t.Errorf("Get() = %v, want %v", got, saved)
+	}
+	if _, err := store.Get(ctx, "t2", saved.GetId()); !errors.Is(err, a2asrv.ErrPushConfigNotFound) {
+		t.Errorf("Get() for another task error = %v, want %v", err, a2asrv.ErrPushConfigNotFound)
+	}
+
+	saved.Url = "https://client.example.com/other"
+	if _, err := store.Save(ctx, "t1", saved); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+	if got, _ := store.Get(ctx, "t1", saved.GetId()); got.GetUrl() != saved.GetUrl() {
+		t.Errorf("Get() URL = %q after replacing the config, want %q", got.GetUrl(), saved.GetUrl())
+	}
+	if configs, _, _ := store.List(ctx, "t1", 0, ""); len(configs) != 1 {
+		t.Errorf("List() = %v, want the replaced config only", configs)
+	}
+
+	if err := store.Delete(ctx, "t1", saved.GetId()); err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+	if err := store.Delete(ctx, "t1", saved.GetId()); !errors.Is(err, a2asrv.ErrPushConfigNotFound) {
+		t.Errorf("Delete() of a deleted config error = %v, want %v", err, a2asrv.ErrPushConfigNotFound)
+	}
+	if _, err := store.Get(ctx, "t1", saved.GetId()); !errors.Is(err, a2asrv.ErrPushConfigNotFound) {
+		t.Errorf("Get() of a deleted config error = %v, want %v", err, a2asrv.ErrPushConfigNotFound)
+	}
+}
+
+func TestPushConfigStoreList(t *testing.T) {
+	ctx := context.Background()
+	store := newTestPushConfigStore(t)
+	for _, id := range []string{"c", "a", "e", "b", "d"} {
+		if _, err := store.Save(ctx, "t1", &a2apb.PushNotificationConfig{Id: id}); err != nil {
+			t.Fatalf("Save() error = %v", err)
+		}
+	}
+	if _, err := store.Save(ctx, "t2", &a2apb.PushNotificationConfig{Id: "x"}); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+
+	tests := []struct {
+		pageSize  int
+		wantPages []string
+	}{
+		{pageSize: 0, wantPages: []string{"abcde"}},
+		{pageSize: 1, wantPages: []string{"a", "b", "c", "d", "e"}},
+		{pageSize: 2, wantPages: []string{"ab", "cd", "e"}},
+		{pageSize: 5, wantPages: []string{"abcde"}},
+	}
+	for _, tc := range tests {
+		t.Run(fmt.Sprintf("page size %d", tc.pageSize), func(t *testing.T) {
+			var pages []string
+			token := ""
+			for {
+				configs, next, err := store.List(ctx, "t1", tc.pageSize, token)
+				if err != nil {
+					t.Fatalf("List() error = %v", err)
+				}
+				page := ""
+				for _, config := range configs {
+					page += config.GetId()
+				}
+				pages = append(pages, page)
+				if next == "" {
+					break
+				}
+				token = next
+			}
+			if fmt.Sprint(pages) != fmt.Sprint(tc.wantPages) {
+				t.Errorf("pages = %v, want %v", pages, tc.wantPages)
+			}
+		})
+	}
+
+	if _, _, err := store.List(ctx, "t1", 1, "invalid"); !errors.Is(err, a2a.ErrInvalidParams) {
+		t.Errorf("List() with an invalid page token error = %v, want %v", err, a2a.ErrInvalidParams)
+	}
+	// Tokens are interchangeable with the in-memory store.
+	configs, _, err := store.List(ctx, "t1", 0, a2asrv.EncodePageToken("c"))
+	if err != nil || len(configs) != 2 {
+		t.Errorf("List() after c = %v, %v, want d and e", configs, err)
+	}
+}
+
+func TestPushConfigStoreMigrate(t *testing.T) {
+	ctx := context.Background()
+	db := openTestDB(t)
+	tasks := NewTaskStore(db, SQLite)
+	configs := NewPushConfigStore(db, SQLite)
+	// The stores share a database but keep separate migration histories.
+	for _, m := range []interface{ Migrate(context.Context) error }{tasks, configs, tasks, configs} {
+		if err := m.Migrate(ctx); err != nil {
+			t.Fatalf("Migrate() error = %v", err)
+		}
+	}
+	if got := appliedVersions(t, db, DefaultPushConfigTable); len(got) != len(pushConfigMigrations) {
+		t.Errorf("applied migrations = %v, want %d", got, len(pushConfigMigrations))
+	}
+}
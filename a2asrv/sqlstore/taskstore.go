@@ -0,0 +1,141 @@
+// Copyright 2025 The A2A Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sqlstore
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"google.golang.org/protobuf/proto"
+
+	"github.com/a2aproject/a2a-go/a2a"
+	"github.com/a2aproject/a2a-go/a2asrv"
+	a2apb "github.com/a2aproject/a2a-go/grpc"
+)
+
+// DefaultTaskTable is the name of the table TaskStore uses by default.
+const DefaultTaskTable = "a2a_tasks"
+
+// TaskStoreOption configures a TaskStore.
+type TaskStoreOption func(*TaskStore)
+
+// WithTaskTable makes the store use the table instead of DefaultTaskTable.
+// The name is used in SQL statements as is and must not come from untrusted input.
+func WithTaskTable(name string) TaskStoreOption {
+	return func(s *TaskStore) {
+		s.table = name
+	}
+}
+
+// TaskStore is an a2asrv.TaskStore which keeps tasks in a SQL database.
+type TaskStore struct {
+	db      *sql.DB
+	dialect Dialect
+	table   string
+}
+
+var _ a2asrv.TaskStore = (*TaskStore)(nil)
+
+// NewTaskStore returns a TaskStore using the database. Migrate must be called
+// before the store is used for the first time.
+func NewTaskStore(db *sql.DB, dialect Dialect, opts ...TaskStoreOption) *TaskStore {
+	s := &TaskStore{db: db, dialect: dialect, table: DefaultTaskTable}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
+}
+
+// Migrate creates the tables of the store or upgrades them to the latest schema.
+func (s *TaskStore) Migrate(ctx context.Context) error {
+	return migrate(ctx, s.db, s.dialect, s.table, taskMigrations)
+}
+
+// Get implements a2asrv.TaskStore.
+func (s *TaskStore) Get(ctx context.Context, taskID string) (*a2apb.Task, a2asrv.TaskVersion, error) {
+	var (
+		data    []byte
+		version int64
+	)
+	query := s.dialect.rebind(`SELECT data, version FROM ` + s.table + ` WHERE id = ?`)
+	err := s.db.QueryRowContext(ctx, query, taskID).Scan(&data, &version)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, a2asrv.TaskVersionMissing, fmt.Errorf("%w: %s", a2a.ErrTaskNotFound, taskID)
+	}
+	if err != nil {
+		return nil, a2asrv.TaskVersionMissing, fmt.Errorf("failed to load task %s: %w", taskID, err)
+	}
+	task := &a2apb.Task{}
+	if err := proto.Unmarshal(data, task); err != nil {
+		return nil, a2asrv.TaskVersionMissing, fmt.Errorf("failed to decode task %s: %w", taskID, err)
+	}
+	return task, a2asrv.TaskVersion(version), nil
+}
+
+// Save implements a2asrv.TaskStore.
+func (s *TaskStore) Save(ctx context.Context, task *a2apb.Task, prev a2asrv.TaskVersion) (a2asrv.TaskVersion, error) {
+	if task.GetId() == "" {
+		return a2asrv.TaskVersionMissing, fmt.Errorf("%w: task ID is required", a2a.ErrInvalidParams)
+	}
+	data, err := proto.Marshal(task)
+	if err != nil {
+		return a2asrv.TaskVersionMissing, fmt.Errorf("failed to encode task %s: %w", task.GetId(), err)
+	}
+	version := prev + 1
+	state := task.GetStatus().GetState().String()
+	updatedAt := time.Now().UTC()
+
+	var result sql.Result
+	if prev == a2asrv.TaskVersionMissing {
+		query := s.dialect.rebind(`INSERT INTO ` + s.table + ` (id, context_id, state, version, updated_at, data)
+			VALUES (?, ?, ?, ?, ?, ?) ON CONFLICT (id) DO NOTHING`)
+		result, err = s.db.ExecContext(ctx, query, task.GetId(), task.GetContextId(), state, int64(version), updatedAt, data)
+	} else {
+		query := s.dialect.rebind(`UPDATE ` + s.table + ` SET context_id = ?, state = ?, version = ?, updated_at = ?, data = ?
+			WHERE id = ? AND version = ?`)
+		result, err = s.db.ExecContext(ctx, query, task.GetContextId(), state, int64(version), updatedAt, data, task.GetId(), int64(prev))
+	}
+	if err != nil {
+		return a2asrv.TaskVersionMissing, fmt.Errorf("failed to save task %s: %w", task.GetId(), err)
+	}
+	n, err := result.RowsAffected()
+	if err != nil {
+		return a2asrv.TaskVersionMissing, fmt.Errorf("failed to save task %s: %w", task.GetId(), err)
+	}
+	if n == 0 {
+		return a2asrv.TaskVersionMissing, fmt.Errorf("%w: task %s is not at version %d", a2asrv.ErrConcurrentModification, task.GetId(), prev)
+	}
+	return version, nil
+}
+
+// Delete implements a2asrv.TaskStore.
+func (s *TaskStore) Delete(ctx context.Context, taskID string) error {
+	query := s.dialect.rebind(`DELETE FROM ` + s.table + ` WHERE id = ?`)
+	result, err := s.db.ExecContext(ctx, query, taskID)
+	if err != nil {
+		return fmt.Errorf("failed to delete task %s: %w", taskID, err)
+	}
+	n, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to delete task %s: %w", taskID, err)
+	}
+	if n == 0 {
+		return fmt.Errorf("%w: %s", a2a.ErrTaskNotFound, taskID)
+	}
+	return nil
+}
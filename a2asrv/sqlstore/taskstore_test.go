@@ -0,0 +1,208 @@
+// Copyright 2025 The A2A Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sqlstore
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+
+	"google.golang.org/protobuf/proto"
+
+	"github.com/a2aproject/a2a-go/a2a"
+	"github.com/a2aproject/a2a-go/a2asrv"
+	a2apb "github.com/a2aproject/a2a-go/grpc"
+)
+
+func newTestTaskStore(t *testing.T) *TaskStore {
+	t.Helper()
+	store := NewTaskStore(openTestDB(t), SQLite, WithTaskTable("tasks"))
+	if err := store.Migrate(context.Background()); err != nil {
+		t.Fatalf("Migrate() error = %v", err)
+	}
+	return store
+}
+
+func TestTaskStore(t *testing.T) {
+	ctx := context.Background()
+	store := newTestTaskStore(t)
+	task := &a2apb.Task{
+		Id:        "t1",
+		ContextId: "c1",
+		Status:    &a2apb.TaskStatus{State: a2apb.TaskState_TASK_STATE_SUBMITTED},
+		History:   []*a2apb.Message{{MessageId: "m1", Role: a2apb.Role_ROLE_USER}},
+	}
+
+	if _, _, err := store.Get(ctx, "t1"); !errors.Is(err, a2a.ErrTaskNotFound) {
+		t.Fatalf("Get() of a missing task error = %v, want %v", err, a2a.ErrTaskNotFound)
+	}
+	v1, err := store.Save(ctx, task, a2asrv.TaskVersionMissing)
+	if err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+	got, version, err := store.Get(ctx, "t1")
+	if err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+	if version != v1 || !proto.Equal(got, task) {
+		t.Errorf("Get() = %v at version %d, want %v at version %d", got, version, task, v1)
+	}
+
+	task.Status.State = a2apb.TaskState_TASK_STATE_COMPLETED
+	v2, err := store.Save(ctx, task, v1)
+	if err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+	if v2 == v1 {
+		t.Errorf("Save() returned the previous version %d", v2)
+	}
+	var state string
+	if err := store.db.QueryRow(`SELECT state FROM tasks WHERE id = 't1'`).Scan(&state); err != nil {
+		t.Fatalf("failed to query the state column: %v", err)
+	}
+	if state != a2apb.TaskState_TASK_STATE_COMPLETED.String() {
+		t.Errorf("state column = %q, want %q", state, a2apb.TaskState_TASK_STATE_COMPLETED)
+	}
+
+	if err := store.Delete(ctx, "t1"); err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+	if err := store.Delete(ctx, "t1"); !errors.Is(err, a2a.ErrTaskNotFound) {
+		t.Errorf("Delete() of a deleted task error = %v, want %v", err, a2a.ErrTaskNotFound)
+	}
+	if _, _, err := store.Get(ctx, "t1"); !errors.Is(err, a2a.ErrTaskNotFound) {
+		t.Errorf("Get() of a deleted task error = %v, want %v", err, a2a.ErrTaskNotFound)
+	}
+}
+
+func TestTaskStoreVersionConflicts(t *testing.T) {
+	ctx := context.Background()
+	task := &a2apb.Task{Id: "t1", ContextId: "c1"}
+	tests := []struct {
+		name string
+		prev func(saved a2asrv.TaskVersion) a2asrv.TaskVersion
+	}{
+		{name: "create existing task", prev: func(a2asrv.TaskVersion) a2asrv.TaskVersion { return a2asrv.TaskVersionMissing }},
+		{name: "stale version", prev: func(saved a2asrv.TaskVersion) a2asrv.TaskVersion { return saved - 1 }},
+		{name: "future version", prev: func(saved a2asrv.TaskVersion) a2asrv.TaskVersion { return saved + 1 }},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			store := newTestTaskStore(t)
+			v1, err := store.Save(ctx, task, a2asrv.TaskVersionMissing)
+			if err != nil {
+				t.Fatalf("Save() error = %v", err)
+			}
+			saved, err := store.Save(ctx, task, v1)
+			if err != nil {
+				t.Fatalf("Save() error = %v", err)
+			}
+			if _, err := store.Save(ctx, task, tc.prev(saved)); !errors.Is(err, a2asrv.ErrConcurrentModification) {
+				t.Errorf("Save() error = %v, want %v", err, a2asrv.ErrConcurrentModification)
+			}
+		})
+	}
+
+	t.Run("update missing task", func(t *testing.T) {
+		if _, err := newTestTaskStore(t).Save(ctx, task, 3); !errors.Is(err, a2asrv.ErrConcurrentModification) {
+			t.Errorf("Save() error = %v, want %v", err, a2asrv.ErrConcurrentModification)
+		}
+	})
+
+	t.Run("missing id", func(t *testing.T) {
+		if _, err := newTestTaskStore(t).Save(ctx, &a2apb.Task{}, a2asrv.TaskVersionMissing); !errors.Is(err, a2a.ErrInvalidParams) {
+			t.Errorf("Save() error = %v, want %v", err, a2a.ErrInvalidParams)
+		}
+	})
+}
+
+func TestTaskStoreConcurrentSaves(t *testing.T) {
+	ctx := context.Background()
+	store := newTestTaskStore(t)
+	v1, err := store.Save(ctx, &a2apb.Task{Id: "t1"}, a2asrv.TaskVersionMissing)
+	if err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+
+	const writers = 8
+	var wg sync.WaitGroup
+	errs := make(chan error, writers)
+	for range writers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := store.Save(ctx, &a2apb.Task{Id: "t1"}, v1)
+			errs <- err
+		}()
+	}
+	wg.Wait()
+	close(errs)
+	succeeded := 0
+	for err := range errs {
+		switch {
+		case err == nil:
+			succeeded++
+		case !errors.Is(err, a2asrv.ErrConcurrentModification):
+			t.Errorf("Save() error = %v, want %v", err, a2asrv.ErrConcurrentModification)
+		}
+	}
+	if succeeded != 1 {
+		t.Errorf("%d concurrent saves succeeded, want 1", succeeded)
+	}
+}
+
+func TestTaskStoreWithHandler(t *testing.T) {
+	store := newTestTaskStore(t)
+	executor := &echoExecutor{}
+	h := a2asrv.NewHandler(executor, a2asrv.WithTaskStore(store))
+	resp, err := h.SendMessage(context.Background(), &a2apb.SendMessageRequest{Request: &a2apb.Message{
+		MessageId: "m1",
+		Role:      a2apb.Role_ROLE_USER,
+		Content:   []*a2apb.Part{{Part: &a2apb.Part_Text{Text: "hello"}}},
+	}, Configuration: &a2apb.SendMessageConfiguration{Blocking: true}})
+	if err != nil {
+		t.Fatalf("SendMessage() error = %v", err)
+	}
+	stored, _, err := store.Get(context.Background(), resp.GetTask().GetId())
+	if err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+	if stored.GetStatus().GetState() != a2apb.TaskState_TASK_STATE_COMPLETED || len(stored.GetHistory()) != 1 {
+		t.Errorf("stored task = %v, want a completed task with the request in history", stored)
+	}
+}
+
+// echoExecutor completes every task right away.
+type echoExecutor struct{}
+
+func (echoExecutor) Execute(ctx context.Context, reqCtx *a2asrv.RequestContext, queue a2asrv.EventQueue) error {
+	for _, state := range []a2apb.TaskState{a2apb.TaskState_TASK_STATE_WORKING, a2apb.TaskState_TASK_STATE_COMPLETED} {
+		err := queue.Write(ctx, &a2apb.StreamResponse{Payload: &a2apb.StreamResponse_StatusUpdate{StatusUpdate: &a2apb.TaskStatusUpdateEvent{
+			TaskId:    reqCtx.TaskID,
+			ContextId: reqCtx.ContextID,
+			Status:    &a2apb.TaskStatus{State: state},
+			Final:     state == a2apb.TaskState_TASK_STATE_COMPLETED,
+		}}})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (echoExecutor) Cancel(context.Context, *a2asrv.RequestContext, a2asrv.EventQueue) error {
+	return a2a.ErrTaskNotCancelable
+}
@@ -0,0 +1,146 @@
+// Copyright 2025 The A2A Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package a2asrv
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+
+	a2apb "github.com/a2aproject/a2a-go/grpc"
+	"github.com/a2aproject/a2a-go/internal/taskupdate"
+)
+
+// ErrTaskTerminal is matched by the InvalidTransitionError of an update to a task
+// in a terminal state.
+var ErrTaskTerminal = errors.New("task is in a terminal state")
+
+// IsTerminal reports whether a task in the state can not be updated anymore:
+// COMPLETED, FAILED, CANCELLED and REJECTED.
+func IsTerminal(state a2apb.TaskState) bool {
+	return taskupdate.IsTerminal(state)
+}
+
+// IsInterrupted reports whether a task in the state waits for the client:
+// INPUT_REQUIRED and AUTH_REQUIRED.
+func IsInterrupted(state a2apb.TaskState) bool {
+	return taskupdate.IsInterrupted(state)
+}
+
+// InvalidTransitionError is returned when a task can not move from one state to another.
+// If the task is in a terminal state, the error matches ErrTaskTerminal.
+type InvalidTransitionError struct {
+	From a2apb.TaskState
+	To   a2apb.TaskState
+}
+
+func (e *InvalidTransitionError) Error() string {
+	if IsTerminal(e.From) {
+		return fmt.Sprintf("task in terminal state %s can not be updated", e.From)
+	}
+	return fmt.Sprintf("invalid task state transition from %s to %s", e.From, e.To)
+}
+
+func (e *InvalidTransitionError) Unwrap() error {
+	if IsTerminal(e.From) {
+		return ErrTaskTerminal
+	}
+	return nil
+}
+
+// transitions lists the states a task can move to from each non-terminal state.
+// TASK_STATE_UNSPECIFIED stands for a task which does not exist yet. Terminal states
+// have no entry, and neither do unknown states.
+//
+// A task which started working can not go back to SUBMITTED, unless it was
+// interrupted and the client responded. Moving to the current state is allowed for
+// non-terminal states, which lets agents report progress messages.
+var transitions = map[a2apb.TaskState][]a2apb.TaskState{
+	a2apb.TaskState_TASK_STATE_UNSPECIFIED: {
+		a2apb.TaskState_TASK_STATE_SUBMITTED,
+		a2apb.TaskState_TASK_STATE_WORKING,
+		a2apb.TaskState_TASK_STATE_INPUT_REQUIRED,
+		a2apb.TaskState_TASK_STATE_AUTH_REQUIRED,
+		a2apb.TaskState_TASK_STATE_COMPLETED,
+		a2apb.TaskState_TASK_STATE_FAILED,
+		a2apb.TaskState_TASK_STATE_CANCELLED,
+		a2apb.TaskState_TASK_STATE_REJECTED,
+	},
+	a2apb.TaskState_TASK_STATE_SUBMITTED: {
+		a2apb.TaskState_TASK_STATE_SUBMITTED,
+		a2apb.TaskState_TASK_STATE_WORKING,
+		a2apb.TaskState_TASK_STATE_INPUT_REQUIRED,
+		a2apb.TaskState_TASK_STATE_AUTH_REQUIRED,
+		a2apb.TaskState_TASK_STATE_COMPLETED,
+		a2apb.TaskState_TASK_STATE_FAILED,
+		a2apb.TaskState_TASK_STATE_CANCELLED,
+		a2apb.TaskState_TASK_STATE_REJECTED,
+	},
+	a2apb.TaskState_TASK_STATE_WORKING: {
+		a2apb.TaskState_TASK_STATE_WORKING,
+		a2apb.TaskState_TASK_STATE_INPUT_REQUIRED,
+		a2apb.TaskState_TASK_STATE_AUTH_REQUIRED,
+		a2apb.TaskState_TASK_STATE_COMPLETED,
+		a2apb.TaskState_TASK_STATE_FAILED,
+		a2apb.TaskState_TASK_STATE_CANCELLED,
+		a2apb.TaskState_TASK_STATE_REJECTED,
+	},
+	a2apb.TaskState_TASK_STATE_INPUT_REQUIRED: {
+		a2apb.TaskState_TASK_STATE_SUBMITTED,
+		a2apb.TaskState_TASK_STATE_WORKING,
+		a2apb.TaskState_TASK_STATE_INPUT_REQUIRED,
+		a2apb.TaskState_TASK_STATE_AUTH_REQUIRED,
+		a2apb.TaskState_TASK_STATE_COMPLETED,
+		a2apb.TaskState_TASK_STATE_FAILED,
+		a2apb.TaskState_TASK_STATE_CANCELLED,
+		a2apb.TaskState_TASK_STATE_REJECTED,
+	},
+	a2apb.TaskState_TASK_STATE_AUTH_REQUIRED: {
+		a2apb.TaskState_TASK_STATE_SUBMITTED,
+		a2apb.TaskState_TASK_STATE_WORKING,
+		a2apb.TaskState_TASK_STATE_INPUT_REQUIRED,
+		a2apb.TaskState_TASK_STATE_AUTH_REQUIRED,
+		a2apb.TaskState_TASK_STATE_COMPLETED,
+		a2apb.TaskState_TASK_STATE_FAILED,
+		a2apb.TaskState_TASK_STATE_CANCELLED,
+		a2apb.TaskState_TASK_STATE_REJECTED,
+	},
+}
+
+// ValidateTransition checks that a task can move from one state to another.
+// TASK_STATE_UNSPECIFIED as the source state stands for a task which does not exist yet.
+// A task in a terminal state can not change anymore.
+func ValidateTransition(from, to a2apb.TaskState) error {
+	if !slices.Contains(transitions[from], to) {
+		return &InvalidTransitionError{From: from, To: to}
+	}
+	return nil
+}
+
+// isFinalEvent reports whether the event is the last one of a stream.
+func isFinalEvent(event *a2apb.StreamResponse) bool {
+	switch p := event.GetPayload().(type) {
+	case *a2apb.StreamResponse_Msg:
+		return true
+	case *a2apb.StreamResponse_Task:
+		state := p.Task.GetStatus().GetState()
+		return IsTerminal(state) || IsInterrupted(state)
+	case *a2apb.StreamResponse_StatusUpdate:
+		state := p.StatusUpdate.GetStatus().GetState()
+		return p.StatusUpdate.GetFinal() || IsTerminal(state) || IsInterrupted(state)
+	default:
+		return false
+	}
+}
@@ -0,0 +1,156 @@
+// Copyright 2025 The A2A Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package a2asrv
+
+import (
+	"errors"
+	"testing"
+
+	a2apb "github.com/a2aproject/a2a-go/grpc"
+)
+
+const (
+	stateNone          = a2apb.TaskState_TASK_STATE_UNSPECIFIED
+	stateSubmitted     = a2apb.TaskState_TASK_STATE_SUBMITTED
+	stateWorking       = a2apb.TaskState_TASK_STATE_WORKING
+	stateInputRequired = a2apb.TaskState_TASK_STATE_INPUT_REQUIRED
+	stateAuthRequired  = a2apb.TaskState_TASK_STATE_AUTH_REQUIRED
+	stateCompleted     = a2apb.TaskState_TASK_STATE_COMPLETED
+	stateFailed        = a2apb.TaskState_TASK_STATE_FAILED
+	stateCancelled     = a2apb.TaskState_TASK_STATE_CANCELLED
+	stateRejected      = a2apb.TaskState_TASK_STATE_REJECTED
+)
+
+func TestValidateTransition(t *testing.T) {
+	tests := []struct {
+		from, to     a2apb.TaskState
+		wantErr      bool
+		wantTerminal bool
+	}{
+		{from: stateNone, to: stateSubmitted},
+		{from: stateNone, to: stateCompleted},
+		{from: stateNone, to: stateRejected},
+		{from: stateNone, to: stateNone, wantErr: true},
+		{from: stateSubmitted, to: stateSubmitted},
+		{from: stateSubmitted, to: stateWorking},
+		{from: stateSubmitted, to: stateRejected},
+		{from: stateSubmitted, to: stateNone, wantErr: true},
+		{from: stateWorking, to: stateWorking},
+		{from: stateWorking, to: stateInputRequired},
+		{from: stateWorking, to: stateAuthRequired},
+		{from: stateWorking, to: stateCompleted},
+		{from: stateWorking, to: stateFailed},
+		{from: stateWorking, to: stateCancelled},
+		{from: stateWorking, to: stateSubmitted, wantErr: true},
+		{from: stateWorking, to: stateRejected},
+		{from: stateWorking, to: stateNone, wantErr: true},
+		{from: stateInputRequired, to: stateSubmitted},
+		{from: stateInputRequired, to: stateWorking},
+		{from: stateInputRequired, to: stateAuthRequired},
+		{from: stateInputRequired, to: stateCompleted},
+		{from: stateInputRequired, to: stateRejected},
+		{from: stateAuthRequired, to: stateSubmitted},
+		{from: stateAuthRequired, to: stateWorking},
+		{from: stateAuthRequired, to: stateCancelled},
+		{from: stateAuthRequired, to: stateNone, wantErr: true},
+		{from: stateCompleted, to: stateWorking, wantErr: true, wantTerminal: true},
+		{from: stateCompleted, to: stateCompleted, wantErr: true, wantTerminal: true},
+		{from: stateFailed, to: stateSubmitted, wantErr: true, wantTerminal: true},
+		{from: stateCancelled, to: stateWorking, wantErr: true, wantTerminal: true},
+		{from: stateRejected, to: stateInputRequired, wantErr: true, wantTerminal: true},
+		{from: a2apb.TaskState(99), to: stateWorking, wantErr: true},
+		{from: stateWorking, to: a2apb.TaskState(99), wantErr: true},
+	}
+	for _, tc := range tests {
+		t.Run(tc.from.String()+"->"+tc.to.String(), func(t *testing.T) {
+			err := ValidateTransition(tc.from, tc.to)
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("ValidateTransition() error = %v, want error: %v", err, tc.wantErr)
+			}
+			if err == nil {
+				return
+			}
+			var terr *InvalidTransitionError
+			if !errors.As(err, &terr) || terr.From != tc.from || terr.To != tc.to {
+				t.Errorf("ValidateTransition() error = %#v, want an InvalidTransitionError from %v to %v", err, tc.from, tc.to)
+			}
+			if got := errors.Is(err, ErrTaskTerminal); got != tc.wantTerminal {
+				t.Errorf("errors.Is(err, ErrTaskTerminal) = %v, want %v", got, tc.wantTerminal)
+			}
+		})
+	}
+}
+
+func TestTransitionsFromTerminalStates(t *testing.T) {
+	for state := range a2apb.TaskState_name {
+		from := a2apb.TaskState(state)
+		if IsTerminal(from) != (transitions[from] == nil) {
+			t.Errorf("terminal state %v has transitions %v", from, transitions[from])
+		}
+		for _, to := range transitions[from] {
+			if to == stateNone {
+				t.Errorf("%v can move to %v", from, to)
+			}
+		}
+	}
+}
+
+func TestStatePredicates(t *testing.T) {
+	tests := []struct {
+		state       a2apb.TaskState
+		terminal    bool
+		interrupted bool
+	}{
+		{state: stateNone},
+		{state: stateSubmitted},
+		{state: stateWorking},
+		{state: stateInputRequired, interrupted: true},
+		{state: stateAuthRequired, interrupted: true},
+		{state: stateCompleted, terminal: true},
+		{state: stateFailed, terminal: true},
+		{state: stateCancelled, terminal: true},
+		{state: stateRejected, terminal: true},
+	}
+	for _, tc := range tests {
+		if got := IsTerminal(tc.state); got != tc.terminal {
+			t.Errorf("IsTerminal(%v) = %v, want %v", tc.state, got, tc.terminal)
+		}
+		if got := IsInterrupted(tc.state); got != tc.interrupted {
+			t.Errorf("IsInterrupted(%v) = %v, want %v", tc.state, got, tc.interrupted)
+		}
+	}
+}
+
+func TestIsFinalEvent(t *testing.T) {
+	tests := []struct {
+		name  string
+		event *a2apb.StreamResponse
+		want  bool
+	}{
+		{name: "message", event: &a2apb.StreamResponse{Payload: &a2apb.StreamResponse_Msg{Msg: &a2apb.Message{}}}, want: true},
+		{name: "working task", event: taskEvent(testTask, stateWorking)},
+		{name: "interrupted task", event: taskEvent(testTask, stateInputRequired), want: true},
+		{name: "completed task", event: taskEvent(testTask, stateCompleted), want: true},
+		{name: "working status", event: statusEvent(testTask, stateWorking, false)},
+		{name: "final status", event: statusEvent(testTask, stateWorking, true), want: true},
+		{name: "terminal status", event: statusEvent(testTask, stateFailed, false), want: true},
+		{name: "artifact", event: artifactEvent(testTask, "hello")},
+	}
+	for _, tc := range tests {
+		if got := isFinalEvent(tc.event); got != tc.want {
+			t.Errorf("%s: isFinalEvent() = %v, want %v", tc.name, got, tc.want)
+		}
+	}
+}
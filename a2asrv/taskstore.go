@@ -0,0 +1,111 @@
+// Copyright 2025 The A2A Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package a2asrv
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/a2aproject/a2a-go/a2a"
+	a2apb "github.com/a2aproject/a2a-go/grpc"
+)
+
+// ErrConcurrentModification is returned by TaskStore.Save when the stored task was
+// modified after it was read. It can be matched using errors.Is.
+var ErrConcurrentModification = status.Error(codes.Aborted, "task was modified concurrently")
+
+// TaskVersion identifies a revision of a stored task. It is used by TaskStore
+// implementations for optimistic concurrency control.
+type TaskVersion int64
+
+// TaskVersionMissing is the version of a task which is not stored yet.
+const TaskVersionMissing TaskVersion = 0
+
+// TaskStore persists tasks. Implementations must be safe for concurrent use.
+// Modifications of the tasks passed to or returned from a store must not affect
+// the stored state.
+type TaskStore interface {
+	// Get returns the task with the ID and its current version.
+	// It fails with a2a.ErrTaskNotFound if the task does not exist.
+	Get(ctx context.Context, taskID string) (*a2apb.Task, TaskVersion, error)
+
+	// Save stores the task if its stored version is still prev, and returns the new
+	// version. New tasks are saved with TaskVersionMissing. It fails with
+	// ErrConcurrentModification if the task was saved by someone else in the meantime.
+	Save(ctx context.Context, task *a2apb.Task, prev TaskVersion) (TaskVersion, error)
+
+	// Delete removes the task. It fails with a2a.ErrTaskNotFound if the task does not exist.
+	Delete(ctx context.Context, taskID string) error
+}
+
+// InMemoryTaskStore is a TaskStore which keeps tasks in memory.
+// Tasks are cloned on every access.
+type InMemoryTaskStore struct {
+	mu    sync.RWMutex
+	tasks map[string]storedTask
+}
+
+type storedTask struct {
+	task    *a2apb.Task
+	version TaskVersion
+}
+
+var _ TaskStore = (*InMemoryTaskStore)(nil)
+
+// NewInMemoryTaskStore returns an empty InMemoryTaskStore.
+func NewInMemoryTaskStore() *InMemoryTaskStore {
+	return &InMemoryTaskStore{tasks: make(map[string]storedTask)}
+}
+
+// Get implements TaskStore.
+func (s *InMemoryTaskStore) Get(_ context.Context, taskID string) (*a2apb.Task, TaskVersion, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	stored, ok := s.tasks[taskID]
+	if !ok {
+		return nil, TaskVersionMissing, fmt.Errorf("%w: %s", a2a.ErrTaskNotFound, taskID)
+	}
+	return cloneTask(stored.task), stored.version, nil
+}
+
+// Save implements TaskStore.
+func (s *InMemoryTaskStore) Save(_ context.Context, task *a2apb.Task, prev TaskVersion) (TaskVersion, error) {
+	if task.GetId() == "" {
+		return TaskVersionMissing, fmt.Errorf("%w: task ID is required", a2a.ErrInvalidParams)
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if current := s.tasks[task.GetId()].version; current != prev {
+		return TaskVersionMissing, fmt.Errorf("%w: task %s is at version %d, expected %d", ErrConcurrentModification, task.GetId(), current, prev)
+	}
+	stored := storedTask{task: cloneTask(task), version: prev + 1}
+	s.tasks[task.GetId()] = stored
+	return stored.version, nil
+}
+
+// Delete implements TaskStore.
+func (s *InMemoryTaskStore) Delete(_ context.Context, taskID string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.tasks[taskID]; !ok {
+		return fmt.Errorf("%w: %s", a2a.ErrTaskNotFound, taskID)
+	}
+	delete(s.tasks, taskID)
+	return nil
+}
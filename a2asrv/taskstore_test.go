@@ -0,0 +1,150 @@
+// Copyright 2025 The A2A Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package a2asrv
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+
+	"google.golang.org/protobuf/proto"
+
+	"github.com/a2aproject/a2a-go/a2a"
+	a2apb "github.com/a2aproject/a2a-go/grpc"
+)
+
+func TestInMemoryTaskStore(t *testing.T) {
+	ctx := context.Background()
+	store := NewInMemoryTaskStore()
+	task := &a2apb.Task{Id: "t1", ContextId: "c1", Status: &a2apb.TaskStatus{State: a2apb.TaskState_TASK_STATE_SUBMITTED}}
+
+	if _, _, err := store.Get(ctx, "t1"); !errors.Is(err, a2a.ErrTaskNotFound) {
+		t.Fatalf("Get() of a missing task error = %v, want %v", err, a2a.ErrTaskNotFound)
+	}
+	v1, err := store.Save(ctx, task, TaskVersionMissing)
+	if err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+	// Modifications of the saved task must not affect the store.
+	task.Status.State = a2apb.TaskState_TASK_STATE_WORKING
+
+	got, version, err := store.Get(ctx, "t1")
+	if err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+	if version != v1 || got.GetStatus().GetState() != a2apb.TaskState_TASK_STATE_SUBMITTED {
+		t.Errorf("Get() = %v at version %d, want the submitted task at version %d", got, version, v1)
+	}
+
+	v2, err := store.Save(ctx, task, v1)
+	if err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+	if v2 == v1 {
+		t.Errorf("Save() returned the previous version %d", v2)
+	}
+	got, _, err = store.Get(ctx, "t1")
+	if err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+	if !proto.Equal(got, task) {
+		t.Errorf("Get() = %v, want %v", got, task)
+	}
+
+	if err := store.Delete(ctx, "t1"); err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+	if err := store.Delete(ctx, "t1"); !errors.Is(err, a2a.ErrTaskNotFound) {
+		t.Errorf("Delete() of a deleted task error = %v, want %v", err, a2a.ErrTaskNotFound)
+	}
+}
+
+func TestInMemoryTaskStoreVersionConflicts(t *testing.T) {
+	ctx := context.Background()
+	task := &a2apb.Task{Id: "t1", ContextId: "c1"}
+	tests := []struct {
+		name string
+		prev func(saved TaskVersion) TaskVersion
+	}{
+		{name: "create existing task", prev: func(TaskVersion) TaskVersion { return TaskVersionMissing }},
+		{name: "stale version", prev: func(saved TaskVersion) TaskVersion { return saved - 1 }},
+		{name: "future version", prev: func(saved TaskVersion) TaskVersion { return saved + 1 }},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			store := NewInMemoryTaskStore()
+			v1, err := store.Save(ctx, task, TaskVersionMissing)
+			if err != nil {
+				t.Fatalf("Save() error = %v", err)
+			}
+			saved, err := store.Save(ctx, task, v1)
+			if err != nil {
+				t.Fatalf("Save() error = %v", err)
+			}
+			if _, err := store.Save(ctx, task, tc.prev(saved)); !errors.Is(err, ErrConcurrentModification) {
+				t.Errorf("Save() error = %v, want %v", err, ErrConcurrentModification)
+			}
+		})
+	}
+
+	t.Run("update missing task", func(t *testing.T) {
+		if _, err := NewInMemoryTaskStore().Save(ctx, task, 5); !errors.Is(err, ErrConcurrentModification) {
+			t.Errorf("Save() error = %v, want %v", err, ErrConcurrentModification)
+		}
+	})
+
+	t.Run("missing id", func(t *testing.T) {
+		if _, err := NewInMemoryTaskStore().Save(ctx, &a2apb.Task{}, TaskVersionMissing); !errors.Is(err, a2a.ErrInvalidParams) {
+			t.Errorf("Save() error = %v, want %v", err, a2a.ErrInvalidParams)
+		}
+	})
+}
+
+func TestInMemoryTaskStoreConcurrentSaves(t *testing.T) {
+	ctx := context.Background()
+	store := NewInMemoryTaskStore()
+	v1, err := store.Save(ctx, &a2apb.Task{Id: "t1"}, TaskVersionMissing)
+	if err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+
+	// Only one of the writers which read the same version succeeds.
+	const writers = 10
+	var wg sync.WaitGroup
+	errs := make(chan error, writers)
+	for range writers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := store.Save(ctx, &a2apb.Task{Id: "t1"}, v1)
+			errs <- err
+		}()
+	}
+	wg.Wait()
+	close(errs)
+	succeeded := 0
+	for err := range errs {
+		switch {
+		case err == nil:
+			succeeded++
+		case !errors.Is(err, ErrConcurrentModification):
+			t.Errorf("Save() error = %v, want %v", err, ErrConcurrentModification)
+		}
+	}
+	if succeeded != 1 {
+		t.Errorf("%d concurrent saves succeeded, want 1", succeeded)
+	}
+}
@@ -0,0 +1,250 @@
+// Copyright 2025 The A2A Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package a2asrv
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	"google.golang.org/protobuf/types/known/structpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	a2apb "github.com/a2aproject/a2a-go/grpc"
+	"github.com/a2aproject/a2a-go/internal/uuid"
+)
+
+// ErrArtifactClosed is returned when appending to an artifact after its last chunk.
+var ErrArtifactClosed = errors.New("artifact is closed")
+
+// ArtifactOption sets optional fields of an artifact created by TaskUpdater.
+type ArtifactOption func(*a2apb.Artifact)
+
+// WithArtifactID sets the ID of the artifact. By default a random ID is generated.
+func WithArtifactID(id string) ArtifactOption {
+	return func(a *a2apb.Artifact) {
+		a.ArtifactId = id
+	}
+}
+
+// WithArtifactName sets the human-readable name of the artifact.
+func WithArtifactName(name string) ArtifactOption {
+	return func(a *a2apb.Artifact) {
+		a.Name = name
+	}
+}
+
+// WithArtifactDescription sets the human-readable description of the artifact.
+func WithArtifactDescription(description string) ArtifactOption {
+	return func(a *a2apb.Artifact) {
+		a.Description = description
+	}
+}
+
+// WithArtifactMetadata sets the metadata of the artifact.
+func WithArtifactMetadata(metadata *structpb.Struct) ArtifactOption {
+	return func(a *a2apb.Artifact) {
+		a.Metadata = metadata
+	}
+}
+
+// TaskUpdater writes the status and artifact updates of a task to an EventQueue.
+// It fills in the task and context IDs and the timestamps, and rejects updates which
+// are invalid for the task: state transitions not allowed by ValidateTransition and
+// artifact chunks following the last one.
+//
+// A TaskUpdater is safe for concurrent use.
+type TaskUpdater struct {
+	queue     EventQueue
+	taskID    string
+	contextID string
+
+	mu    sync.Mutex
+	state a2apb.TaskState
+	// closed holds the IDs of the artifacts which received their last chunk.
+	closed map[string]struct{}
+	// open holds the IDs of the artifacts being streamed in chunks.
+	open map[string]struct{}
+}
+
+// NewTaskUpdater returns a TaskUpdater for the task of the request.
+func NewTaskUpdater(reqCtx *RequestContext, queue EventQueue) *TaskUpdater {
+	return &TaskUpdater{
+		queue:     queue,
+		taskID:    reqCtx.TaskID,
+		contextID: reqCtx.ContextID,
+		state:     reqCtx.Task.GetStatus().GetState(),
+		closed:    make(map[string]struct{}),
+		open:      make(map[string]struct{}),
+	}
+}
+
+// NewAgentMessage returns a message of the agent for the task with a random ID.
+func (u *TaskUpdater) NewAgentMessage(parts ...*a2apb.Part) *a2apb.Message {
+	return &a2apb.Message{
+		MessageId: uuid.New(),
+		TaskId:    u.taskID,
+		ContextId: u.contextID,
+		Role:      a2apb.Role_ROLE_AGENT,
+		Content:   parts,
+	}
+}
+
+// Submit marks the task as submitted.
+func (u *TaskUpdater) Submit(ctx context.Context) error {
+	return u.UpdateStatus(ctx, a2apb.TaskState_TASK_STATE_SUBMITTED, nil, false)
+}
+
+// StartWork marks the task as being worked on. The message is optional.
+func (u *TaskUpdater) StartWork(ctx context.Context, msg *a2apb.Message) error {
+	return u.UpdateStatus(ctx, a2apb.TaskState_TASK_STATE_WORKING, msg, false)
+}
+
+// RequireInput asks the client for more input with the message. It is a final update.
+func (u *TaskUpdater) RequireInput(ctx context.Context, msg *a2apb.Message) error {
+	return u.UpdateStatus(ctx, a2apb.TaskState_TASK_STATE_INPUT_REQUIRED, msg, true)
+}
+
+// RequireAuth asks the client for authentication with the message. It is a final update.
+func (u *TaskUpdater) RequireAuth(ctx context.Context, msg *a2apb.Message) error {
+	return u.UpdateStatus(ctx, a2apb.TaskState_TASK_STATE_AUTH_REQUIRED, msg, true)
+}
+
+// Complete marks the task as completed. The message is optional.
+func (u *TaskUpdater) Complete(ctx context.Context, msg *a2apb.Message) error {
+	return u.UpdateStatus(ctx, a2apb.TaskState_TASK_STATE_COMPLETED, msg, true)
+}
+
+// Fail marks the task as failed. If err is not nil, its text is reported to the
+// client in a message of the agent.
+func (u *TaskUpdater) Fail(ctx context.Context, err error) error {
+	var msg *a2apb.Message
+	if err != nil {
+		msg = u.NewAgentMessage(&a2apb.Part{Part: &a2apb.Part_Text{Text: err.Error()}})
+	}
+	return u.UpdateStatus(ctx, a2apb.TaskState_TASK_STATE_FAILED, msg, true)
+}
+
+// Cancel marks the task as canceled. The message is optional.
+func (u *TaskUpdater) Cancel(ctx context.Context, msg *a2apb.Message) error {
+	return u.UpdateStatus(ctx, a2apb.TaskState_TASK_STATE_CANCELLED, msg, true)
+}
+
+// Reject marks the task as rejected by the agent. The message is optional.
+func (u *TaskUpdater) Reject(ctx context.Context, msg *a2apb.Message) error {
+	return u.UpdateStatus(ctx, a2apb.TaskState_TASK_STATE_REJECTED, msg, true)
+}
+
+// UpdateStatus moves the task to the state. The message is optional.
+// Updates to terminal states are always final.
+func (u *TaskUpdater) UpdateStatus(ctx context.Context, state a2apb.TaskState, msg *a2apb.Message, final bool) error {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	if err := ValidateTransition(u.state, state); err != nil {
+		return err
+	}
+	event := &a2apb.TaskStatusUpdateEvent{
+		TaskId:    u.taskID,
+		ContextId: u.contextID,
+		Status: &a2apb.TaskStatus{
+			State:     state,
+			Update:    msg,
+			Timestamp: timestamppb.Now(),
+		},
+		Final: final || IsTerminal(state),
+	}
+	if err := u.queue.Write(ctx, &a2apb.StreamResponse{Payload: &a2apb.StreamResponse_StatusUpdate{StatusUpdate: event}}); err != nil {
+		return err
+	}
+	u.state = state
+	return nil
+}
+
+// AddArtifact adds a complete artifact with the parts to the task. An artifact with
+// the same ID replaces the existing one.
+func (u *TaskUpdater) AddArtifact(ctx context.Context, parts []*a2apb.Part, opts ...ArtifactOption) error {
+	artifact := newArtifact(parts, opts)
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	if err := u.checkArtifact(artifact.GetArtifactId(), false); err != nil {
+		return err
+	}
+	if err := u.writeArtifact(ctx, artifact, false, true); err != nil {
+		return err
+	}
+	delete(u.open, artifact.GetArtifactId())
+	u.closed[artifact.GetArtifactId()] = struct{}{}
+	return nil
+}
+
+// AppendArtifact streams a chunk of the artifact with the ID. The first chunk creates
+// the artifact, the following ones append their parts to it. Once a chunk with
+// lastChunk set is written, further chunks fail with ErrArtifactClosed.
+// The options are applied to the chunk.
+func (u *TaskUpdater) AppendArtifact(ctx context.Context, artifactID string, parts []*a2apb.Part, lastChunk bool, opts ...ArtifactOption) error {
+	if artifactID == "" {
+		return errors.New("artifact ID is required")
+	}
+	artifact := newArtifact(parts, append(opts, WithArtifactID(artifactID)))
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	if err := u.checkArtifact(artifactID, true); err != nil {
+		return err
+	}
+	_, appending := u.open[artifactID]
+	if err := u.writeArtifact(ctx, artifact, appending, lastChunk); err != nil {
+		return err
+	}
+	if lastChunk {
+		delete(u.open, artifactID)
+		u.closed[artifactID] = struct{}{}
+	} else {
+		u.open[artifactID] = struct{}{}
+	}
+	return nil
+}
+
+func (u *TaskUpdater) checkArtifact(artifactID string, chunk bool) error {
+	if IsTerminal(u.state) {
+		return &InvalidTransitionError{From: u.state, To: u.state}
+	}
+	if _, ok := u.closed[artifactID]; ok && chunk {
+		return fmt.Errorf("%w: %s", ErrArtifactClosed, artifactID)
+	}
+	return nil
+}
+
+func (u *TaskUpdater) writeArtifact(ctx context.Context, artifact *a2apb.Artifact, appending, lastChunk bool) error {
+	event := &a2apb.TaskArtifactUpdateEvent{
+		TaskId:    u.taskID,
+		ContextId: u.contextID,
+		Artifact:  artifact,
+		Append:    appending,
+		LastChunk: lastChunk,
+	}
+	return u.queue.Write(ctx, &a2apb.StreamResponse{Payload: &a2apb.StreamResponse_ArtifactUpdate{ArtifactUpdate: event}})
+}
+
+func newArtifact(parts []*a2apb.Part, opts []ArtifactOption) *a2apb.Artifact {
+	artifact := &a2apb.Artifact{Parts: parts}
+	for _, opt := range opts {
+		opt(artifact)
+	}
+	if artifact.ArtifactId == "" {
+		artifact.ArtifactId = uuid.New()
+	}
+	return artifact
+}
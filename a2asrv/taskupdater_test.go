@@ -0,0 +1,253 @@
+// Copyright 2025 The A2A Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package a2asrv
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+
+	a2apb "github.com/a2aproject/a2a-go/grpc"
+)
+
+// recordingQueue is an EventQueue keeping the written events. It fails the writes
+// with err if it is set.
+type recordingQueue struct {
+	mu     sync.Mutex
+	events []*a2apb.StreamResponse
+	err    error
+}
+
+func (q *recordingQueue) Write(_ context.Context, event *a2apb.StreamResponse) error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if q.err != nil {
+		return q.err
+	}
+	q.events = append(q.events, event)
+	return nil
+}
+
+func (q *recordingQueue) written() []*a2apb.StreamResponse {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return append([]*a2apb.StreamResponse(nil), q.events...)
+}
+
+func textParts(text string) []*a2apb.Part {
+	return []*a2apb.Part{{Part: &a2apb.Part_Text{Text: text}}}
+}
+
+func TestTaskUpdaterStatus(t *testing.T) {
+	ctx := context.Background()
+	tests := []struct {
+		name      string
+		task      *a2apb.Task
+		update    func(u *TaskUpdater) error
+		wantState a2apb.TaskState
+		wantFinal bool
+		wantErr   error
+	}{
+		{name: "submit", update: func(u *TaskUpdater) error { return u.Submit(ctx) }, wantState: stateSubmitted},
+		{name: "start work", update: func(u *TaskUpdater) error { return u.StartWork(ctx, nil) }, wantState: stateWorking},
+		{name: "require input", update: func(u *TaskUpdater) error { return u.RequireInput(ctx, nil) }, wantState: stateInputRequired, wantFinal: true},
+		{name: "require auth", update: func(u *TaskUpdater) error { return u.RequireAuth(ctx, nil) }, wantState: stateAuthRequired, wantFinal: true},
+		{name: "complete", update: func(u *TaskUpdater) error { return u.Complete(ctx, nil) }, wantState: stateCompleted, wantFinal: true},
+		{name: "fail", update: func(u *TaskUpdater) error { return u.Fail(ctx, nil) }, wantState: stateFailed, wantFinal: true},
+		{name: "cancel", update: func(u *TaskUpdater) error { return u.Cancel(ctx, nil) }, wantState: stateCancelled, wantFinal: true},
+		{name: "reject", update: func(u *TaskUpdater) error { return u.Reject(ctx, nil) }, wantState: stateRejected, wantFinal: true},
+		{
+			name:      "terminal updates are final",
+			update:    func(u *TaskUpdater) error { return u.UpdateStatus(ctx, stateCompleted, nil, false) },
+			wantState: stateCompleted,
+			wantFinal: true,
+		},
+		{
+			name:      "resume interrupted task",
+			task:      &a2apb.Task{Status: &a2apb.TaskStatus{State: stateInputRequired}},
+			update:    func(u *TaskUpdater) error { return u.StartWork(ctx, nil) },
+			wantState: stateWorking,
+		},
+		{
+			name:      "reject working task",
+			task:      &a2apb.Task{Status: &a2apb.TaskStatus{State: stateWorking}},
+			update:    func(u *TaskUpdater) error { return u.Reject(ctx, nil) },
+			wantState: stateRejected,
+			wantFinal: true,
+		},
+		{
+			name:    "update terminal task",
+			task:    &a2apb.Task{Status: &a2apb.TaskStatus{State: stateCompleted}},
+			update:  func(u *TaskUpdater) error { return u.StartWork(ctx, nil) },
+			wantErr: ErrTaskTerminal,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			queue := &recordingQueue{}
+			u := NewTaskUpdater(&RequestContext{TaskID: "t1", ContextID: "c1", Task: tc.task}, queue)
+			err := tc.update(u)
+			if !errors.Is(err, tc.wantErr) {
+				t.Fatalf("update error = %v, want %v", err, tc.wantErr)
+			}
+			events := queue.written()
+			if tc.wantErr != nil {
+				if len(events) != 0 {
+					t.Errorf("rejected update wrote %v", events)
+				}
+				return
+			}
+			if len(events) != 1 {
+				t.Fatalf("update wrote %d events, want 1", len(events))
+			}
+			got := events[0].GetStatusUpdate()
+			if got.GetTaskId() != "t1" || got.GetContextId() != "c1" {
+				t.Errorf("status update for %s/%s, want t1/c1", got.GetTaskId(), got.GetContextId())
+			}
+			if got.GetStatus().GetState() != tc.wantState || got.GetFinal() != tc.wantFinal {
+				t.Errorf("status update = %v final %v, want %v final %v", got.GetStatus().GetState(), got.GetFinal(), tc.wantState, tc.wantFinal)
+			}
+			if got.GetStatus().GetTimestamp() == nil {
+				t.Error("status update without timestamp")
+			}
+		})
+	}
+}
+
+func TestTaskUpdaterTransitions(t *testing.T) {
+	ctx := context.Background()
+	u := NewTaskUpdater(&RequestContext{TaskID: "t1", ContextID: "c1"}, &recordingQueue{})
+	if err := u.StartWork(ctx, nil); err != nil {
+		t.Fatalf("StartWork() error = %v", err)
+	}
+	var terr *InvalidTransitionError
+	if err := u.Submit(ctx); !errors.As(err, &terr) {
+		t.Errorf("Submit() of a working task error = %v, want an InvalidTransitionError", err)
+	}
+	if err := u.Complete(ctx, nil); err != nil {
+		t.Fatalf("Complete() error = %v", err)
+	}
+	if err := u.Complete(ctx, nil); !errors.Is(err, ErrTaskTerminal) {
+		t.Errorf("Complete() of a completed task error = %v, want %v", err, ErrTaskTerminal)
+	}
+	if err := u.AddArtifact(ctx, textParts("late")); !errors.Is(err, ErrTaskTerminal) {
+		t.Errorf("AddArtifact() to a completed task error = %v, want %v", err, ErrTaskTerminal)
+	}
+}
+
+func TestTaskUpdaterFailedWrite(t *testing.T) {
+	ctx := context.Background()
+	queue := &recordingQueue{err: errExecutionStopped}
+	u := NewTaskUpdater(&RequestContext{TaskID: "t1", ContextID: "c1"}, queue)
+	if err := u.Complete(ctx, nil); !errors.Is(err, errExecutionStopped) {
+		t.Fatalf("Complete() error = %v, want %v", err, errExecutionStopped)
+	}
+	// The state is unchanged when the event is not written.
+	queue.err = nil
+	if err := u.StartWork(ctx, nil); err != nil {
+		t.Errorf("StartWork() after a failed write error = %v", err)
+	}
+}
+
+func TestTaskUpdaterFailMessage(t *testing.T) {
+	queue := &recordingQueue{}
+	u := NewTaskUpdater(&RequestContext{TaskID: "t1", ContextID: "c1"}, queue)
+	if err := u.Fail(context.Background(), errors.New("out of quota")); err != nil {
+		t.Fatalf("Fail() error = %v", err)
+	}
+	msg := queue.written()[0].GetStatusUpdate().GetStatus().GetUpdate()
+	if msg.GetRole() != a2apb.Role_ROLE_AGENT || msg.GetTaskId() != "t1" || msg.GetContextId() != "c1" || msg.GetMessageId() == "" {
+		t.Errorf("failure message = %v, want an agent message for the task", msg)
+	}
+	if got := msg.GetContent()[0].GetText(); got != "out of quota" {
+		t.Errorf("failure message text = %q, want %q", got, "out of quota")
+	}
+}
+
+func TestTaskUpdaterArtifacts(t *testing.T) {
+	ctx := context.Background()
+	queue := &recordingQueue{}
+	u := NewTaskUpdater(&RequestContext{TaskID: "t1", ContextID: "c1"}, queue)
+
+	if err := u.AddArtifact(ctx, textParts("report"), WithArtifactName("report"), WithArtifactDescription("the report")); err != nil {
+		t.Fatalf("AddArtifact() error = %v", err)
+	}
+	chunks := []struct {
+		text string
+		last bool
+	}{{"a", false}, {"b", false}, {"c", true}}
+	for _, chunk := range chunks {
+		if err := u.AppendArtifact(ctx, "stream", textParts(chunk.text), chunk.last); err != nil {
+			t.Fatalf("AppendArtifact(%q) error = %v", chunk.text, err)
+		}
+	}
+	if err := u.AppendArtifact(ctx, "stream", textParts("d"), false); !errors.Is(err, ErrArtifactClosed) {
+		t.Errorf("AppendArtifact() after the last chunk error = %v, want %v", err, ErrArtifactClosed)
+	}
+	if err := u.AppendArtifact(ctx, "", textParts("d"), false); err == nil {
+		t.Error("AppendArtifact() without ID error = nil, want error")
+	}
+	// A complete artifact replaces a closed one.
+	if err := u.AddArtifact(ctx, textParts("final"), WithArtifactID("stream")); err != nil {
+		t.Errorf("AddArtifact() replacing a streamed artifact error = %v", err)
+	}
+
+	events := queue.written()
+	if len(events) != 5 {
+		t.Fatalf("wrote %d events, want 5", len(events))
+	}
+	report := events[0].GetArtifactUpdate()
+	if report.GetArtifact().GetArtifactId() == "" || report.GetArtifact().GetName() != "report" ||
+		report.GetArtifact().GetDescription() != "the report" || report.GetAppend() || !report.GetLastChunk() {
+		t.Errorf("complete artifact update = %v", report)
+	}
+	for i, want := range []struct{ append, last bool }{{false, false}, {true, false}, {true, true}} {
+		got := events[i+1].GetArtifactUpdate()
+		if got.GetTaskId() != "t1" || got.GetContextId() != "c1" || got.GetArtifact().GetArtifactId() != "stream" {
+			t.Errorf("chunk %d = %v, want a chunk of the stream artifact for t1/c1", i, got)
+		}
+		if got.GetAppend() != want.append || got.GetLastChunk() != want.last {
+			t.Errorf("chunk %d append = %v last = %v, want %v %v", i, got.GetAppend(), got.GetLastChunk(), want.append, want.last)
+		}
+	}
+}
+
+func TestTaskUpdaterWithHandler(t *testing.T) {
+	executor := &testExecutor{execute: func(ctx context.Context, reqCtx *RequestContext, queue EventQueue) error {
+		u := NewTaskUpdater(reqCtx, queue)
+		if err := u.StartWork(ctx, nil); err != nil {
+			return err
+		}
+		if err := u.AppendArtifact(ctx, "a1", textParts("hello "), false); err != nil {
+			return err
+		}
+		if err := u.AppendArtifact(ctx, "a1", textParts("world"), true); err != nil {
+			return err
+		}
+		return u.Complete(ctx, u.NewAgentMessage(textParts("done")...))
+	}}
+	resp, err := NewHandler(executor).SendMessage(context.Background(), newTestMessage(""))
+	if err != nil {
+		t.Fatalf("SendMessage() error = %v", err)
+	}
+	task := resp.GetTask()
+	if task.GetStatus().GetState() != stateCompleted {
+		t.Errorf("task state = %v, want %v", task.GetStatus().GetState(), stateCompleted)
+	}
+	if len(task.GetArtifacts()) != 1 || len(task.GetArtifacts()[0].GetParts()) != 2 {
+		t.Errorf("task artifacts = %v, want one artifact with two parts", task.GetArtifacts())
+	}
+}
@@ -0,0 +1,207 @@
+// Copyright 2025 The A2A Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package jwt implements the subset of JWS, JWT and JWK needed by the A2A SDK,
+// using only the standard library. Supported algorithms are ES256, ES384, RS256 and EdDSA.
+package jwt
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+)
+
+// Algorithms supported by Sign and Verify.
+const (
+	ES256 = "ES256"
+	ES384 = "ES384"
+	RS256 = "RS256"
+	EdDSA = "EdDSA"
+)
+
+// ErrInvalidSignature is returned when a signature does not match the signing input.
+var ErrInvalidSignature = errors.New("invalid signature")
+
+// Header is the protected header of a JWS.
+type Header struct {
+	Alg string `json:"alg"`
+	Kid string `json:"kid,omitempty"`
+	Typ string `json:"typ,omitempty"`
+}
+
+// Token is a parsed compact JWS.
+type Token struct {
+	Header Header
+	// Claims is the raw JSON payload.
+	Claims json.RawMessage
+	// signingInput is the part of the token covered by the signature.
+	signingInput string
+	signature    []byte
+}
+
+// Algorithm returns the JWS algorithm to use with the key.
+func Algorithm(key crypto.PublicKey) (string, error) {
+	switch k := key.(type) {
+	case *ecdsa.PublicKey:
+		switch k.Curve.Params().Name {
+		case "P-256":
+			return ES256, nil
+		case "P-384":
+			return ES384, nil
+		}
+		return "", fmt.Errorf("unsupported curve %s", k.Curve.Params().Name)
+	case *rsa.PublicKey:
+		return RS256, nil
+	case ed25519.PublicKey:
+		return EdDSA, nil
+	default:
+		return "", fmt.Errorf("unsupported key type %T", key)
+	}
+}
+
+// Sign returns the compact JWS of the claims signed with the key.
+func Sign(header Header, claims any, key crypto.Signer) (string, error) {
+	h, err := json.Marshal(header)
+	if err != nil {
+		return "", err
+	}
+	c, err := json.Marshal(claims)
+	if err != nil {
+		return "", err
+	}
+	input := encode(h) + "." + encode(c)
+	sig, err := sign(header.Alg, key, []byte(input))
+	if err != nil {
+		return "", err
+	}
+	return input + "." + encode(sig), nil
+}
+
+// Parse decodes a compact JWS without verifying its signature.
+func Parse(token string) (*Token, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return nil, errors.New("malformed token")
+	}
+	h, err := decode(parts[0])
+	if err != nil {
+		return nil, fmt.Errorf("malformed token header: %w", err)
+	}
+	var header Header
+	if err := json.Unmarshal(h, &header); err != nil {
+		return nil, fmt.Errorf("malformed token header: %w", err)
+	}
+	claims, err := decode(parts[1])
+	if err != nil {
+		return nil, fmt.Errorf("malformed token claims: %w", err)
+	}
+	sig, err := decode(parts[2])
+	if err != nil {
+		return nil, fmt.Errorf("malformed token signature: %w", err)
+	}
+	return &Token{Header: header, Claims: claims, signingInput: parts[0] + "." + parts[1], signature: sig}, nil
+}
+
+// Verify checks the signature of the token with the key. The algorithm of the
+// token header must match the key.
+func (t *Token) Verify(key crypto.PublicKey) error {
+	alg, err := Algorithm(key)
+	if err != nil {
+		return err
+	}
+	if t.Header.Alg != alg {
+		return fmt.Errorf("token algorithm %q does not match the key", t.Header.Alg)
+	}
+	return verify(alg, key, []byte(t.signingInput), t.signature)
+}
+
+func sign(alg string, key crypto.Signer, input []byte) ([]byte, error) {
+	switch alg {
+	case ES256, ES384:
+		k, ok := key.(*ecdsa.PrivateKey)
+		if !ok {
+			return nil, fmt.Errorf("%s requires an ECDSA key", alg)
+		}
+		hash, size := digest(alg, input)
+		r, s, err := ecdsa.Sign(rand.Reader, k, hash)
+		if err != nil {
+			return nil, err
+		}
+		sig := make([]byte, 2*size)
+		r.FillBytes(sig[:size])
+		s.FillBytes(sig[size:])
+		return sig, nil
+	case RS256:
+		hash, _ := digest(alg, input)
+		return key.Sign(rand.Reader, hash, crypto.SHA256)
+	case EdDSA:
+		return key.Sign(rand.Reader, input, crypto.Hash(0))
+	default:
+		return nil, fmt.Errorf("unsupported algorithm %q", alg)
+	}
+}
+
+func verify(alg string, key crypto.PublicKey, input, sig []byte) error {
+	ok := false
+	switch alg {
+	case ES256, ES384:
+		hash, size := digest(alg, input)
+		if len(sig) != 2*size {
+			return ErrInvalidSignature
+		}
+		r := new(big.Int).SetBytes(sig[:size])
+		s := new(big.Int).SetBytes(sig[size:])
+		ok = ecdsa.Verify(key.(*ecdsa.PublicKey), hash, r, s)
+	case RS256:
+		hash, _ := digest(alg, input)
+		ok = rsa.VerifyPKCS1v15(key.(*rsa.PublicKey), crypto.SHA256, hash, sig) == nil
+	case EdDSA:
+		ok = ed25519.Verify(key.(ed25519.PublicKey), input, sig)
+	default:
+		return fmt.Errorf("unsupported algorithm %q", alg)
+	}
+	if !ok {
+		return ErrInvalidSignature
+	}
+	return nil
+}
+
+// digest hashes the input for the algorithm and returns the size of the
+// signature components for ECDSA algorithms.
+func digest(alg string, input []byte) ([]byte, int) {
+	if alg == ES384 {
+		h := sha512.Sum384(input)
+		return h[:], 48
+	}
+	h := sha256.Sum256(input)
+	return h[:], 32
+}
+
+func encode(b []byte) string {
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+func decode(s string) ([]byte, error) {
+	return base64.RawURLEncoding.DecodeString(s)
+}
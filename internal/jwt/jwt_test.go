@@ -0,0 +1,220 @@
+// Copyright 2025 The A2A Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jwt
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+)
+
+type testKey struct {
+	alg string
+	key crypto.Signer
+}
+
+func newTestKeys(t *testing.T) []testKey {
+	t.Helper()
+	p256, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	p384, err := ecdsa.GenerateKey(elliptic.P384(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, edKey, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return []testKey{{ES256, p256}, {ES384, p384}, {RS256, rsaKey}, {EdDSA, edKey}}
+}
+
+func TestSignVerify(t *testing.T) {
+	keys := newTestKeys(t)
+	for i, k := range keys {
+		t.Run(k.alg, func(t *testing.T) {
+			alg, err := Algorithm(k.key.Public())
+			if err != nil || alg != k.alg {
+				t.Fatalf("Algorithm() = %q, %v, want %q", alg, err, k.alg)
+			}
+			token, err := Sign(Header{Alg: alg, Kid: "k1", Typ: "JWT"}, map[string]any{"sub": "agent"}, k.key)
+			if err != nil {
+				t.Fatalf("Sign() error = %v", err)
+			}
+			parsed, err := Parse(token)
+			if err != nil {
+				t.Fatalf("Parse() error = %v", err)
+			}
+			if parsed.Header.Alg != alg || parsed.Header.Kid != "k1" || string(parsed.Claims) != `{"sub":"agent"}` {
+				t.Errorf("Parse() = %+v, want the signed header and claims", parsed)
+			}
+			if err := parsed.Verify(k.key.Public()); err != nil {
+				t.Errorf("Verify() error = %v", err)
+			}
+
+			// A key of the same type which did not sign the token is rejected.
+			other := newTestKeys(t)[i].key
+			if err := parsed.Verify(other.Public()); !errors.Is(err, ErrInvalidSignature) {
+				t.Errorf("Verify() with another key error = %v, want %v", err, ErrInvalidSignature)
+			}
+			// So is a key of another type.
+			if err := parsed.Verify(keys[(i+1)%len(keys)].key.Public()); err == nil {
+				t.Error("Verify() with a key of another algorithm error = nil, want error")
+			}
+		})
+	}
+}
+
+func TestVerifyTamperedToken(t *testing.T) {
+	key := newTestKeys(t)[0].key
+	token, err := Sign(Header{Alg: ES256}, map[string]any{"sub": "agent"}, key)
+	if err != nil {
+		t.Fatalf("Sign() error = %v", err)
+	}
+	parts := strings.Split(token, ".")
+	forgedClaims := encode([]byte(`{"sub":"admin"}`))
+	noneHeader := encode([]byte(`{"alg":"none"}`))
+	hsHeader := encode([]byte(`{"alg":"HS256"}`))
+	tests := []struct {
+		name  string
+		token string
+	}{
+		{name: "modified claims", token: parts[0] + "." + forgedClaims + "." + parts[2]},
+		{name: "alg none", token: noneHeader + "." + parts[1] + "."},
+		{name: "alg none with signature", token: noneHeader + "." + parts[1] + "." + parts[2]},
+		{name: "symmetric alg", token: hsHeader + "." + parts[1] + "." + parts[2]},
+		{name: "truncated signature", token: parts[0] + "." + parts[1] + "." + parts[2][:10]},
+		{name: "empty signature", token: parts[0] + "." + parts[1] + "."},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			parsed, err := Parse(tc.token)
+			if err != nil {
+				return
+			}
+			if err := parsed.Verify(key.Public()); err == nil {
+				t.Error("Verify() error = nil, want error")
+			}
+		})
+	}
+}
+
+func TestParseMalformed(t *testing.T) {
+	for _, token := range []string{
+		"",
+		"a.b",
+		"a.b.c.d",
+		"!!.e30.",
+		encode([]byte("not json")) + ".e30.",
+		"e30.!!.",
+		"e30.e30.!!",
+	} {
+		if _, err := Parse(token); err == nil {
+			t.Errorf("Parse(%q) error = nil, want error", token)
+		}
+	}
+}
+
+func TestSignUnsupported(t *testing.T) {
+	key := newTestKeys(t)[0].key
+	if _, err := Sign(Header{Alg: "HS256"}, map[string]any{}, key); err == nil {
+		t.Error("Sign() with HS256 error = nil, want error")
+	}
+	if _, err := Sign(Header{Alg: ES256}, map[string]any{}, newTestKeys(t)[3].key); err == nil {
+		t.Error("Sign() with ES256 and an Ed25519 key error = nil, want error")
+	}
+	p224, err := ecdsa.GenerateKey(elliptic.P224(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Algorithm(p224.Public()); err == nil {
+		t.Error("Algorithm() of a P-224 key error = nil, want error")
+	}
+}
+
+func TestJWKRoundTrip(t *testing.T) {
+	for _, k := range newTestKeys(t) {
+		t.Run(k.alg, func(t *testing.T) {
+			jwk, err := NewJWK("k1", k.key.Public())
+			if err != nil {
+				t.Fatalf("NewJWK() error = %v", err)
+			}
+			if jwk.Kid != "k1" || jwk.Use != "sig" || jwk.Alg != k.alg {
+				t.Errorf("NewJWK() = %+v, want a signing key k1 for %s", jwk, k.alg)
+			}
+			data, err := json.Marshal(JWKS{Keys: []JWK{jwk}})
+			if err != nil {
+				t.Fatalf("json.Marshal() error = %v", err)
+			}
+			var set JWKS
+			if err := json.Unmarshal(data, &set); err != nil {
+				t.Fatalf("json.Unmarshal() error = %v", err)
+			}
+			decoded, ok := set.Key("k1")
+			if !ok {
+				t.Fatal("Key(k1) not found")
+			}
+			pub, err := decoded.PublicKey()
+			if err != nil {
+				t.Fatalf("PublicKey() error = %v", err)
+			}
+			if !pub.(interface{ Equal(crypto.PublicKey) bool }).Equal(k.key.Public()) {
+				t.Errorf("PublicKey() = %v, want %v", pub, k.key.Public())
+			}
+			if _, ok := set.Key("k2"); ok {
+				t.Error("Key(k2) found in a set without it")
+			}
+		})
+	}
+}
+
+func TestJWKInvalid(t *testing.T) {
+	p256, err := NewJWK("k1", newTestKeys(t)[0].key.Public())
+	if err != nil {
+		t.Fatal(err)
+	}
+	offCurve := p256
+	offCurve.Y = encode(make([]byte, 32))
+	tests := []struct {
+		name string
+		jwk  JWK
+	}{
+		{name: "unknown type", jwk: JWK{Kty: "oct"}},
+		{name: "unsupported curve", jwk: JWK{Kty: "EC", Crv: "P-521"}},
+		{name: "point not on curve", jwk: offCurve},
+		{name: "bad encoding", jwk: JWK{Kty: "EC", Crv: "P-256", X: "!!", Y: "!!"}},
+		{name: "RSA exponent 1", jwk: JWK{Kty: "RSA", N: encode([]byte{1, 2, 3}), E: encode([]byte{1})}},
+		{name: "huge RSA exponent", jwk: JWK{Kty: "RSA", N: encode([]byte{1, 2, 3}), E: encode([]byte{1, 0, 0, 0, 0, 0, 0, 0, 1})}},
+		{name: "Ed448", jwk: JWK{Kty: "OKP", Crv: "Ed448"}},
+		{name: "short Ed25519 key", jwk: JWK{Kty: "OKP", Crv: "Ed25519", X: encode([]byte{1, 2, 3})}},
+	}
+	for _, tc := range tests {
+		if _, err := tc.jwk.PublicKey(); err == nil {
+			t.Errorf("%s: PublicKey() error = nil, want error", tc.name)
+		}
+	}
+}
@@ -0,0 +1,164 @@
+// Copyright 2025 The A2A Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jwt
+
+import (
+	"context"
+	"crypto"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// ErrUnknownKey is returned when a key set does not contain the requested key.
+var ErrUnknownKey = errors.New("unknown key")
+
+// minRefreshInterval limits how often an unknown key ID or a failed fetch triggers a
+// refetch of a remote key set, so that forged tokens cannot be used to flood its server.
+const minRefreshInterval = 30 * time.Second
+
+// RemoteKeySet fetches and caches the signing keys published at a JWKS URL. The set
+// is fetched again when it is older than its TTL, or when a key it does not contain
+// is requested, which picks up rotated keys. While the set can not be fetched, the
+// cached keys keep being used, even when they are older than the TTL.
+type RemoteKeySet struct {
+	url    string
+	client *http.Client
+	ttl    time.Duration
+
+	// fetchMu serializes fetches of the key set.
+	fetchMu sync.Mutex
+	mu      sync.Mutex
+	keys    map[string]crypto.PublicKey
+	fetched time.Time
+	// failed is the time of the last failed fetch since the last successful one,
+	// and fetchErr its error.
+	failed   time.Time
+	fetchErr error
+}
+
+// NewRemoteKeySet returns a RemoteKeySet for the URL.
+func NewRemoteKeySet(url string, client *http.Client, ttl time.Duration) *RemoteKeySet {
+	return &RemoteKeySet{url: url, client: client, ttl: ttl}
+}
+
+// Key returns the public key with the ID. An empty ID selects the only key of sets
+// containing a single key.
+func (s *RemoteKeySet) Key(ctx context.Context, kid string) (crypto.PublicKey, error) {
+	if key, ok, age := s.lookup(kid); ok && age <= s.ttl {
+		return key, nil
+	}
+	s.fetchMu.Lock()
+	defer s.fetchMu.Unlock()
+	// Another caller may have fetched the key set while waiting for the lock.
+	key, ok, age := s.lookup(kid)
+	if ok && age <= s.ttl {
+		return key, nil
+	}
+	if failedAgo, err := s.lastFailure(); failedAgo < minRefreshInterval {
+		if ok {
+			return key, nil
+		}
+		return nil, err
+	}
+	if !ok && age < minRefreshInterval {
+		return nil, fmt.Errorf("%w %q", ErrUnknownKey, kid)
+	}
+	if err := s.fetch(ctx); err != nil {
+		// A fetch canceled by the caller says nothing about the server.
+		if ctx.Err() == nil {
+			s.mu.Lock()
+			s.failed, s.fetchErr = time.Now(), err
+			s.mu.Unlock()
+		}
+		if ok {
+			return key, nil
+		}
+		return nil, err
+	}
+	if key, ok, _ := s.lookup(kid); ok {
+		return key, nil
+	}
+	return nil, fmt.Errorf("%w %q", ErrUnknownKey, kid)
+}
+
+// lookup returns the key with the ID from the cached key set, and the age of the set.
+func (s *RemoteKeySet) lookup(kid string) (crypto.PublicKey, bool, time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.keys == nil {
+		return nil, false, time.Duration(math.MaxInt64)
+	}
+	key, ok := s.keys[kid]
+	if !ok && kid == "" && len(s.keys) == 1 {
+		for _, k := range s.keys {
+			key, ok = k, true
+		}
+	}
+	return key, ok, time.Since(s.fetched)
+}
+
+// lastFailure returns how long ago the last fetch failed and its error, if it failed
+// after the last successful fetch.
+func (s *RemoteKeySet) lastFailure() (time.Duration, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.failed.IsZero() {
+		return time.Duration(math.MaxInt64), nil
+	}
+	return time.Since(s.failed), s.fetchErr
+}
+
+func (s *RemoteKeySet) fetch(ctx context.Context) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, s.url, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/json")
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to fetch key set: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("unexpected HTTP status %q fetching key set from %s", resp.Status, s.url)
+	}
+	var set JWKS
+	if err := json.NewDecoder(io.LimitReader(resp.Body, 1<<20)).Decode(&set); err != nil {
+		return fmt.Errorf("failed to decode key set: %w", err)
+	}
+	keys := make(map[string]crypto.PublicKey, len(set.Keys))
+	for _, k := range set.Keys {
+		if k.Use != "" && k.Use != "sig" {
+			continue
+		}
+		// Keys of unsupported types are skipped rather than failing the whole set.
+		key, err := k.PublicKey()
+		if err != nil {
+			continue
+		}
+		keys[k.Kid] = key
+	}
+	s.mu.Lock()
+	s.keys, s.fetched = keys, time.Now()
+	s.failed, s.fetchErr = time.Time{}, nil
+	s.mu.Unlock()
+	return nil
+}
@@ -0,0 +1,302 @@
+// Copyright 2025 The A2A Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jwt
+
+import (
+	"context"
+	"crypto"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+)
+
+// keyServer serves a JSON Web Key Set.
+type keyServer struct {
+	*httptest.Server
+
+	mu      sync.Mutex
+	set     JWKS
+	status  int
+	fetches int
+}
+
+func newKeyServer(t *testing.T, keys map[string]crypto.PublicKey) *keyServer {
+	t.Helper()
+	s := &keyServer{status: http.StatusOK}
+	s.setKeys(t, keys)
+	s.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		s.fetches++
+		w.WriteHeader(s.status)
+		_ = json.NewEncoder(w).Encode(s.set)
+	}))
+	t.Cleanup(s.Close)
+	return s
+}
+
+func (s *keyServer) setKeys(t *testing.T, keys map[string]crypto.PublicKey) {
+	t.Helper()
+	var set JWKS
+	for kid, key := range keys {
+		jwk, err := NewJWK(kid, key)
+		if err != nil {
+			t.Fatalf("NewJWK() error = %v", err)
+		}
+		set.Keys = append(set.Keys, jwk)
+	}
+	s.mu.Lock()
+	s.set = set
+	s.mu.Unlock()
+}
+
+func (s *keyServer) fetchCount() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.fetches
+}
+
+// expire makes the key set, and the last failed fetch, look older by the interval.
+func (ks *RemoteKeySet) expire(d time.Duration) {
+	ks.mu.Lock()
+	ks.fetched = ks.fetched.Add(-d)
+	if !ks.failed.IsZero() {
+		ks.failed = ks.failed.Add(-d)
+	}
+	ks.mu.Unlock()
+}
+
+func TestRemoteKeySetKey(t *testing.T) {
+	keys := newTestKeys(t)
+	ctx := context.Background()
+	tests := []struct {
+		name    string
+		keys    map[string]crypto.PublicKey
+		kid     string
+		want    crypto.PublicKey
+		wantErr error
+	}{
+		{
+			name: "key by ID",
+			keys: map[string]crypto.PublicKey{"k1": keys[0].key.Public(), "k2": keys[1].key.Public()},
+			kid:  "k2",
+			want: keys[1].key.Public(),
+		},
+		{
+			name: "only key without ID",
+			keys: map[string]crypto.PublicKey{"k1": keys[2].key.Public()},
+			want: keys[2].key.Public(),
+		},
+		{
+			name:    "several keys without ID",
+			keys:    map[string]crypto.PublicKey{"k1": keys[0].key.Public(), "k2": keys[1].key.Public()},
+			wantErr: ErrUnknownKey,
+		},
+		{
+			name:    "unknown key",
+			keys:    map[string]crypto.PublicKey{"k1": keys[0].key.Public()},
+			kid:     "k2",
+			wantErr: ErrUnknownKey,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			server := newKeyServer(t, tc.keys)
+			got, err := NewRemoteKeySet(server.URL, http.DefaultClient, time.Hour).Key(ctx, tc.kid)
+			if !errors.Is(err, tc.wantErr) {
+				t.Fatalf("Key() error = %v, want %v", err, tc.wantErr)
+			}
+			if tc.want != nil && !tc.want.(interface{ Equal(crypto.PublicKey) bool }).Equal(got) {
+				t.Errorf("Key() = %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestRemoteKeySetSkipsUnusableKeys(t *testing.T) {
+	keys := newTestKeys(t)
+	server := newKeyServer(t, map[string]crypto.PublicKey{"sig": keys[0].key.Public(), "enc": keys[1].key.Public()})
+	server.mu.Lock()
+	for i, k := range server.set.Keys {
+		if k.Kid == "enc" {
+			server.set.Keys[i].Use = "enc"
+		}
+	}
+	server.set.Keys = append(server.set.Keys, JWK{Kty: "oct", Kid: "secret"})
+	server.mu.Unlock()
+
+	set := NewRemoteKeySet(server.URL, http.DefaultClient, time.Hour)
+	if _, err := set.Key(context.Background(), "sig"); err != nil {
+		t.Errorf("Key(sig) error = %v", err)
+	}
+	for _, kid := range []string{"enc", "secret"} {
+		if _, err := set.Key(context.Background(), kid); !errors.Is(err, ErrUnknownKey) {
+			t.Errorf("Key(%s) error = %v, want %v", kid, err, ErrUnknownKey)
+		}
+	}
+}
+
+func TestRemoteKeySetRefetches(t *testing.T) {
+	keys := newTestKeys(t)
+	ctx := context.Background()
+	server := newKeyServer(t, map[string]crypto.PublicKey{"k1": keys[0].key.Public()})
+	set := NewRemoteKeySet(server.URL, http.DefaultClient, time.Hour)
+
+	rotated := map[string]crypto.PublicKey{"k2": keys[1].key.Public()}
+	steps := []struct {
+		name string
+		// keys replace the keys of the server before the step if not nil.
+		keys        map[string]crypto.PublicKey
+		expire      time.Duration
+		kid         string
+		wantErr     error
+		wantFetches int
+	}{
+		{name: "first use", kid: "k1", wantFetches: 1},
+		{name: "cached key", kid: "k1", wantFetches: 1},
+		{name: "rotated key within the refresh interval", keys: rotated, kid: "k2", wantErr: ErrUnknownKey, wantFetches: 1},
+		{name: "rotated key after the refresh interval", expire: minRefreshInterval, kid: "k2", wantFetches: 2},
+		{name: "removed key", expire: minRefreshInterval, kid: "k1", wantErr: ErrUnknownKey, wantFetches: 3},
+		{name: "expired key set", expire: time.Hour + time.Second, kid: "k2", wantFetches: 4},
+	}
+	for _, step := range steps {
+		if step.keys != nil {
+			server.setKeys(t, step.keys)
+		}
+		set.expire(step.expire)
+		if _, err := set.Key(ctx, step.kid); !errors.Is(err, step.wantErr) {
+			t.Errorf("%s: Key() error = %v, want %v", step.name, err, step.wantErr)
+		}
+		if got := server.fetchCount(); got != step.wantFetches {
+			t.Errorf("%s: fetches = %d, want %d", step.name, got, step.wantFetches)
+		}
+	}
+}
+
+func TestRemoteKeySetServerDown(t *testing.T) {
+	keys := newTestKeys(t)
+	ctx := context.Background()
+	server := newKeyServer(t, map[string]crypto.PublicKey{"k1": keys[0].key.Public()})
+	set := NewRemoteKeySet(server.URL, http.DefaultClient, time.Hour)
+	if _, err := set.Key(ctx, "k1"); err != nil {
+		t.Fatalf("Key() error = %v", err)
+	}
+	server.mu.Lock()
+	server.status = http.StatusServiceUnavailable
+	server.mu.Unlock()
+
+	steps := []struct {
+		name        string
+		expire      time.Duration
+		kid         string
+		wantErr     bool
+		wantFetches int
+	}{
+		{name: "expired key set", expire: time.Hour + time.Second, kid: "k1", wantFetches: 2},
+		{name: "cached key after the failure", kid: "k1", wantFetches: 2},
+		{name: "unknown key after the failure", kid: "k2", wantErr: true, wantFetches: 2},
+		{name: "retry after the refresh interval", expire: minRefreshInterval, kid: "k1", wantFetches: 3},
+		{name: "unknown key after the refresh interval", expire: minRefreshInterval, kid: "k2", wantErr: true, wantFetches: 4},
+	}
+	for _, step := range steps {
+		set.expire(step.expire)
+		key, err := set.Key(ctx, step.kid)
+		if (err != nil) != step.wantErr {
+			t.Errorf("%s: Key() error = %v, want error: %v", step.name, err, step.wantErr)
+		}
+		if err == nil && !keys[0].key.Public().(interface{ Equal(crypto.PublicKey) bool }).Equal(key) {
+			t.Errorf("%s: Key() = %v, want the cached key", step.name, key)
+		}
+		if got := server.fetchCount(); got != step.wantFetches {
+			t.Errorf("%s: fetches = %d, want %d", step.name, got, step.wantFetches)
+		}
+	}
+}
+
+func TestRemoteKeySetServerDownWithoutKeys(t *testing.T) {
+	ctx := context.Background()
+	server := newKeyServer(t, nil)
+	server.status = http.StatusServiceUnavailable
+	set := NewRemoteKeySet(server.URL, http.DefaultClient, time.Hour)
+	for _, want := range []int{1, 1} {
+		if _, err := set.Key(ctx, "k1"); err == nil || errors.Is(err, ErrUnknownKey) {
+			t.Errorf("Key() error = %v, want a fetch error", err)
+		}
+		if got := server.fetchCount(); got != want {
+			t.Errorf("fetches = %d, want %d", got, want)
+		}
+	}
+	set.expire(minRefreshInterval)
+	if _, err := set.Key(ctx, "k1"); err == nil {
+		t.Error("Key() error = nil, want a fetch error")
+	}
+	if got := server.fetchCount(); got != 2 {
+		t.Errorf("fetches after the refresh interval = %d, want 2", got)
+	}
+}
+
+func TestRemoteKeySetConcurrentFetch(t *testing.T) {
+	keys := newTestKeys(t)
+	server := newKeyServer(t, map[string]crypto.PublicKey{"k1": keys[0].key.Public()})
+	set := NewRemoteKeySet(server.URL, http.DefaultClient, time.Hour)
+	var wg sync.WaitGroup
+	for range 10 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := set.Key(context.Background(), "k1"); err != nil {
+				t.Errorf("Key() error = %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+	if got := server.fetchCount(); got != 1 {
+		t.Errorf("fetches = %d, want 1", got)
+	}
+}
+
+func TestRemoteKeySetFetchErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		handler http.HandlerFunc
+	}{
+		{
+			name: "HTTP error",
+			handler: func(w http.ResponseWriter, _ *http.Request) {
+				http.Error(w, "unavailable", http.StatusServiceUnavailable)
+			},
+		},
+		{
+			name: "malformed key set",
+			handler: func(w http.ResponseWriter, _ *http.Request) {
+				_, _ = w.Write([]byte("not json"))
+			},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			server := httptest.NewServer(tc.handler)
+			defer server.Close()
+			_, err := NewRemoteKeySet(server.URL, http.DefaultClient, time.Hour).Key(context.Background(), "k1")
+			if err == nil || errors.Is(err, ErrUnknownKey) {
+				t.Errorf("Key() error = %v, want a fetch error", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,115 @@
+// Copyright 2025 The A2A Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package taskupdate applies task update events to Task snapshots.
+package taskupdate
+
+import (
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/structpb"
+
+	a2apb "github.com/a2aproject/a2a-go/grpc"
+)
+
+// ApplyStatusUpdate replaces the status of the task with the one from the event.
+// The message of the previous status, if any, is moved to the task history.
+func ApplyStatusUpdate(task *a2apb.Task, event *a2apb.TaskStatusUpdateEvent) {
+	if prev := task.GetStatus().GetUpdate(); prev != nil {
+		task.History = append(task.History, prev)
+	}
+	task.Status = proto.Clone(event.GetStatus()).(*a2apb.TaskStatus)
+	task.Metadata = mergeMetadata(task.Metadata, event.GetMetadata())
+}
+
+// ApplyArtifactUpdate adds the artifact from the event to the task. If Append is set,
+// the parts are appended to the artifact with the same ID, otherwise the artifact
+// replaces the one with the same ID. Appending to an unknown artifact adds it to the task.
+// It returns the updated artifact of the task.
+func ApplyArtifactUpdate(task *a2apb.Task, event *a2apb.TaskArtifactUpdateEvent) *a2apb.Artifact {
+	update := proto.Clone(event.GetArtifact()).(*a2apb.Artifact)
+	for i, artifact := range task.Artifacts {
+		if artifact.GetArtifactId() != update.GetArtifactId() {
+			continue
+		}
+		if !event.GetAppend() {
+			task.Artifacts[i] = update
+			return update
+		}
+		artifact.Parts = append(artifact.Parts, update.Parts...)
+		if update.GetName() != "" {
+			artifact.Name = update.GetName()
+		}
+		if update.GetDescription() != "" {
+			artifact.Description = update.GetDescription()
+		}
+		artifact.Metadata = mergeMetadata(artifact.Metadata, update.GetMetadata())
+		return artifact
+	}
+	task.Artifacts = append(task.Artifacts, update)
+	return update
+}
+
+// IsTerminal reports whether a task in the state can not be updated anymore.
+func IsTerminal(state a2apb.TaskState) bool {
+	switch state {
+	case a2apb.TaskState_TASK_STATE_COMPLETED,
+		a2apb.TaskState_TASK_STATE_FAILED,
+		a2apb.TaskState_TASK_STATE_CANCELLED,
+		a2apb.TaskState_TASK_STATE_REJECTED:
+		return true
+	default:
+		return false
+	}
+}
+
+// IsInterrupted reports whether a task in the state waits for the client.
+func IsInterrupted(state a2apb.TaskState) bool {
+	return state == a2apb.TaskState_TASK_STATE_INPUT_REQUIRED || state == a2apb.TaskState_TASK_STATE_AUTH_REQUIRED
+}
+
+// HasMessage reports whether the task history contains a message with the ID.
+func HasMessage(task *a2apb.Task, messageID string) bool {
+	for _, m := range task.GetHistory() {
+		if m.GetMessageId() == messageID {
+			return true
+		}
+	}
+	return false
+}
+
+// TrimHistory keeps at most length most recent messages in the task history.
+// A non-positive length keeps the full history.
+func TrimHistory(task *a2apb.Task, length int) {
+	if length <= 0 || len(task.History) <= length {
+		return
+	}
+	task.History = task.History[len(task.History)-length:]
+}
+
+// mergeMetadata copies the fields of src to dst, creating dst if needed.
+func mergeMetadata(dst, src *structpb.Struct) *structpb.Struct {
+	if len(src.GetFields()) == 0 {
+		return dst
+	}
+	if dst == nil {
+		dst = &structpb.Struct{}
+	}
+	if dst.Fields == nil {
+		dst.Fields = make(map[string]*structpb.Value, len(src.GetFields()))
+	}
+	for k, v := range src.GetFields() {
+		dst.Fields[k] = proto.Clone(v).(*structpb.Value)
+	}
+	return dst
+}
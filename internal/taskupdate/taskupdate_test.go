@@ -0,0 +1,143 @@
+// Copyright 2025 The A2A Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package taskupdate
+
+import (
+	"testing"
+
+	"google.golang.org/protobuf/types/known/structpb"
+
+	a2apb "github.com/a2aproject/a2a-go/grpc"
+)
+
+func textArtifact(id string, texts ...string) *a2apb.Artifact {
+	artifact := &a2apb.Artifact{ArtifactId: id}
+	for _, text := range texts {
+		artifact.Parts = append(artifact.Parts, &a2apb.Part{Part: &a2apb.Part_Text{Text: text}})
+	}
+	return artifact
+}
+
+func TestApplyArtifactUpdate(t *testing.T) {
+	tests := []struct {
+		name      string
+		existing  []*a2apb.Artifact
+		update    *a2apb.TaskArtifactUpdateEvent
+		wantParts map[string]int
+	}{
+		{
+			name:      "new artifact",
+			update:    &a2apb.TaskArtifactUpdateEvent{Artifact: textArtifact("a1", "x")},
+			wantParts: map[string]int{"a1": 1},
+		},
+		{
+			name:      "append",
+			existing:  []*a2apb.Artifact{textArtifact("a1", "x")},
+			update:    &a2apb.TaskArtifactUpdateEvent{Artifact: textArtifact("a1", "y", "z"), Append: true},
+			wantParts: map[string]int{"a1": 3},
+		},
+		{
+			name:      "replace",
+			existing:  []*a2apb.Artifact{textArtifact("a1", "x", "y")},
+			update:    &a2apb.TaskArtifactUpdateEvent{Artifact: textArtifact("a1", "z")},
+			wantParts: map[string]int{"a1": 1},
+		},
+		{
+			name:      "append to unknown artifact",
+			existing:  []*a2apb.Artifact{textArtifact("a1", "x")},
+			update:    &a2apb.TaskArtifactUpdateEvent{Artifact: textArtifact("a2", "y"), Append: true},
+			wantParts: map[string]int{"a1": 1, "a2": 1},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			task := &a2apb.Task{Artifacts: tc.existing}
+			ApplyArtifactUpdate(task, tc.update)
+			got := make(map[string]int)
+			for _, artifact := range task.GetArtifacts() {
+				got[artifact.GetArtifactId()] = len(artifact.GetParts())
+			}
+			if len(got) != len(tc.wantParts) {
+				t.Fatalf("artifacts = %v, want %v", got, tc.wantParts)
+			}
+			for id, n := range tc.wantParts {
+				if got[id] != n {
+					t.Errorf("artifact %s has %d parts, want %d", id, got[id], n)
+				}
+			}
+		})
+	}
+}
+
+func TestApplyArtifactUpdateMergesFields(t *testing.T) {
+	task := &a2apb.Task{Artifacts: []*a2apb.Artifact{{ArtifactId: "a1", Name: "draft"}}}
+	metadata, _ := structpb.NewStruct(map[string]any{"lang": "en"})
+	update := &a2apb.Artifact{ArtifactId: "a1", Description: "the report", Metadata: metadata}
+	got := ApplyArtifactUpdate(task, &a2apb.TaskArtifactUpdateEvent{Artifact: update, Append: true})
+	if got.GetName() != "draft" || got.GetDescription() != "the report" || got.GetMetadata().GetFields()["lang"].GetStringValue() != "en" {
+		t.Errorf("ApplyArtifactUpdate() = %v, want the name kept and the description and metadata merged", got)
+	}
+	update.Description = "changed"
+	if got.GetDescription() != "the report" {
+		t.Error("ApplyArtifactUpdate() keeps a reference to the event artifact")
+	}
+}
+
+func TestApplyStatusUpdate(t *testing.T) {
+	task := &a2apb.Task{Status: &a2apb.TaskStatus{
+		State:  a2apb.TaskState_TASK_STATE_WORKING,
+		Update: &a2apb.Message{MessageId: "m1"},
+	}}
+	metadata, _ := structpb.NewStruct(map[string]any{"step": 2})
+	ApplyStatusUpdate(task, &a2apb.TaskStatusUpdateEvent{
+		Status:   &a2apb.TaskStatus{State: a2apb.TaskState_TASK_STATE_COMPLETED},
+		Metadata: metadata,
+	})
+	if task.GetStatus().GetState() != a2apb.TaskState_TASK_STATE_COMPLETED || task.GetStatus().GetUpdate() != nil {
+		t.Errorf("status = %v, want the status of the event", task.GetStatus())
+	}
+	if len(task.GetHistory()) != 1 || task.GetHistory()[0].GetMessageId() != "m1" {
+		t.Errorf("history = %v, want the previous status message", task.GetHistory())
+	}
+	if task.GetMetadata().GetFields()["step"].GetNumberValue() != 2 {
+		t.Errorf("metadata = %v, want the metadata of the event", task.GetMetadata())
+	}
+}
+
+func TestTrimHistory(t *testing.T) {
+	tests := []struct {
+		length int
+		want   []string
+	}{
+		{length: 0, want: []string{"m1", "m2", "m3"}},
+		{length: -1, want: []string{"m1", "m2", "m3"}},
+		{length: 2, want: []string{"m2", "m3"}},
+		{length: 5, want: []string{"m1", "m2", "m3"}},
+	}
+	for _, tc := range tests {
+		task := &a2apb.Task{History: []*a2apb.Message{{MessageId: "m1"}, {MessageId: "m2"}, {MessageId: "m3"}}}
+		TrimHistory(task, tc.length)
+		var got []string
+		for _, m := range task.GetHistory() {
+			got = append(got, m.GetMessageId())
+		}
+		if len(got) != len(tc.want) || got[0] != tc.want[0] {
+			t.Errorf("TrimHistory(%d) = %v, want %v", tc.length, got, tc.want)
+		}
+		if !HasMessage(task, tc.want[len(tc.want)-1]) {
+			t.Errorf("HasMessage(%s) = false after TrimHistory(%d)", tc.want[len(tc.want)-1], tc.length)
+		}
+	}
+}
@@ -0,0 +1,318 @@
+// Copyright 2025 The A2A Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jsonrpc
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"net/url"
+	"strconv"
+	"sync/atomic"
+
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/types/known/emptypb"
+
+	"github.com/a2aproject/a2a-go/a2a"
+	a2apb "github.com/a2aproject/a2a-go/grpc"
+)
+
+// Client is a JSON-RPC client of an A2A agent. It implements the A2AServiceClient
+// interface, so callers can switch between the gRPC and JSON-RPC transports without
+// code changes. gRPC call options are not applicable to the transport and are ignored.
+type Client struct {
+	url          string
+	httpClient   *http.Client
+	maxEventSize int
+	lastID       atomic.Int64
+}
+
+// DefaultMaxEventSize is the default limit of the size of a streamed event in bytes.
+const DefaultMaxEventSize = 16 << 20
+
+var _ a2apb.A2AServiceClient = (*Client)(nil)
+
+// ClientOption configures a Client.
+type ClientOption func(*Client)
+
+// WithHTTPClient sets the HTTP client used for making requests.
+// http.DefaultClient is used by default.
+func WithHTTPClient(client *http.Client) ClientOption {
+	return func(c *Client) {
+		c.httpClient = client
+	}
+}
+
+// WithMaxEventSize sets the maximum size in bytes of an event received from a stream,
+// including the SSE fields of the event. Streams sending larger events fail with
+// a2a.ErrInvalidAgentResponse. A non-positive value disables the limit.
+func WithMaxEventSize(size int) ClientOption {
+	return func(c *Client) {
+		c.maxEventSize = size
+	}
+}
+
+// NewClient creates a Client sending requests to the JSON-RPC endpoint at the provided URL.
+func NewClient(url string, opts ...ClientOption) *Client {
+	c := &Client{url: url, httpClient: http.DefaultClient, maxEventSize: DefaultMaxEventSize}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
+// Ping checks that the JSON-RPC endpoint is reachable by sending a HEAD request to it.
+// The binding does not define a health check method, so any HTTP response, including
+// an error status, means the endpoint is reachable. Only transport errors are returned.
+func (c *Client) Ping(ctx context.Context) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodHead, c.url, nil)
+	if err != nil {
+		return err
+	}
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	return resp.Body.Close()
+}
+
+// SendMessage implements A2AServiceClient.
+func (c *Client) SendMessage(ctx context.Context, in *a2apb.SendMessageRequest, _ ...grpc.CallOption) (*a2apb.SendMessageResponse, error) {
+	params, err := sendMessageParamsFromProto(in)
+	if err != nil {
+		return nil, err
+	}
+	var raw json.RawMessage
+	if err := c.call(ctx, MethodSendMessage, params, &raw); err != nil {
+		return nil, err
+	}
+	event, err := a2a.UnmarshalEvent(raw)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", a2a.ErrInvalidAgentResponse, err)
+	}
+	result, ok := event.(a2a.SendMessageResult)
+	if !ok {
+		return nil, fmt.Errorf("%w: unexpected result type %T", a2a.ErrInvalidAgentResponse, event)
+	}
+	return a2a.SendMessageResultToProto(result)
+}
+
+// SendStreamingMessage implements A2AServiceClient.
+func (c *Client) SendStreamingMessage(ctx context.Context, in *a2apb.SendMessageRequest, _ ...grpc.CallOption) (grpc.ServerStreamingClient[a2apb.StreamResponse], error) {
+	params, err := sendMessageParamsFromProto(in)
+	if err != nil {
+		return nil, err
+	}
+	return c.stream(ctx, MethodSendStreamingMessage, params)
+}
+
+// GetTask implements A2AServiceClient.
+func (c *Client) GetTask(ctx context.Context, in *a2apb.GetTaskRequest, _ ...grpc.CallOption) (*a2apb.Task, error) {
+	params, err := taskQueryParamsFromProto(in)
+	if err != nil {
+		return nil, err
+	}
+	var task a2a.Task
+	if err := c.call(ctx, MethodGetTask, params, &task); err != nil {
+		return nil, err
+	}
+	return a2a.TaskToProto(&task)
+}
+
+// CancelTask implements A2AServiceClient.
+func (c *Client) CancelTask(ctx context.Context, in *a2apb.CancelTaskRequest, _ ...grpc.CallOption) (*a2apb.Task, error) {
+	params, err := taskIDParamsFromName(in.GetName())
+	if err != nil {
+		return nil, err
+	}
+	var task a2a.Task
+	if err := c.call(ctx, MethodCancelTask, params, &task); err != nil {
+		return nil, err
+	}
+	return a2a.TaskToProto(&task)
+}
+
+// TaskSubscription implements A2AServiceClient.
+func (c *Client) TaskSubscription(ctx context.Context, in *a2apb.TaskSubscriptionRequest, _ ...grpc.CallOption) (grpc.ServerStreamingClient[a2apb.StreamResponse], error) {
+	params, err := taskIDParamsFromName(in.GetName())
+	if err != nil {
+		return nil, err
+	}
+	return c.stream(ctx, MethodResubscribe, params)
+}
+
+// CreateTaskPushNotificationConfig implements A2AServiceClient.
+func (c *Client) CreateTaskPushNotificationConfig(ctx context.Context, in *a2apb.CreateTaskPushNotificationConfigRequest, _ ...grpc.CallOption) (*a2apb.TaskPushNotificationConfig, error) {
+	params, err := setPushConfigParamsFromProto(in)
+	if err != nil {
+		return nil, err
+	}
+	var config a2a.TaskPushConfig
+	if err := c.call(ctx, MethodSetPushConfig, params, &config); err != nil {
+		return nil, err
+	}
+	return a2a.TaskPushConfigToProto(&config), nil
+}
+
+// GetTaskPushNotificationConfig implements A2AServiceClient.
+func (c *Client) GetTaskPushNotificationConfig(ctx context.Context, in *a2apb.GetTaskPushNotificationConfigRequest, _ ...grpc.CallOption) (*a2apb.TaskPushNotificationConfig, error) {
+	params, err := getPushConfigParamsFromProto(in)
+	if err != nil {
+		return nil, err
+	}
+	var config a2a.TaskPushConfig
+	if err := c.call(ctx, MethodGetPushConfig, params, &config); err != nil {
+		return nil, err
+	}
+	return a2a.TaskPushConfigToProto(&config), nil
+}
+
+// ListTaskPushNotificationConfig implements A2AServiceClient. The JSON-RPC binding
+// has no pagination, so all the configs are returned in a single page.
+func (c *Client) ListTaskPushNotificationConfig(ctx context.Context, in *a2apb.ListTaskPushNotificationConfigRequest, _ ...grpc.CallOption) (*a2apb.ListTaskPushNotificationConfigResponse, error) {
+	params, err := listPushConfigParamsFromProto(in)
+	if err != nil {
+		return nil, err
+	}
+	var configs []*a2a.TaskPushConfig
+	if err := c.call(ctx, MethodListPushConfig, params, &configs); err != nil {
+		return nil, err
+	}
+	resp := &a2apb.ListTaskPushNotificationConfigResponse{}
+	for _, config := range configs {
+		resp.Configs = append(resp.Configs, a2a.TaskPushConfigToProto(config))
+	}
+	return resp, nil
+}
+
+// GetAgentCard implements A2AServiceClient. The card is fetched from the well-known
+// path on the origin of the client URL.
+func (c *Client) GetAgentCard(ctx context.Context, _ *a2apb.GetAgentCardRequest, _ ...grpc.CallOption) (*a2apb.AgentCard, error) {
+	u, err := url.Parse(c.url)
+	if err != nil {
+		return nil, fmt.Errorf("invalid agent url: %w", err)
+	}
+	cardURL := u.Scheme + "://" + u.Host + a2a.WellKnownAgentCardPath
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, cardURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "application/json")
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected HTTP status %q fetching %s", resp.Status, cardURL)
+	}
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	card, err := a2a.UnmarshalAgentCard(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode agent card: %w", err)
+	}
+	return card, nil
+}
+
+// DeleteTaskPushNotificationConfig implements A2AServiceClient.
+func (c *Client) DeleteTaskPushNotificationConfig(ctx context.Context, in *a2apb.DeleteTaskPushNotificationConfigRequest, _ ...grpc.CallOption) (*emptypb.Empty, error) {
+	params, err := deletePushConfigParamsFromProto(in)
+	if err != nil {
+		return nil, err
+	}
+	if err := c.call(ctx, MethodDeletePushConfig, params, nil); err != nil {
+		return nil, err
+	}
+	return &emptypb.Empty{}, nil
+}
+
+// call sends a request and decodes the result of the response into result, if it is not nil.
+func (c *Client) call(ctx context.Context, method string, params, result any) error {
+	resp, err := c.send(ctx, method, params, "application/json")
+	if err != nil {
+		return err
+	}
+	defer func() { _ = resp.Body.Close() }()
+	return readResponse(resp.Body, result)
+}
+
+// stream sends a request to a streaming method. If the agent fails the request before
+// starting the stream, the error is returned immediately.
+func (c *Client) stream(ctx context.Context, method string, params any) (grpc.ServerStreamingClient[a2apb.StreamResponse], error) {
+	resp, err := c.send(ctx, method, params, "text/event-stream")
+	if err != nil {
+		return nil, err
+	}
+	mediaType, _, _ := mime.ParseMediaType(resp.Header.Get("Content-Type"))
+	if mediaType != "text/event-stream" {
+		defer func() { _ = resp.Body.Close() }()
+		if err := readResponse(resp.Body, nil); err != nil {
+			return nil, err
+		}
+		return nil, fmt.Errorf("%w: unexpected content type %q of a stream", a2a.ErrInvalidAgentResponse, mediaType)
+	}
+	return newClientStream(ctx, resp, c.maxEventSize), nil
+}
+
+func (c *Client) send(ctx context.Context, method string, params any, accept string) (*http.Response, error) {
+	rawParams, err := json.Marshal(params)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode params: %w", err)
+	}
+	id := strconv.FormatInt(c.lastID.Add(1), 10)
+	body, err := json.Marshal(&request{JSONRPC: Version, Method: method, Params: rawParams, ID: json.RawMessage(id)})
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode request: %w", err)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.url, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", accept)
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		_ = resp.Body.Close()
+		return nil, fmt.Errorf("unexpected HTTP status %q", resp.Status)
+	}
+	return resp, nil
+}
+
+func readResponse(body io.Reader, result any) error {
+	var resp response
+	if err := json.NewDecoder(body).Decode(&resp); err != nil {
+		return fmt.Errorf("%w: failed to decode response: %w", a2a.ErrInvalidAgentResponse, err)
+	}
+	if resp.Error != nil {
+		return resp.Error
+	}
+	if result == nil {
+		return nil
+	}
+	if err := json.Unmarshal(resp.Result, result); err != nil {
+		return fmt.Errorf("%w: failed to decode result: %w", a2a.ErrInvalidAgentResponse, err)
+	}
+	return nil
+}
@@ -0,0 +1,277 @@
+// Copyright 2025 The A2A Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jsonrpc
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"google.golang.org/protobuf/proto"
+
+	"github.com/a2aproject/a2a-go/a2a"
+	a2apb "github.com/a2aproject/a2a-go/grpc"
+)
+
+func newTestClient(t *testing.T, h http.Handler) *Client {
+	t.Helper()
+	server := httptest.NewServer(h)
+	t.Cleanup(server.Close)
+	return NewClient(server.URL)
+}
+
+func TestClientCall(t *testing.T) {
+	client := newTestClient(t, NewHandler(&fakeServer{}))
+	ctx := context.Background()
+
+	task, err := client.GetTask(ctx, &a2apb.GetTaskRequest{Name: "tasks/t1"})
+	if err != nil {
+		t.Fatalf("GetTask() error = %v", err)
+	}
+	want := &a2apb.Task{Id: "t1", Status: &a2apb.TaskStatus{State: a2apb.TaskState_TASK_STATE_WORKING}}
+	if !proto.Equal(task, want) {
+		t.Errorf("GetTask() = %v, want %v", task, want)
+	}
+
+	tests := []struct {
+		name    string
+		call    func() error
+		wantErr error
+	}{
+		{
+			name: "protocol error",
+			call: func() error {
+				_, err := client.GetTask(ctx, &a2apb.GetTaskRequest{Name: "tasks/missing"})
+				return err
+			},
+			wantErr: a2a.ErrTaskNotFound,
+		},
+		{
+			name: "unimplemented method",
+			call: func() error {
+				_, err := client.GetTaskPushNotificationConfig(ctx, &a2apb.GetTaskPushNotificationConfigRequest{Name: "tasks/t1/pushNotificationConfigs/c1"})
+				return err
+			},
+			wantErr: a2a.ErrUnsupportedOperation,
+		},
+		{
+			name: "invalid name",
+			call: func() error {
+				_, err := client.CancelTask(ctx, &a2apb.CancelTaskRequest{Name: "t1"})
+				return err
+			},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.call()
+			if err == nil {
+				t.Fatal("error = nil, want error")
+			}
+			if tc.wantErr != nil && !errors.Is(err, tc.wantErr) {
+				t.Errorf("error = %v, want %v", err, tc.wantErr)
+			}
+		})
+	}
+}
+
+func TestClientHTTPErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		handler http.HandlerFunc
+		wantErr error
+	}{
+		{
+			name: "unexpected status",
+			handler: func(w http.ResponseWriter, _ *http.Request) {
+				http.Error(w, "unavailable", http.StatusServiceUnavailable)
+			},
+		},
+		{
+			name: "malformed response",
+			handler: func(w http.ResponseWriter, _ *http.Request) {
+				_, _ = w.Write([]byte("not json"))
+			},
+			wantErr: a2a.ErrInvalidAgentResponse,
+		},
+		{
+			name: "malformed result",
+			handler: func(w http.ResponseWriter, _ *http.Request) {
+				_, _ = w.Write([]byte(`{"jsonrpc":"2.0","id":1,"result":{"id":5}}`))
+			},
+			wantErr: a2a.ErrInvalidAgentResponse,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := newTestClient(t, tc.handler)
+			_, err := client.GetTask(context.Background(), &a2apb.GetTaskRequest{Name: "tasks/t1"})
+			if err == nil {
+				t.Fatal("GetTask() error = nil, want error")
+			}
+			if tc.wantErr != nil && !errors.Is(err, tc.wantErr) {
+				t.Errorf("GetTask() error = %v, want %v", err, tc.wantErr)
+			}
+		})
+	}
+}
+
+func TestClientStream(t *testing.T) {
+	srv := &streamServer{
+		events: []*a2apb.StreamResponse{statusUpdate(a2apb.TaskState_TASK_STATE_WORKING, false), statusUpdate(a2apb.TaskState_TASK_STATE_COMPLETED, true)},
+	}
+	client := newTestClient(t, NewHandler(srv))
+	stream, err := client.TaskSubscription(context.Background(), &a2apb.TaskSubscriptionRequest{Name: "tasks/t1"})
+	if err != nil {
+		t.Fatalf("TaskSubscription() error = %v", err)
+	}
+	for i, want := range srv.events {
+		got, err := stream.Recv()
+		if err != nil {
+			t.Fatalf("Recv() %d error = %v", i, err)
+		}
+		if !proto.Equal(got, want) {
+			t.Errorf("Recv() %d = %v, want %v", i, got, want)
+		}
+	}
+	if _, err := stream.Recv(); !errors.Is(err, io.EOF) {
+		t.Errorf("Recv() after the last event error = %v, want io.EOF", err)
+	}
+}
+
+func TestClientStreamErrors(t *testing.T) {
+	t.Run("before first event", func(t *testing.T) {
+		client := newTestClient(t, NewHandler(&streamServer{err: a2a.ErrTaskNotFound}))
+		_, err := client.TaskSubscription(context.Background(), &a2apb.TaskSubscriptionRequest{Name: "tasks/t1"})
+		if !errors.Is(err, a2a.ErrTaskNotFound) {
+			t.Errorf("TaskSubscription() error = %v, want %v", err, a2a.ErrTaskNotFound)
+		}
+	})
+
+	t.Run("after first event", func(t *testing.T) {
+		srv := &streamServer{events: []*a2apb.StreamResponse{statusUpdate(a2apb.TaskState_TASK_STATE_WORKING, false)}, err: a2a.ErrUnsupportedOperation}
+		client := newTestClient(t, NewHandler(srv))
+		stream, err := client.TaskSubscription(context.Background(), &a2apb.TaskSubscriptionRequest{Name: "tasks/t1"})
+		if err != nil {
+			t.Fatalf("TaskSubscription() error = %v", err)
+		}
+		if _, err := stream.Recv(); err != nil {
+			t.Fatalf("Recv() error = %v", err)
+		}
+		if _, err := stream.Recv(); !errors.Is(err, a2a.ErrUnsupportedOperation) {
+			t.Errorf("Recv() error = %v, want %v", err, a2a.ErrUnsupportedOperation)
+		}
+		// The error is sticky.
+		if _, err := stream.Recv(); !errors.Is(err, a2a.ErrUnsupportedOperation) {
+			t.Errorf("Recv() after an error = %v, want %v", err, a2a.ErrUnsupportedOperation)
+		}
+	})
+}
+
+func TestClientStreamReadEvent(t *testing.T) {
+	long := strings.Repeat("x", 5000)
+	tests := []struct {
+		name    string
+		body    string
+		maxSize int
+		want    []string
+		wantErr error
+	}{
+		{
+			name: "single event",
+			body: "data: {\"a\":1}\n\n",
+			want: []string{`{"a":1}`},
+		},
+		{
+			name: "comments and other fields",
+			body: ": keep-alive\n\nevent: message\nid: 5\ndata: {\"a\":1}\n\n: keep-alive\n\n",
+			want: []string{`{"a":1}`},
+		},
+		{
+			name: "multi-line data",
+			body: "data: {\"a\":\ndata: 1}\n\n",
+			want: []string{"{\"a\":\n1}"},
+		},
+		{
+			name: "crlf line endings",
+			body: "data: {\"a\":1}\r\n\r\ndata:{\"b\":2}\r\n\r\n",
+			want: []string{`{"a":1}`, `{"b":2}`},
+		},
+		{
+			name:    "truncated event",
+			body:    "data: {\"a\":1}\n",
+			wantErr: io.ErrUnexpectedEOF,
+		},
+		{
+			name: "line longer than the read buffer",
+			body: "data: " + long + "\n\n",
+			want: []string{long},
+		},
+		{
+			name:    "event within the maximum size",
+			body:    ": keep-alive\n\n: keep-alive\n\ndata: 012345678\n\n",
+			maxSize: 20,
+			want:    []string{"012345678"},
+		},
+		{
+			name:    "line exceeding the maximum size",
+			body:    "data: " + long,
+			maxSize: 20,
+			wantErr: a2a.ErrInvalidAgentResponse,
+		},
+		{
+			name:    "event exceeding the maximum size",
+			body:    "data: 0123\ndata: 0123\ndata: 0123\n\n",
+			maxSize: 20,
+			wantErr: a2a.ErrInvalidAgentResponse,
+		},
+		{
+			name:    "fields exceeding the maximum size",
+			body:    ": 0123\nevent: 0123\nid: 0123\ndata: 1\n\n",
+			maxSize: 20,
+			wantErr: a2a.ErrInvalidAgentResponse,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			resp := &http.Response{Body: io.NopCloser(strings.NewReader(tc.body))}
+			maxSize := tc.maxSize
+			if maxSize == 0 {
+				maxSize = DefaultMaxEventSize
+			}
+			stream := newClientStream(context.Background(), resp, maxSize)
+			for i, want := range tc.want {
+				got, err := stream.readEvent()
+				if err != nil {
+					t.Fatalf("readEvent() %d error = %v", i, err)
+				}
+				if got != want {
+					t.Errorf("readEvent() %d = %q, want %q", i, got, want)
+				}
+			}
+			wantErr := tc.wantErr
+			if wantErr == nil {
+				wantErr = io.EOF
+			}
+			if _, err := stream.readEvent(); !errors.Is(err, wantErr) {
+				t.Errorf("readEvent() at the end error = %v, want %v", err, wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,191 @@
+// Copyright 2025 The A2A Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jsonrpc
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/a2aproject/a2a-go/a2a"
+	a2apb "github.com/a2aproject/a2a-go/grpc"
+)
+
+// clientStream reads JSON-RPC responses from an SSE stream and implements
+// grpc.ServerStreamingClient. Recv returns io.EOF when the server closes the stream.
+type clientStream struct {
+	ctx     context.Context
+	resp    *http.Response
+	reader  *bufio.Reader
+	maxSize int
+
+	closeOnce sync.Once
+	err       error
+}
+
+var _ grpc.ServerStreamingClient[a2apb.StreamResponse] = (*clientStream)(nil)
+
+func newClientStream(ctx context.Context, resp *http.Response, maxSize int) *clientStream {
+	return &clientStream{ctx: ctx, resp: resp, reader: bufio.NewReader(resp.Body), maxSize: maxSize}
+}
+
+// Recv implements grpc.ServerStreamingClient.
+func (s *clientStream) Recv() (*a2apb.StreamResponse, error) {
+	if s.err != nil {
+		return nil, s.err
+	}
+	resp, err := s.recv()
+	if err != nil {
+		s.fail(err)
+		return nil, s.err
+	}
+	return resp, nil
+}
+
+func (s *clientStream) recv() (*a2apb.StreamResponse, error) {
+	data, err := s.readEvent()
+	if err != nil {
+		return nil, err
+	}
+	var raw json.RawMessage
+	if err := readResponse(strings.NewReader(data), &raw); err != nil {
+		return nil, err
+	}
+	event, err := a2a.UnmarshalEvent(raw)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", a2a.ErrInvalidAgentResponse, err)
+	}
+	return a2a.EventToProto(event)
+}
+
+// readEvent returns the data of the next SSE event. Comments and fields other than
+// "data" are skipped, but count towards the maximum size of the event.
+func (s *clientStream) readEvent() (string, error) {
+	var data strings.Builder
+	hasData := false
+	size := 0
+	for {
+		line, err := s.readLine(size)
+		size += len(line)
+		if err != nil {
+			if errors.Is(err, io.EOF) && line == "" && !hasData {
+				return "", io.EOF
+			}
+			if ctxErr := s.ctx.Err(); ctxErr != nil {
+				return "", ctxErr
+			}
+			if errors.Is(err, io.EOF) {
+				return "", io.ErrUnexpectedEOF
+			}
+			return "", err
+		}
+		line = strings.TrimRight(line, "\r\n")
+		if line == "" {
+			if hasData {
+				return data.String(), nil
+			}
+			size = 0
+			continue
+		}
+		if strings.HasPrefix(line, ":") {
+			continue
+		}
+		field, value, _ := strings.Cut(line, ":")
+		if field != "data" {
+			continue
+		}
+		if hasData {
+			data.WriteByte('\n')
+		}
+		data.WriteString(strings.TrimPrefix(value, " "))
+		hasData = true
+	}
+}
+
+// readLine returns the next line of the stream, including its line terminator. It
+// fails if the line would make an event which already has size bytes exceed the
+// maximum size.
+func (s *clientStream) readLine(size int) (string, error) {
+	var line []byte
+	for {
+		chunk, err := s.reader.ReadSlice('\n')
+		if s.maxSize > 0 && size+len(line)+len(chunk) > s.maxSize {
+			return "", fmt.Errorf("%w: event exceeds %d bytes", a2a.ErrInvalidAgentResponse, s.maxSize)
+		}
+		line = append(line, chunk...)
+		if !errors.Is(err, bufio.ErrBufferFull) {
+			return string(line), err
+		}
+	}
+}
+
+func (s *clientStream) fail(err error) {
+	s.err = err
+	s.closeOnce.Do(func() { _ = s.resp.Body.Close() })
+}
+
+// Header implements grpc.ClientStream. HTTP response headers are returned as metadata.
+func (s *clientStream) Header() (metadata.MD, error) {
+	md := metadata.MD{}
+	for k, v := range s.resp.Header {
+		md.Append(k, v...)
+	}
+	return md, nil
+}
+
+// Trailer implements grpc.ClientStream. Trailers are not supported by the binding.
+func (s *clientStream) Trailer() metadata.MD {
+	return nil
+}
+
+// CloseSend implements grpc.ClientStream. The request is fully sent when the stream is created.
+func (s *clientStream) CloseSend() error {
+	return nil
+}
+
+// Context implements grpc.ClientStream.
+func (s *clientStream) Context() context.Context {
+	return s.ctx
+}
+
+// SendMsg implements grpc.ClientStream. Streaming A2A methods are server-streaming only.
+func (s *clientStream) SendMsg(any) error {
+	return errors.New("client streaming is not supported")
+}
+
+// RecvMsg implements grpc.ClientStream.
+func (s *clientStream) RecvMsg(m any) error {
+	target, ok := m.(*a2apb.StreamResponse)
+	if !ok {
+		return fmt.Errorf("unexpected message type %T", m)
+	}
+	resp, err := s.Recv()
+	if err != nil {
+		return err
+	}
+	proto.Reset(target)
+	proto.Merge(target, resp)
+	return nil
+}
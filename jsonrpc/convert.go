@@ -0,0 +1,210 @@
+// Copyright 2025 The A2A Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jsonrpc
+
+import (
+	"errors"
+	"fmt"
+
+	"google.golang.org/protobuf/types/known/structpb"
+
+	"github.com/a2aproject/a2a-go/a2a"
+	a2apb "github.com/a2aproject/a2a-go/grpc"
+)
+
+func sendMessageRequestToProto(p *a2a.MessageSendParams) (*a2apb.SendMessageRequest, error) {
+	if p.Message == nil {
+		return nil, fmt.Errorf("%w: message is required", a2a.ErrInvalidParams)
+	}
+	msg, err := a2a.MessageToProto(p.Message)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", a2a.ErrInvalidParams, err)
+	}
+	metadata, err := metadataToProto(p.Metadata)
+	if err != nil {
+		return nil, err
+	}
+	req := &a2apb.SendMessageRequest{Request: msg, Metadata: metadata}
+	if c := p.Config; c != nil {
+		req.Configuration = &a2apb.SendMessageConfiguration{
+			AcceptedOutputModes: c.AcceptedOutputModes,
+			PushNotification:    a2a.PushConfigToProto(c.PushConfig),
+			HistoryLength:       historyLengthToProto(c.HistoryLength),
+			Blocking:            c.Blocking,
+		}
+	}
+	return req, nil
+}
+
+func getTaskRequestToProto(p *a2a.TaskQueryParams) (*a2apb.GetTaskRequest, error) {
+	if p.ID == "" {
+		return nil, fmt.Errorf("%w: task id is required", a2a.ErrInvalidParams)
+	}
+	return &a2apb.GetTaskRequest{Name: a2a.TaskName(p.ID), HistoryLength: historyLengthToProto(p.HistoryLength)}, nil
+}
+
+func cancelTaskRequestToProto(p *a2a.TaskIDParams) (*a2apb.CancelTaskRequest, error) {
+	if p.ID == "" {
+		return nil, fmt.Errorf("%w: task id is required", a2a.ErrInvalidParams)
+	}
+	return &a2apb.CancelTaskRequest{Name: a2a.TaskName(p.ID)}, nil
+}
+
+func taskSubscriptionRequestToProto(p *a2a.TaskIDParams) (*a2apb.TaskSubscriptionRequest, error) {
+	if p.ID == "" {
+		return nil, fmt.Errorf("%w: task id is required", a2a.ErrInvalidParams)
+	}
+	return &a2apb.TaskSubscriptionRequest{Name: a2a.TaskName(p.ID)}, nil
+}
+
+func createPushConfigRequestToProto(p *a2a.TaskPushConfig) (*a2apb.CreateTaskPushNotificationConfigRequest, error) {
+	if p.TaskID == "" {
+		return nil, fmt.Errorf("%w: task id is required", a2a.ErrInvalidParams)
+	}
+	if p.Config.URL == "" {
+		return nil, fmt.Errorf("%w: push notification url is required", a2a.ErrInvalidParams)
+	}
+	return &a2apb.CreateTaskPushNotificationConfigRequest{
+		Parent:   a2a.TaskName(p.TaskID),
+		ConfigId: p.Config.ID,
+		Config:   a2a.TaskPushConfigToProto(p),
+	}, nil
+}
+
+func getPushConfigRequestToProto(p *a2a.GetTaskPushConfigParams) (*a2apb.GetTaskPushNotificationConfigRequest, error) {
+	if p.TaskID == "" {
+		return nil, fmt.Errorf("%w: task id is required", a2a.ErrInvalidParams)
+	}
+	return &a2apb.GetTaskPushNotificationConfigRequest{Name: a2a.PushConfigName(p.TaskID, p.ConfigID)}, nil
+}
+
+func listPushConfigRequestToProto(p *a2a.ListTaskPushConfigParams) (*a2apb.ListTaskPushNotificationConfigRequest, error) {
+	if p.TaskID == "" {
+		return nil, fmt.Errorf("%w: task id is required", a2a.ErrInvalidParams)
+	}
+	return &a2apb.ListTaskPushNotificationConfigRequest{Parent: a2a.TaskName(p.TaskID)}, nil
+}
+
+func deletePushConfigRequestToProto(p *a2a.DeleteTaskPushConfigParams) (*a2apb.DeleteTaskPushNotificationConfigRequest, error) {
+	if p.TaskID == "" || p.ConfigID == "" {
+		return nil, fmt.Errorf("%w: task id and config id are required", a2a.ErrInvalidParams)
+	}
+	return &a2apb.DeleteTaskPushNotificationConfigRequest{Name: a2a.PushConfigName(p.TaskID, p.ConfigID)}, nil
+}
+
+func historyLengthToProto(length *int) int32 {
+	if length == nil {
+		return 0
+	}
+	return int32(*length)
+}
+
+func metadataToProto(m map[string]any) (*structpb.Struct, error) {
+	if m == nil {
+		return nil, nil
+	}
+	s, err := structpb.NewStruct(m)
+	if err != nil {
+		return nil, fmt.Errorf("%w: metadata: %w", a2a.ErrInvalidParams, err)
+	}
+	return s, nil
+}
+
+func sendMessageParamsFromProto(req *a2apb.SendMessageRequest) (*a2a.MessageSendParams, error) {
+	msg, err := a2a.MessageFromProto(req.GetRequest())
+	if err != nil {
+		return nil, err
+	}
+	params := &a2a.MessageSendParams{Message: msg, Metadata: metadataFromProto(req.GetMetadata())}
+	if c := req.GetConfiguration(); c != nil {
+		params.Config = &a2a.MessageSendConfig{
+			AcceptedOutputModes: c.GetAcceptedOutputModes(),
+			PushConfig:          a2a.PushConfigFromProto(c.GetPushNotification()),
+			HistoryLength:       historyLengthFromProto(c.GetHistoryLength()),
+			Blocking:            c.GetBlocking(),
+		}
+	}
+	return params, nil
+}
+
+func taskQueryParamsFromProto(req *a2apb.GetTaskRequest) (*a2a.TaskQueryParams, error) {
+	taskID, err := a2a.ParseTaskName(req.GetName())
+	if err != nil {
+		return nil, err
+	}
+	return &a2a.TaskQueryParams{ID: taskID, HistoryLength: historyLengthFromProto(req.GetHistoryLength())}, nil
+}
+
+func taskIDParamsFromName(name string) (*a2a.TaskIDParams, error) {
+	taskID, err := a2a.ParseTaskName(name)
+	if err != nil {
+		return nil, err
+	}
+	return &a2a.TaskIDParams{ID: taskID}, nil
+}
+
+func setPushConfigParamsFromProto(req *a2apb.CreateTaskPushNotificationConfigRequest) (*a2a.TaskPushConfig, error) {
+	taskID, err := a2a.ParseTaskName(req.GetParent())
+	if err != nil {
+		return nil, err
+	}
+	config := a2a.PushConfigFromProto(req.GetConfig().GetPushNotificationConfig())
+	if config == nil {
+		return nil, errors.New("push notification config is required")
+	}
+	if req.GetConfigId() != "" {
+		config.ID = req.GetConfigId()
+	}
+	return &a2a.TaskPushConfig{TaskID: taskID, Config: *config}, nil
+}
+
+func getPushConfigParamsFromProto(req *a2apb.GetTaskPushNotificationConfigRequest) (*a2a.GetTaskPushConfigParams, error) {
+	taskID, configID, err := a2a.ParsePushConfigName(req.GetName())
+	if err != nil {
+		return nil, err
+	}
+	return &a2a.GetTaskPushConfigParams{TaskID: taskID, ConfigID: configID}, nil
+}
+
+func listPushConfigParamsFromProto(req *a2apb.ListTaskPushNotificationConfigRequest) (*a2a.ListTaskPushConfigParams, error) {
+	taskID, err := a2a.ParseTaskName(req.GetParent())
+	if err != nil {
+		return nil, err
+	}
+	return &a2a.ListTaskPushConfigParams{TaskID: taskID}, nil
+}
+
+func deletePushConfigParamsFromProto(req *a2apb.DeleteTaskPushNotificationConfigRequest) (*a2a.DeleteTaskPushConfigParams, error) {
+	taskID, configID, err := a2a.ParsePushConfigName(req.GetName())
+	if err != nil {
+		return nil, err
+	}
+	return &a2a.DeleteTaskPushConfigParams{TaskID: taskID, ConfigID: configID}, nil
+}
+
+func historyLengthFromProto(length int32) *int {
+	if length == 0 {
+		return nil
+	}
+	result := int(length)
+	return &result
+}
+
+func metadataFromProto(s *structpb.Struct) map[string]any {
+	if s == nil {
+		return nil
+	}
+	return s.AsMap()
+}
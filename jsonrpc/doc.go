@@ -0,0 +1,27 @@
+// Copyright 2025 The A2A Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package jsonrpc implements the JSON-RPC 2.0 over HTTP binding of the A2A protocol.
+//
+// Handler exposes an A2AServiceServer implementation to JSON-RPC clients. Requests are
+// decoded into the types of package a2a, converted to the gRPC request messages and
+// dispatched to the server. Errors returned by the server are reported using the
+// error codes defined by the A2A specification. Streaming methods (message/stream and
+// tasks/resubscribe) are served using Server-Sent Events, where every event carries
+// a JSON-RPC response with the ID of the request. Requests without an ID are treated as
+// notifications: the method is invoked, but no response body is written.
+//
+// Client implements the A2AServiceClient interface on top of the JSON-RPC binding, so
+// the same calling code can be used with agents exposing either of the transports.
+package jsonrpc
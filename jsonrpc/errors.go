@@ -0,0 +1,82 @@
+// Copyright 2025 The A2A Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jsonrpc
+
+import (
+	"context"
+	"errors"
+	"slices"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/a2aproject/a2a-go/a2a"
+)
+
+var protocolErrors = []struct {
+	code int
+	err  error
+}{
+	{code: CodeTaskNotFound, err: a2a.ErrTaskNotFound},
+	{code: CodeTaskNotCancelable, err: a2a.ErrTaskNotCancelable},
+	{code: CodePushNotificationNotSupported, err: a2a.ErrPushNotificationNotSupported},
+	{code: CodeUnsupportedOperation, err: a2a.ErrUnsupportedOperation},
+	{code: CodeContentTypeNotSupported, err: a2a.ErrUnsupportedContentType},
+	{code: CodeInvalidAgentResponse, err: a2a.ErrInvalidAgentResponse},
+	{code: CodeInvalidParams, err: a2a.ErrInvalidParams},
+	// Listed after ErrInvalidParams, which received errors with the code unwrap to.
+	{code: CodeInvalidParams, err: a2a.ErrPushConfigNotFound},
+}
+
+// grpcCodes is used for errors which are not wrapping any of the a2a errors, but carry
+// a gRPC status, e.g. when an A2AServiceServer proxies calls to a remote gRPC agent.
+// NotFound is only reported as TaskNotFound by the methods looking up a task, see
+// pushConfigMethods.
+var grpcCodes = map[codes.Code]int{
+	codes.NotFound:        CodeTaskNotFound,
+	codes.InvalidArgument: CodeInvalidParams,
+	codes.Unimplemented:   CodeUnsupportedOperation,
+}
+
+// pushConfigMethods look up a push notification config, so a NotFound status means the
+// config does not exist rather than the task.
+var pushConfigMethods = []string{MethodGetPushConfig, MethodDeletePushConfig}
+
+// toJSONRPCError converts an error returned by an A2AServiceServer for the method to a
+// JSON-RPC error object.
+func toJSONRPCError(method string, err error) *Error {
+	var jsonrpcErr *Error
+	if errors.As(err, &jsonrpcErr) {
+		return jsonrpcErr
+	}
+	for _, pe := range protocolErrors {
+		if errors.Is(err, pe.err) {
+			return &Error{Code: pe.code, Message: err.Error()}
+		}
+	}
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return &Error{Code: CodeInternalError, Message: err.Error()}
+	}
+	if st, ok := status.FromError(err); ok {
+		if st.Code() == codes.NotFound && slices.Contains(pushConfigMethods, method) {
+			return &Error{Code: CodeInvalidParams, Message: st.Message()}
+		}
+		if code, ok := grpcCodes[st.Code()]; ok {
+			return &Error{Code: code, Message: st.Message()}
+		}
+		return &Error{Code: CodeInternalError, Message: st.Message()}
+	}
+	return &Error{Code: CodeInternalError, Message: err.Error()}
+}
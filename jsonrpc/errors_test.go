@@ -0,0 +1,89 @@
+// Copyright 2025 The A2A Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jsonrpc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/a2aproject/a2a-go/a2a"
+)
+
+func TestToJSONRPCError(t *testing.T) {
+	tests := []struct {
+		name     string
+		method   string
+		err      error
+		wantCode int
+	}{
+		{name: "task not found", method: MethodGetTask, err: fmt.Errorf("%w: t1", a2a.ErrTaskNotFound), wantCode: CodeTaskNotFound},
+		{name: "task not cancelable", method: MethodCancelTask, err: a2a.ErrTaskNotCancelable, wantCode: CodeTaskNotCancelable},
+		{name: "invalid params", method: MethodSendMessage, err: a2a.ErrInvalidParams, wantCode: CodeInvalidParams},
+		{
+			name:     "push config not found",
+			method:   MethodGetPushConfig,
+			err:      fmt.Errorf("%w: tasks/t1/pushNotificationConfigs/c1", a2a.ErrPushConfigNotFound),
+			wantCode: CodeInvalidParams,
+		},
+		{
+			name:     "task of push config not found",
+			method:   MethodGetPushConfig,
+			err:      fmt.Errorf("%w: t1", a2a.ErrTaskNotFound),
+			wantCode: CodeTaskNotFound,
+		},
+		{name: "NotFound status of task lookup", method: MethodGetTask, err: status.Error(codes.NotFound, "no task"), wantCode: CodeTaskNotFound},
+		{
+			name:     "NotFound status of push config lookup",
+			method:   MethodDeletePushConfig,
+			err:      status.Error(codes.NotFound, "no config"),
+			wantCode: CodeInvalidParams,
+		},
+		{name: "InvalidArgument status", method: MethodSendMessage, err: status.Error(codes.InvalidArgument, "bad"), wantCode: CodeInvalidParams},
+		{name: "other status", method: MethodSendMessage, err: status.Error(codes.Internal, "boom"), wantCode: CodeInternalError},
+		{name: "context canceled", method: MethodSendMessage, err: context.Canceled, wantCode: CodeInternalError},
+		{name: "JSON-RPC error", method: MethodGetTask, err: &Error{Code: CodeMethodNotFound, Message: "x"}, wantCode: CodeMethodNotFound},
+		{name: "other error", method: MethodGetTask, err: errors.New("boom"), wantCode: CodeInternalError},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := toJSONRPCError(tc.method, tc.err); got.Code != tc.wantCode {
+				t.Errorf("toJSONRPCError() = %+v, want code %d", got, tc.wantCode)
+			}
+		})
+	}
+}
+
+func TestErrorUnwrap(t *testing.T) {
+	tests := []struct {
+		code int
+		want error
+	}{
+		{code: CodeTaskNotFound, want: a2a.ErrTaskNotFound},
+		{code: CodeInvalidParams, want: a2a.ErrInvalidParams},
+		{code: CodeInternalError},
+	}
+	for _, tc := range tests {
+		t.Run(fmt.Sprint(tc.code), func(t *testing.T) {
+			if got := (&Error{Code: tc.code}).Unwrap(); got != tc.want {
+				t.Errorf("Unwrap() = %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,95 @@
+// Copyright 2025 The A2A Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jsonrpc
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// Version is the JSON-RPC protocol version used by A2A.
+const Version = "2.0"
+
+// Methods defined by the JSON-RPC binding of the A2A protocol.
+const (
+	MethodSendMessage          = "message/send"
+	MethodSendStreamingMessage = "message/stream"
+	MethodGetTask              = "tasks/get"
+	MethodCancelTask           = "tasks/cancel"
+	MethodResubscribe          = "tasks/resubscribe"
+	MethodSetPushConfig        = "tasks/pushNotificationConfig/set"
+	MethodGetPushConfig        = "tasks/pushNotificationConfig/get"
+	MethodListPushConfig       = "tasks/pushNotificationConfig/list"
+	MethodDeletePushConfig     = "tasks/pushNotificationConfig/delete"
+)
+
+// Error codes defined by JSON-RPC 2.0 and the A2A specification.
+const (
+	CodeParseError                   = -32700
+	CodeInvalidRequest               = -32600
+	CodeMethodNotFound               = -32601
+	CodeInvalidParams                = -32602
+	CodeInternalError                = -32603
+	CodeTaskNotFound                 = -32001
+	CodeTaskNotCancelable            = -32002
+	CodePushNotificationNotSupported = -32003
+	CodeUnsupportedOperation         = -32004
+	CodeContentTypeNotSupported      = -32005
+	CodeInvalidAgentResponse         = -32006
+)
+
+// Error is a JSON-RPC error object.
+type Error struct {
+	// Code identifies the type of the error.
+	Code int `json:"code"`
+	// Message is a short description of the error.
+	Message string `json:"message"`
+	// Data is optional additional information about the error.
+	Data any `json:"data,omitempty"`
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("jsonrpc error %d: %s", e.Code, e.Message)
+}
+
+// Unwrap returns the a2a error corresponding to the error code, so that errors
+// received from a remote agent can be matched using errors.Is(err, a2a.ErrTaskNotFound).
+func (e *Error) Unwrap() error {
+	for _, pe := range protocolErrors {
+		if pe.code == e.Code {
+			return pe.err
+		}
+	}
+	return nil
+}
+
+type request struct {
+	JSONRPC string          `json:"jsonrpc"`
+	Method  string          `json:"method"`
+	Params  json.RawMessage `json:"params,omitempty"`
+	ID      json.RawMessage `json:"id,omitempty"`
+}
+
+// isNotification reports whether the request has no ID. A null ID is not a notification.
+func (r *request) isNotification() bool {
+	return len(r.ID) == 0
+}
+
+type response struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id"`
+	Result  json.RawMessage `json:"result,omitempty"`
+	Error   *Error          `json:"error,omitempty"`
+}
@@ -0,0 +1,429 @@
+// Copyright 2025 The A2A Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jsonrpc
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc"
+
+	"github.com/a2aproject/a2a-go/a2a"
+	a2apb "github.com/a2aproject/a2a-go/grpc"
+)
+
+// DefaultKeepAliveInterval is the default interval between keep-alive comments
+// written to idle SSE streams.
+const DefaultKeepAliveInterval = 15 * time.Second
+
+// DefaultMaxRequestSize is the default limit of the request body size in bytes.
+const DefaultMaxRequestSize = 4 << 20
+
+type methodHandler func(ctx context.Context, params json.RawMessage) (any, error)
+
+// streamMethodHandler validates request params and returns a function which invokes
+// the streaming method of the server.
+type streamMethodHandler func(params json.RawMessage) (streamFunc, error)
+
+type streamFunc func(stream grpc.ServerStreamingServer[a2apb.StreamResponse]) error
+
+// Handler is an http.Handler serving JSON-RPC requests by dispatching them
+// to an A2AServiceServer. Streaming methods are served using Server-Sent Events.
+type Handler struct {
+	srv               a2apb.A2AServiceServer
+	methods           map[string]methodHandler
+	streamMethods     map[string]streamMethodHandler
+	keepAliveInterval time.Duration
+	maxRequestSize    int64
+}
+
+// HandlerOption configures a Handler.
+type HandlerOption func(*Handler)
+
+// WithKeepAliveInterval sets the interval between keep-alive comments written to SSE
+// streams. A non-positive value disables keep-alive.
+func WithKeepAliveInterval(interval time.Duration) HandlerOption {
+	return func(h *Handler) {
+		h.keepAliveInterval = interval
+	}
+}
+
+// WithMaxRequestSize sets the maximum size of a request body in bytes. Larger requests
+// are rejected with an invalid request error. A non-positive value disables the limit.
+func WithMaxRequestSize(size int64) HandlerOption {
+	return func(h *Handler) {
+		h.maxRequestSize = size
+	}
+}
+
+// NewHandler creates a Handler which serves the JSON-RPC binding of the protocol
+// using the provided server implementation.
+func NewHandler(srv a2apb.A2AServiceServer, opts ...HandlerOption) *Handler {
+	h := &Handler{srv: srv, keepAliveInterval: DefaultKeepAliveInterval, maxRequestSize: DefaultMaxRequestSize}
+	h.methods = map[string]methodHandler{
+		MethodSendMessage:      h.onSendMessage,
+		MethodGetTask:          h.onGetTask,
+		MethodCancelTask:       h.onCancelTask,
+		MethodSetPushConfig:    h.onSetPushConfig,
+		MethodGetPushConfig:    h.onGetPushConfig,
+		MethodListPushConfig:   h.onListPushConfig,
+		MethodDeletePushConfig: h.onDeletePushConfig,
+	}
+	h.streamMethods = map[string]streamMethodHandler{
+		MethodSendStreamingMessage: h.onSendStreamingMessage,
+		MethodResubscribe:          h.onResubscribe,
+	}
+	for _, opt := range opts {
+		opt(h)
+	}
+	return h
+}
+
+// ServeHTTP implements http.Handler.
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	body := r.Body
+	if h.maxRequestSize > 0 {
+		body = http.MaxBytesReader(w, r.Body, h.maxRequestSize)
+	}
+	req, jsonrpcErr := readRequest(body)
+	if jsonrpcErr != nil {
+		writeResponse(w, &response{ID: req.ID, Error: jsonrpcErr})
+		return
+	}
+
+	if req.isNotification() {
+		h.serveNotification(r.Context(), req)
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+
+	if prepare, ok := h.streamMethods[req.Method]; ok {
+		run, err := prepare(req.Params)
+		if err != nil {
+			writeResponse(w, &response{ID: req.ID, Error: toJSONRPCError(req.Method, err)})
+			return
+		}
+		h.serveStream(w, r, req, run)
+		return
+	}
+
+	handle, ok := h.methods[req.Method]
+	if !ok {
+		writeResponse(w, &response{ID: req.ID, Error: &Error{Code: CodeMethodNotFound, Message: fmt.Sprintf("method %q not found", req.Method)}})
+		return
+	}
+
+	result, err := handle(r.Context(), req.Params)
+	if err != nil {
+		writeResponse(w, &response{ID: req.ID, Error: toJSONRPCError(req.Method, err)})
+		return
+	}
+	data, err := json.Marshal(result)
+	if err != nil {
+		writeResponse(w, &response{ID: req.ID, Error: &Error{Code: CodeInternalError, Message: fmt.Sprintf("failed to encode result: %v", err)}})
+		return
+	}
+	writeResponse(w, &response{ID: req.ID, Result: data})
+}
+
+// serveNotification invokes the method of a request without an ID. The result and
+// errors are dropped, because notifications must not be answered. Notifications of
+// streaming methods are ignored, since there would be no way to deliver the events.
+func (h *Handler) serveNotification(ctx context.Context, req *request) {
+	if handle, ok := h.methods[req.Method]; ok {
+		_, _ = handle(ctx, req.Params)
+	}
+}
+
+func (h *Handler) serveStream(w http.ResponseWriter, r *http.Request, req *request, run streamFunc) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		writeResponse(w, &response{ID: req.ID, Error: &Error{Code: CodeInternalError, Message: "streaming is not supported by the response writer"}})
+		return
+	}
+
+	ctx, cancel := context.WithCancel(r.Context())
+	stream := newSSEStream(ctx, w, flusher, req.ID)
+
+	var wg sync.WaitGroup
+	if h.keepAliveInterval > 0 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			stream.keepAlive(ctx, h.keepAliveInterval)
+		}()
+	}
+
+	err := run(stream)
+	if err != nil && r.Context().Err() == nil {
+		stream.sendError(toJSONRPCError(req.Method, err))
+	}
+	// The response writer must not be used after ServeHTTP returns.
+	cancel()
+	wg.Wait()
+}
+
+func (h *Handler) onSendMessage(ctx context.Context, raw json.RawMessage) (any, error) {
+	var params a2a.MessageSendParams
+	if err := unmarshalParams(raw, &params); err != nil {
+		return nil, err
+	}
+	req, err := sendMessageRequestToProto(&params)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := h.srv.SendMessage(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	result, err := a2a.SendMessageResultFromProto(resp)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", a2a.ErrInvalidAgentResponse, err)
+	}
+	return result, nil
+}
+
+func (h *Handler) onGetTask(ctx context.Context, raw json.RawMessage) (any, error) {
+	var params a2a.TaskQueryParams
+	if err := unmarshalParams(raw, &params); err != nil {
+		return nil, err
+	}
+	req, err := getTaskRequestToProto(&params)
+	if err != nil {
+		return nil, err
+	}
+	task, err := h.srv.GetTask(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return taskFromProto(task)
+}
+
+func (h *Handler) onCancelTask(ctx context.Context, raw json.RawMessage) (any, error) {
+	var params a2a.TaskIDParams
+	if err := unmarshalParams(raw, &params); err != nil {
+		return nil, err
+	}
+	req, err := cancelTaskRequestToProto(&params)
+	if err != nil {
+		return nil, err
+	}
+	task, err := h.srv.CancelTask(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return taskFromProto(task)
+}
+
+func (h *Handler) onSetPushConfig(ctx context.Context, raw json.RawMessage) (any, error) {
+	var params a2a.TaskPushConfig
+	if err := unmarshalParams(raw, &params); err != nil {
+		return nil, err
+	}
+	req, err := createPushConfigRequestToProto(&params)
+	if err != nil {
+		return nil, err
+	}
+	config, err := h.srv.CreateTaskPushNotificationConfig(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return pushConfigFromProto(config)
+}
+
+func (h *Handler) onGetPushConfig(ctx context.Context, raw json.RawMessage) (any, error) {
+	var params a2a.GetTaskPushConfigParams
+	if err := unmarshalParams(raw, &params); err != nil {
+		return nil, err
+	}
+	req, err := getPushConfigRequestToProto(&params)
+	if err != nil {
+		return nil, err
+	}
+	config, err := h.srv.GetTaskPushNotificationConfig(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return pushConfigFromProto(config)
+}
+
+func (h *Handler) onListPushConfig(ctx context.Context, raw json.RawMessage) (any, error) {
+	var params a2a.ListTaskPushConfigParams
+	if err := unmarshalParams(raw, &params); err != nil {
+		return nil, err
+	}
+	req, err := listPushConfigRequestToProto(&params)
+	if err != nil {
+		return nil, err
+	}
+	// The JSON-RPC binding has no pagination, so all the pages are collected.
+	result := []*a2a.TaskPushConfig{}
+	for {
+		resp, err := h.srv.ListTaskPushNotificationConfig(ctx, req)
+		if err != nil {
+			return nil, err
+		}
+		for _, c := range resp.GetConfigs() {
+			config, err := pushConfigFromProto(c)
+			if err != nil {
+				return nil, err
+			}
+			result = append(result, config)
+		}
+		if resp.GetNextPageToken() == "" {
+			return result, nil
+		}
+		req.PageToken = resp.GetNextPageToken()
+	}
+}
+
+func (h *Handler) onDeletePushConfig(ctx context.Context, raw json.RawMessage) (any, error) {
+	var params a2a.DeleteTaskPushConfigParams
+	if err := unmarshalParams(raw, &params); err != nil {
+		return nil, err
+	}
+	req, err := deletePushConfigRequestToProto(&params)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := h.srv.DeleteTaskPushNotificationConfig(ctx, req); err != nil {
+		return nil, err
+	}
+	return nil, nil
+}
+
+func (h *Handler) onSendStreamingMessage(raw json.RawMessage) (streamFunc, error) {
+	var params a2a.MessageSendParams
+	if err := unmarshalParams(raw, &params); err != nil {
+		return nil, err
+	}
+	req, err := sendMessageRequestToProto(&params)
+	if err != nil {
+		return nil, err
+	}
+	return func(stream grpc.ServerStreamingServer[a2apb.StreamResponse]) error {
+		return h.srv.SendStreamingMessage(req, stream)
+	}, nil
+}
+
+func (h *Handler) onResubscribe(raw json.RawMessage) (streamFunc, error) {
+	var params a2a.TaskIDParams
+	if err := unmarshalParams(raw, &params); err != nil {
+		return nil, err
+	}
+	req, err := taskSubscriptionRequestToProto(&params)
+	if err != nil {
+		return nil, err
+	}
+	return func(stream grpc.ServerStreamingServer[a2apb.StreamResponse]) error {
+		return h.srv.TaskSubscription(req, stream)
+	}, nil
+}
+
+func taskFromProto(t *a2apb.Task) (*a2a.Task, error) {
+	task, err := a2a.TaskFromProto(t)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", a2a.ErrInvalidAgentResponse, err)
+	}
+	return task, nil
+}
+
+func pushConfigFromProto(c *a2apb.TaskPushNotificationConfig) (*a2a.TaskPushConfig, error) {
+	config, err := a2a.TaskPushConfigFromProto(c)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", a2a.ErrInvalidAgentResponse, err)
+	}
+	return config, nil
+}
+
+// readRequest decodes a JSON-RPC request. The returned request is never nil, so its ID
+// can be used in the response even if validation failed.
+func readRequest(body io.Reader) (*request, *Error) {
+	var req request
+	data, err := io.ReadAll(body)
+	if maxBytesErr := (*http.MaxBytesError)(nil); errors.As(err, &maxBytesErr) {
+		return &req, &Error{Code: CodeInvalidRequest, Message: fmt.Sprintf("request body exceeds %d bytes", maxBytesErr.Limit)}
+	}
+	if err != nil {
+		return &req, &Error{Code: CodeParseError, Message: fmt.Sprintf("failed to read request: %v", err)}
+	}
+	if !json.Valid(data) {
+		return &req, &Error{Code: CodeParseError, Message: "request is not a valid JSON"}
+	}
+	if err := json.Unmarshal(data, &req); err != nil {
+		return &req, &Error{Code: CodeInvalidRequest, Message: fmt.Sprintf("invalid request: %v", err)}
+	}
+	if !isValidID(req.ID) {
+		req.ID = nil
+		return &req, &Error{Code: CodeInvalidRequest, Message: "request id must be a string, a number or null"}
+	}
+	if req.JSONRPC != Version {
+		return &req, &Error{Code: CodeInvalidRequest, Message: fmt.Sprintf("unsupported jsonrpc version %q", req.JSONRPC)}
+	}
+	if req.Method == "" {
+		return &req, &Error{Code: CodeInvalidRequest, Message: "method is required"}
+	}
+	return &req, nil
+}
+
+func isValidID(id json.RawMessage) bool {
+	id = bytes.TrimSpace(id)
+	if len(id) == 0 || bytes.Equal(id, []byte("null")) {
+		return true
+	}
+	switch c := id[0]; {
+	case c == '"':
+		return true
+	case c == '-' || (c >= '0' && c <= '9'):
+		return true
+	default:
+		return false
+	}
+}
+
+func unmarshalParams(raw json.RawMessage, params any) error {
+	if len(raw) == 0 {
+		return fmt.Errorf("%w: params are required", a2a.ErrInvalidParams)
+	}
+	if err := json.Unmarshal(raw, params); err != nil {
+		return fmt.Errorf("%w: %w", a2a.ErrInvalidParams, err)
+	}
+	return nil
+}
+
+func writeResponse(w http.ResponseWriter, resp *response) {
+	resp.JSONRPC = Version
+	if len(resp.ID) == 0 {
+		resp.ID = json.RawMessage("null")
+	}
+	if resp.Error == nil && len(resp.Result) == 0 {
+		resp.Result = json.RawMessage("null")
+	}
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(resp)
+}
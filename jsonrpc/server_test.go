@@ -0,0 +1,246 @@
+// Copyright 2025 The A2A Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jsonrpc
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/a2aproject/a2a-go/a2a"
+	a2apb "github.com/a2aproject/a2a-go/grpc"
+)
+
+type fakeServer struct {
+	a2apb.UnimplementedA2AServiceServer
+	cancelCalls atomic.Int32
+}
+
+func (s *fakeServer) GetTask(_ context.Context, req *a2apb.GetTaskRequest) (*a2apb.Task, error) {
+	if req.GetName() == "tasks/missing" {
+		return nil, a2a.ErrTaskNotFound
+	}
+	return &a2apb.Task{Id: strings.TrimPrefix(req.GetName(), "tasks/"), Status: &a2apb.TaskStatus{State: a2apb.TaskState_TASK_STATE_WORKING}}, nil
+}
+
+func (s *fakeServer) CancelTask(_ context.Context, req *a2apb.CancelTaskRequest) (*a2apb.Task, error) {
+	s.cancelCalls.Add(1)
+	return &a2apb.Task{Id: strings.TrimPrefix(req.GetName(), "tasks/"), Status: &a2apb.TaskStatus{State: a2apb.TaskState_TASK_STATE_CANCELLED}}, nil
+}
+
+type testResponse struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id"`
+	Result  json.RawMessage `json:"result"`
+	Error   *Error          `json:"error"`
+}
+
+func serve(t *testing.T, h http.Handler, body string) *httptest.ResponseRecorder {
+	t.Helper()
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/", strings.NewReader(body)))
+	return rec
+}
+
+func TestHandlerRequests(t *testing.T) {
+	tests := []struct {
+		name     string
+		body     string
+		wantID   string
+		wantCode int
+		wantTask string
+	}{
+		{
+			name:     "success",
+			body:     `{"jsonrpc":"2.0","id":1,"method":"tasks/get","params":{"id":"t1"}}`,
+			wantID:   "1",
+			wantTask: "t1",
+		},
+		{
+			name:     "string id",
+			body:     `{"jsonrpc":"2.0","id":"abc","method":"tasks/get","params":{"id":"t1"}}`,
+			wantID:   `"abc"`,
+			wantTask: "t1",
+		},
+		{
+			name:     "null id",
+			body:     `{"jsonrpc":"2.0","id":null,"method":"tasks/get","params":{"id":"t1"}}`,
+			wantID:   "null",
+			wantTask: "t1",
+		},
+		{
+			name:     "invalid json",
+			body:     `{"jsonrpc":"2.0",`,
+			wantID:   "null",
+			wantCode: CodeParseError,
+		},
+		{
+			name:     "not an object",
+			body:     `[1, 2]`,
+			wantID:   "null",
+			wantCode: CodeInvalidRequest,
+		},
+		{
+			name:     "invalid id",
+			body:     `{"jsonrpc":"2.0","id":{"a":1},"method":"tasks/get"}`,
+			wantID:   "null",
+			wantCode: CodeInvalidRequest,
+		},
+		{
+			name:     "unsupported version",
+			body:     `{"jsonrpc":"1.0","id":1,"method":"tasks/get"}`,
+			wantID:   "1",
+			wantCode: CodeInvalidRequest,
+		},
+		{
+			name:     "missing method",
+			body:     `{"jsonrpc":"2.0","id":1}`,
+			wantID:   "1",
+			wantCode: CodeInvalidRequest,
+		},
+		{
+			name:     "unknown method",
+			body:     `{"jsonrpc":"2.0","id":1,"method":"tasks/unknown"}`,
+			wantID:   "1",
+			wantCode: CodeMethodNotFound,
+		},
+		{
+			name:     "missing params",
+			body:     `{"jsonrpc":"2.0","id":1,"method":"tasks/get"}`,
+			wantID:   "1",
+			wantCode: CodeInvalidParams,
+		},
+		{
+			name:     "malformed params",
+			body:     `{"jsonrpc":"2.0","id":1,"method":"tasks/get","params":{"id":5}}`,
+			wantID:   "1",
+			wantCode: CodeInvalidParams,
+		},
+		{
+			name:     "server error",
+			body:     `{"jsonrpc":"2.0","id":1,"method":"tasks/get","params":{"id":"missing"}}`,
+			wantID:   "1",
+			wantCode: CodeTaskNotFound,
+		},
+		{
+			name:     "unimplemented method",
+			body:     `{"jsonrpc":"2.0","id":1,"method":"tasks/pushNotificationConfig/get","params":{"id":"t1","pushNotificationConfigId":"c1"}}`,
+			wantID:   "1",
+			wantCode: CodeUnsupportedOperation,
+		},
+	}
+	h := NewHandler(&fakeServer{})
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			rec := serve(t, h, tc.body)
+			if rec.Code != http.StatusOK {
+				t.Fatalf("status = %d, want %d", rec.Code, http.StatusOK)
+			}
+			var resp testResponse
+			if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil {
+				t.Fatalf("failed to decode response %q: %v", rec.Body.String(), err)
+			}
+			if resp.JSONRPC != Version {
+				t.Errorf("jsonrpc = %q, want %q", resp.JSONRPC, Version)
+			}
+			if string(resp.ID) != tc.wantID {
+				t.Errorf("id = %s, want %s", resp.ID, tc.wantID)
+			}
+			if tc.wantCode != 0 {
+				if resp.Error == nil || resp.Error.Code != tc.wantCode {
+					t.Fatalf("error = %+v, want code %d", resp.Error, tc.wantCode)
+				}
+				if resp.Result != nil {
+					t.Errorf("result = %s, want none along with an error", resp.Result)
+				}
+				return
+			}
+			if resp.Error != nil {
+				t.Fatalf("error = %+v, want none", resp.Error)
+			}
+			var task a2a.Task
+			if err := json.Unmarshal(resp.Result, &task); err != nil {
+				t.Fatalf("failed to decode result %s: %v", resp.Result, err)
+			}
+			if task.ID != tc.wantTask || task.Status.State != a2a.TaskStateWorking {
+				t.Errorf("result = %+v, want working task %q", task, tc.wantTask)
+			}
+		})
+	}
+}
+
+func TestHandlerNotification(t *testing.T) {
+	srv := &fakeServer{}
+	h := NewHandler(srv)
+
+	for _, body := range []string{
+		`{"jsonrpc":"2.0","method":"tasks/cancel","params":{"id":"t1"}}`,
+		`{"jsonrpc":"2.0","method":"tasks/unknown"}`,
+		`{"jsonrpc":"2.0","method":"tasks/get","params":{"id":"missing"}}`,
+		`{"jsonrpc":"2.0","method":"message/stream","params":{}}`,
+	} {
+		rec := serve(t, h, body)
+		if rec.Code != http.StatusNoContent || rec.Body.Len() != 0 {
+			t.Errorf("notification %s got response %d %q, want %d without a body", body, rec.Code, rec.Body.String(), http.StatusNoContent)
+		}
+	}
+	if got := srv.cancelCalls.Load(); got != 1 {
+		t.Errorf("CancelTask() calls = %d, want 1", got)
+	}
+}
+
+func TestHandlerMaxRequestSize(t *testing.T) {
+	body := `{"jsonrpc":"2.0","id":1,"method":"tasks/get","params":{"id":"t1","metadata":{"padding":"` + strings.Repeat("x", 256) + `"}}}`
+	tests := []struct {
+		name     string
+		opts     []HandlerOption
+		wantCode int
+	}{
+		{name: "within limit", opts: []HandlerOption{WithMaxRequestSize(1024)}},
+		{name: "over limit", opts: []HandlerOption{WithMaxRequestSize(64)}, wantCode: CodeInvalidRequest},
+		{name: "no limit", opts: []HandlerOption{WithMaxRequestSize(0)}},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			rec := serve(t, NewHandler(&fakeServer{}, tc.opts...), body)
+			var resp testResponse
+			if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil {
+				t.Fatalf("failed to decode response %q: %v", rec.Body.String(), err)
+			}
+			var gotCode int
+			if resp.Error != nil {
+				gotCode = resp.Error.Code
+			}
+			if gotCode != tc.wantCode {
+				t.Errorf("error = %+v, want code %d", resp.Error, tc.wantCode)
+			}
+		})
+	}
+}
+
+func TestHandlerMethodNotAllowed(t *testing.T) {
+	rec := httptest.NewRecorder()
+	NewHandler(&fakeServer{}).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+	if rec.Code != http.StatusMethodNotAllowed {
+		t.Errorf("status = %d, want %d", rec.Code, http.StatusMethodNotAllowed)
+	}
+	if got := rec.Header().Get("Allow"); got != http.MethodPost {
+		t.Errorf("Allow = %q, want %q", got, http.MethodPost)
+	}
+}
@@ -0,0 +1,195 @@
+// Copyright 2025 The A2A Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jsonrpc
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+
+	"github.com/a2aproject/a2a-go/a2a"
+	a2apb "github.com/a2aproject/a2a-go/grpc"
+)
+
+var (
+	errStreamStarted = errors.New("stream already started")
+	errStreamClosed  = errors.New("stream closed")
+)
+
+// sseStream adapts an HTTP response to grpc.ServerStreamingServer, so that streaming
+// A2AServiceServer methods can be served using Server-Sent Events. Every StreamResponse
+// is written as a "data:" event containing a JSON-RPC response for the request ID.
+// The response headers are written lazily, which allows to report errors happening
+// before the first event as a regular JSON-RPC response.
+type sseStream struct {
+	ctx     context.Context
+	w       http.ResponseWriter
+	flusher http.Flusher
+	id      json.RawMessage
+
+	mu      sync.Mutex
+	started bool
+	err     error
+}
+
+var _ grpc.ServerStreamingServer[a2apb.StreamResponse] = (*sseStream)(nil)
+
+func newSSEStream(ctx context.Context, w http.ResponseWriter, flusher http.Flusher, id json.RawMessage) *sseStream {
+	return &sseStream{ctx: ctx, w: w, flusher: flusher, id: id}
+}
+
+// Send implements grpc.ServerStreamingServer.
+func (s *sseStream) Send(resp *a2apb.StreamResponse) error {
+	event, err := a2a.EventFromProto(resp)
+	if err != nil {
+		return fmt.Errorf("%w: %w", a2a.ErrInvalidAgentResponse, err)
+	}
+	result, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("failed to encode event: %w", err)
+	}
+	return s.writeEvent(&response{ID: s.id, Result: result})
+}
+
+// sendError writes a JSON-RPC error response either as an event if the stream was started,
+// or as a regular response otherwise.
+func (s *sseStream) sendError(jsonrpcErr *Error) {
+	s.mu.Lock()
+	if !s.started {
+		defer s.mu.Unlock()
+		writeResponse(s.w, &response{ID: s.id, Error: jsonrpcErr})
+		s.started, s.err = true, errStreamClosed
+		return
+	}
+	s.mu.Unlock()
+	_ = s.writeEvent(&response{ID: s.id, Error: jsonrpcErr})
+}
+
+func (s *sseStream) writeEvent(resp *response) error {
+	resp.JSONRPC = Version
+	if len(resp.ID) == 0 {
+		resp.ID = json.RawMessage("null")
+	}
+	data, err := json.Marshal(resp)
+	if err != nil {
+		return fmt.Errorf("failed to encode response: %w", err)
+	}
+	return s.write("data: " + string(data) + "\n\n")
+}
+
+func (s *sseStream) writeKeepAlive() error {
+	return s.write(": keep-alive\n\n")
+}
+
+func (s *sseStream) write(chunk string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.err != nil {
+		return s.err
+	}
+	if err := s.ctx.Err(); err != nil {
+		s.err = err
+		return err
+	}
+	if !s.started {
+		s.startLocked()
+	}
+	if _, err := s.w.Write([]byte(chunk)); err != nil {
+		s.err = fmt.Errorf("failed to write event: %w", err)
+		return s.err
+	}
+	s.flusher.Flush()
+	return nil
+}
+
+func (s *sseStream) startLocked() {
+	h := s.w.Header()
+	h.Set("Content-Type", "text/event-stream")
+	h.Set("Cache-Control", "no-cache")
+	h.Set("Connection", "keep-alive")
+	h.Set("X-Accel-Buffering", "no")
+	s.w.WriteHeader(http.StatusOK)
+	s.started = true
+}
+
+// keepAlive periodically writes SSE comments to prevent intermediaries from closing
+// an idle connection. It returns when the context is canceled or a write fails.
+func (s *sseStream) keepAlive(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := s.writeKeepAlive(); err != nil {
+				return
+			}
+		}
+	}
+}
+
+// SetHeader implements grpc.ServerStream. Metadata is sent as HTTP response headers,
+// which is only possible before the first event is written.
+func (s *sseStream) SetHeader(md metadata.MD) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.started {
+		return errStreamStarted
+	}
+	for k, values := range md {
+		for _, v := range values {
+			s.w.Header().Add(k, v)
+		}
+	}
+	return nil
+}
+
+// SendHeader implements grpc.ServerStream.
+func (s *sseStream) SendHeader(md metadata.MD) error {
+	if err := s.SetHeader(md); err != nil {
+		return err
+	}
+	return s.write(": stream started\n\n")
+}
+
+// SetTrailer implements grpc.ServerStream. Trailers are not supported by the binding.
+func (s *sseStream) SetTrailer(metadata.MD) {}
+
+// Context implements grpc.ServerStream. The context is canceled when the client disconnects.
+func (s *sseStream) Context() context.Context {
+	return s.ctx
+}
+
+// SendMsg implements grpc.ServerStream.
+func (s *sseStream) SendMsg(m any) error {
+	resp, ok := m.(*a2apb.StreamResponse)
+	if !ok {
+		return fmt.Errorf("unexpected message type %T", m)
+	}
+	return s.Send(resp)
+}
+
+// RecvMsg implements grpc.ServerStream. Streaming A2A methods are server-streaming only.
+func (s *sseStream) RecvMsg(any) error {
+	return errors.New("client streaming is not supported")
+}
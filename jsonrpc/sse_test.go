@@ -0,0 +1,238 @@
+// Copyright 2025 The A2A Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jsonrpc
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+
+	"github.com/a2aproject/a2a-go/a2a"
+	a2apb "github.com/a2aproject/a2a-go/grpc"
+)
+
+// streamServer streams the events followed by the error to every subscriber.
+type streamServer struct {
+	fakeServer
+	events []*a2apb.StreamResponse
+	delay  time.Duration
+	err    error
+}
+
+func (s *streamServer) SendStreamingMessage(_ *a2apb.SendMessageRequest, stream grpc.ServerStreamingServer[a2apb.StreamResponse]) error {
+	return s.stream(stream)
+}
+
+func (s *streamServer) TaskSubscription(_ *a2apb.TaskSubscriptionRequest, stream grpc.ServerStreamingServer[a2apb.StreamResponse]) error {
+	return s.stream(stream)
+}
+
+func (s *streamServer) stream(stream grpc.ServerStreamingServer[a2apb.StreamResponse]) error {
+	for _, event := range s.events {
+		if err := stream.Send(event); err != nil {
+			return err
+		}
+	}
+	if s.delay > 0 {
+		select {
+		case <-time.After(s.delay):
+		case <-stream.Context().Done():
+			return stream.Context().Err()
+		}
+	}
+	return s.err
+}
+
+func statusUpdate(state a2apb.TaskState, final bool) *a2apb.StreamResponse {
+	return &a2apb.StreamResponse{Payload: &a2apb.StreamResponse_StatusUpdate{StatusUpdate: &a2apb.TaskStatusUpdateEvent{
+		TaskId:    "t1",
+		ContextId: "c1",
+		Status:    &a2apb.TaskStatus{State: state},
+		Final:     final,
+	}}}
+}
+
+// parseSSE splits a response body into the data of the events and the comments.
+func parseSSE(t *testing.T, body string) (events []testResponse, comments []string) {
+	t.Helper()
+	for _, chunk := range strings.Split(strings.TrimSuffix(body, "\n\n"), "\n\n") {
+		switch {
+		case strings.HasPrefix(chunk, ":"):
+			comments = append(comments, chunk)
+		case strings.HasPrefix(chunk, "data: "):
+			var resp testResponse
+			if err := json.Unmarshal([]byte(strings.TrimPrefix(chunk, "data: ")), &resp); err != nil {
+				t.Fatalf("failed to decode event %q: %v", chunk, err)
+			}
+			events = append(events, resp)
+		default:
+			t.Fatalf("unexpected SSE chunk %q", chunk)
+		}
+	}
+	return events, comments
+}
+
+func TestHandlerStream(t *testing.T) {
+	tests := []struct {
+		name       string
+		method     string
+		srv        *streamServer
+		wantKinds  []string
+		wantErrors []int
+	}{
+		{
+			name:      "message stream",
+			method:    MethodSendStreamingMessage,
+			srv:       &streamServer{events: []*a2apb.StreamResponse{statusUpdate(a2apb.TaskState_TASK_STATE_WORKING, false), statusUpdate(a2apb.TaskState_TASK_STATE_COMPLETED, true)}},
+			wantKinds: []string{"status-update", "status-update"},
+		},
+		{
+			name:      "resubscribe",
+			method:    MethodResubscribe,
+			srv:       &streamServer{events: []*a2apb.StreamResponse{{Payload: &a2apb.StreamResponse_Task{Task: &a2apb.Task{Id: "t1", ContextId: "c1"}}}}},
+			wantKinds: []string{"task"},
+		},
+		{
+			name:       "error after events",
+			method:     MethodSendStreamingMessage,
+			srv:        &streamServer{events: []*a2apb.StreamResponse{statusUpdate(a2apb.TaskState_TASK_STATE_WORKING, false)}, err: a2a.ErrUnsupportedOperation},
+			wantKinds:  []string{"status-update", ""},
+			wantErrors: []int{0, CodeUnsupportedOperation},
+		},
+		{
+			name:       "invalid event",
+			method:     MethodSendStreamingMessage,
+			srv:        &streamServer{events: []*a2apb.StreamResponse{statusUpdate(a2apb.TaskState_TASK_STATE_WORKING, false), {}}},
+			wantKinds:  []string{"status-update", ""},
+			wantErrors: []int{0, CodeInvalidAgentResponse},
+		},
+	}
+	params := map[string]string{
+		MethodSendStreamingMessage: `{"message":{"messageId":"m1","role":"user","parts":[{"kind":"text","text":"hi"}]}}`,
+		MethodResubscribe:          `{"id":"t1"}`,
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			body := `{"jsonrpc":"2.0","id":"s1","method":"` + tc.method + `","params":` + params[tc.method] + `}`
+			rec := serve(t, NewHandler(tc.srv), body)
+			if got := rec.Header().Get("Content-Type"); got != "text/event-stream" {
+				t.Fatalf("Content-Type = %q, want text/event-stream", got)
+			}
+			events, _ := parseSSE(t, rec.Body.String())
+			if len(events) != len(tc.wantKinds) {
+				t.Fatalf("got %d events, want %d: %q", len(events), len(tc.wantKinds), rec.Body.String())
+			}
+			for i, event := range events {
+				if string(event.ID) != `"s1"` {
+					t.Errorf("event %d id = %s, want \"s1\"", i, event.ID)
+				}
+				var wantCode int
+				if tc.wantErrors != nil {
+					wantCode = tc.wantErrors[i]
+				}
+				if wantCode != 0 {
+					if event.Error == nil || event.Error.Code != wantCode {
+						t.Errorf("event %d error = %+v, want code %d", i, event.Error, wantCode)
+					}
+					continue
+				}
+				var kind struct {
+					Kind string `json:"kind"`
+				}
+				if err := json.Unmarshal(event.Result, &kind); err != nil || kind.Kind != tc.wantKinds[i] {
+					t.Errorf("event %d = %s, want kind %q", i, event.Result, tc.wantKinds[i])
+				}
+			}
+		})
+	}
+}
+
+func TestHandlerStreamErrorBeforeEvents(t *testing.T) {
+	tests := []struct {
+		name     string
+		srv      *streamServer
+		params   string
+		wantCode int
+	}{
+		{
+			name:     "server error",
+			srv:      &streamServer{err: a2a.ErrTaskNotFound},
+			params:   `{"id":"t1"}`,
+			wantCode: CodeTaskNotFound,
+		},
+		{
+			name:     "invalid params",
+			srv:      &streamServer{},
+			params:   `{"id":1}`,
+			wantCode: CodeInvalidParams,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			body := `{"jsonrpc":"2.0","id":7,"method":"tasks/resubscribe","params":` + tc.params + `}`
+			rec := serve(t, NewHandler(tc.srv), body)
+			if got := rec.Header().Get("Content-Type"); got != "application/json" {
+				t.Fatalf("Content-Type = %q, want application/json", got)
+			}
+			var resp testResponse
+			if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil {
+				t.Fatalf("failed to decode response %q: %v", rec.Body.String(), err)
+			}
+			if string(resp.ID) != "7" || resp.Error == nil || resp.Error.Code != tc.wantCode {
+				t.Errorf("response = %+v, want error %d for id 7", resp, tc.wantCode)
+			}
+		})
+	}
+}
+
+func TestHandlerStreamKeepAlive(t *testing.T) {
+	srv := &streamServer{events: []*a2apb.StreamResponse{statusUpdate(a2apb.TaskState_TASK_STATE_WORKING, false)}, delay: 100 * time.Millisecond}
+	rec := serve(t, NewHandler(srv, WithKeepAliveInterval(10*time.Millisecond)), `{"jsonrpc":"2.0","id":1,"method":"tasks/resubscribe","params":{"id":"t1"}}`)
+	events, comments := parseSSE(t, rec.Body.String())
+	if len(events) != 1 {
+		t.Errorf("got %d events, want 1", len(events))
+	}
+	if len(comments) == 0 {
+		t.Errorf("got no keep-alive comments in %q", rec.Body.String())
+	}
+	for _, c := range comments {
+		if c != ": keep-alive" {
+			t.Errorf("comment = %q, want \": keep-alive\"", c)
+		}
+	}
+}
+
+func TestSSEStreamClosedAfterDisconnect(t *testing.T) {
+	srv := &streamServer{delay: time.Hour}
+	ctx, cancel := context.WithCancel(context.Background())
+	req := httptest.NewRequestWithContext(ctx, http.MethodPost, "/", strings.NewReader(`{"jsonrpc":"2.0","id":1,"method":"tasks/resubscribe","params":{"id":"t1"}}`))
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		NewHandler(srv).ServeHTTP(httptest.NewRecorder(), req)
+	}()
+	cancel()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("ServeHTTP() did not return after the client disconnected")
+	}
+}